@@ -0,0 +1 @@
+DROP INDEX idx_cakes_deleted_at_rating_title_id ON cakes;
@@ -0,0 +1 @@
+CREATE INDEX idx_cakes_deleted_at_rating_title_id ON cakes (deleted_at, rating DESC, title, id);
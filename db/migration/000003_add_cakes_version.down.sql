@@ -0,0 +1 @@
+ALTER TABLE cakes DROP COLUMN version;
@@ -0,0 +1 @@
+ALTER TABLE cakes ADD COLUMN version int NOT NULL DEFAULT 1;
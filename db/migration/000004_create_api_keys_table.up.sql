@@ -0,0 +1,12 @@
+CREATE TABLE api_keys(
+    id int AUTO_INCREMENT PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    prefix varchar(16) NOT NULL,
+    secret_hash char(64) NOT NULL,
+    scopes varchar(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    last_used_at DATETIME,
+    revoked_at DATETIME
+);
+
+CREATE UNIQUE INDEX idx_api_keys_prefix ON api_keys (prefix);
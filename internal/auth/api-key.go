@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/forderation/ralali-test/internal/model"
+)
+
+type apiKeyKey struct{}
+
+// WithApiKey: context carrying api key of request authenticated by X-API-Key header
+func WithApiKey(ctx context.Context, apiKey *model.AuthenticatedApiKey) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, apiKey)
+}
+
+// ApiKeyFromContext: api key put by WithApiKey, false on request which is not authenticated by api key
+func ApiKeyFromContext(ctx context.Context) (*model.AuthenticatedApiKey, bool) {
+	apiKey, ok := ctx.Value(apiKeyKey{}).(*model.AuthenticatedApiKey)
+	return apiKey, ok
+}
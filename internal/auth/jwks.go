@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// jsonWebKey: supported members of JSON Web Key (RFC 7517), n and e for RSA key and k for oct key
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// LoadJWKS: verification keys of local JWKS file, RSA key is used for RS256 and oct key for HS256.
+// encryption key and key of other type are skipped
+func LoadJWKS(path string) ([]verificationKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error read jwks: %w", err)
+	}
+	return parseJWKS(data)
+}
+
+func parseJWKS(data []byte) ([]verificationKey, error) {
+	var keySet jsonWebKeySet
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return nil, fmt.Errorf("error parse jwks: %w", err)
+	}
+	keys := make([]verificationKey, 0, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Use == "enc" {
+			continue
+		}
+		var key verificationKey
+		switch jwk.Kty {
+		case "RSA":
+			publicKey, err := jwk.rsaPublicKey()
+			if err != nil {
+				return nil, fmt.Errorf("error parse jwks key '%s': %w", jwk.Kid, err)
+			}
+			key = verificationKey{kid: jwk.Kid, alg: AlgRS256, key: publicKey}
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+			if err != nil || len(secret) == 0 {
+				return nil, fmt.Errorf("error parse jwks key '%s': invalid k", jwk.Kid)
+			}
+			key = verificationKey{kid: jwk.Kid, alg: AlgHS256, key: secret}
+		default:
+			continue
+		}
+		if jwk.Alg != "" && jwk.Alg != key.alg {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no RS256 or HS256 key")
+	}
+	return keys, nil
+}
+
+func (jwk jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid n")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil || len(e) == 0 {
+		return nil, errors.New("invalid e")
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid e")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
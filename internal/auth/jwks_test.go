@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeJWKS: JWKS file holding keys on temporary directory
+func writeJWKS(t *testing.T, keys ...jsonWebKey) string {
+	t.Helper()
+	data, err := json.Marshal(jsonWebKeySet{Keys: keys})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func rsaJWK(kid string) jsonWebKey {
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Alg: AlgRS256,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(testRSAKey.PublicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(testRSAKey.PublicKey.E)).Bytes()),
+	}
+}
+
+func TestLoadJWKS(t *testing.T) {
+	octKey := jsonWebKey{Kty: "oct", Kid: "oct-1", K: base64.RawURLEncoding.EncodeToString([]byte(testSecret))}
+	tests := []struct {
+		name     string
+		keys     []jsonWebKey
+		wantKids []string
+		wantErr  bool
+	}{
+		{
+			name:     "rsa and oct keys",
+			keys:     []jsonWebKey{rsaJWK("rsa-1"), octKey},
+			wantKids: []string{"rsa-1", "oct-1"},
+		},
+		{
+			name:     "skip encryption and unsupported keys",
+			keys:     []jsonWebKey{{Kty: "RSA", Kid: "enc", Use: "enc"}, {Kty: "EC", Kid: "ec"}, {Kty: "RSA", Kid: "ps", Alg: "PS256", N: rsaJWK("").N, E: rsaJWK("").E}, octKey},
+			wantKids: []string{"oct-1"},
+		},
+		{
+			name:    "without usable key",
+			keys:    []jsonWebKey{{Kty: "EC", Kid: "ec"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid rsa modulus",
+			keys:    []jsonWebKey{{Kty: "RSA", Kid: "rsa-1", N: "!", E: "AQAB"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadJWKS(writeJWKS(t, tt.keys...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadJWKS() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.wantKids) {
+				t.Errorf("LoadJWKS() got %d keys, want %d", len(got), len(tt.wantKids))
+				return
+			}
+			for i, key := range got {
+				if key.kid != tt.wantKids[i] {
+					t.Errorf("LoadJWKS() kid = %v, want %v", key.kid, tt.wantKids[i])
+				}
+			}
+		})
+	}
+	if _, err := LoadJWKS(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("LoadJWKS() error = nil on missing file")
+	}
+}
+
+func TestVerifier_VerifyJWKS(t *testing.T) {
+	verifier, err := NewVerifier(VerifierConfig{JWKSFile: writeJWKS(t, rsaJWK("rsa-1"))})
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "token with kid of key",
+			token: signToken(t, jwt.SigningMethodRS256, testRSAKey, "rsa-1", validClaims()),
+		},
+		{
+			name:  "token without kid",
+			token: signToken(t, jwt.SigningMethodRS256, testRSAKey, "", validClaims()),
+		},
+		{
+			name:    "token with unknown kid",
+			token:   signToken(t, jwt.SigningMethodRS256, testRSAKey, "rsa-2", validClaims()),
+			wantErr: true,
+		},
+		{
+			name:    "hs256 token signed by public key",
+			token:   signToken(t, jwt.SigningMethodHS256, x509PublicKey(t), "rsa-1", validClaims()),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// x509PublicKey: PEM public key as HS256 secret, token of algorithm confusion attack
+func x509PublicKey(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile(writePublicKeyFile(t, &testRSAKey.PublicKey))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	return data
+}
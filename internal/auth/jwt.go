@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+// ErrNoVerificationKey: verifier is configured without any key
+var ErrNoVerificationKey = errors.New("no jwt verification key is configured")
+
+// Claims: claims of verified token, Scope is space separated scopes e.g. "cakes:read cakes:write"
+type Claims struct {
+	jwt.RegisteredClaims
+	Scope string `json:"scope,omitempty"`
+}
+
+// HasScope: scope is listed on scope claim
+func (c *Claims) HasScope(scope string) bool {
+	for _, granted := range strings.Fields(c.Scope) {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifierConfig: keys and expected claims of token, at least one key must be set
+type VerifierConfig struct {
+	// HS256Secret: shared secret of HS256 token
+	HS256Secret string
+	// RS256PublicKeyFile: PEM encoded RSA public key of RS256 token
+	RS256PublicKeyFile string
+	// JWKSFile: local JSON Web Key Set (RFC 7517) holding RSA and / or oct keys, picked by kid header of token
+	JWKSFile string
+	// Issuer, Audience: expected iss and aud claim, skipped when empty
+	Issuer   string
+	Audience string
+	// Leeway: allowed clock skew on exp, nbf and iat
+	Leeway time.Duration
+}
+
+// verificationKey: key of single algorithm, kid is empty for key which is not from JWKS
+type verificationKey struct {
+	kid string
+	alg string
+	key interface{}
+}
+
+// Verifier: verify signature and registered claims of HS256 / RS256 token, exp claim is required
+type Verifier struct {
+	keys    []verificationKey
+	options []jwt.ParserOption
+}
+
+func NewVerifier(config VerifierConfig) (*Verifier, error) {
+	var keys []verificationKey
+	if config.HS256Secret != "" {
+		keys = append(keys, verificationKey{alg: AlgHS256, key: []byte(config.HS256Secret)})
+	}
+	if config.RS256PublicKeyFile != "" {
+		pem, err := os.ReadFile(config.RS256PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error read rs256 public key: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("error parse rs256 public key: %w", err)
+		}
+		keys = append(keys, verificationKey{alg: AlgRS256, key: key})
+	}
+	if config.JWKSFile != "" {
+		jwksKeys, err := LoadJWKS(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, jwksKeys...)
+	}
+	if len(keys) == 0 {
+		return nil, ErrNoVerificationKey
+	}
+	methods := make([]string, 0, 2)
+	for _, alg := range []string{AlgHS256, AlgRS256} {
+		for _, key := range keys {
+			if key.alg == alg {
+				methods = append(methods, alg)
+				break
+			}
+		}
+	}
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+	return &Verifier{keys: keys, options: options}, nil
+}
+
+// Verify: claims of valid token, signing method is limited to algorithm of configured keys
+// so HS256 token cannot be signed by RSA public key
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, v.keyFunc, v.options...)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// keyFunc: keys of token algorithm, key from JWKS is only used when its kid match kid header of token
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	alg := token.Method.Alg()
+	kid, _ := token.Header["kid"].(string)
+	var keySet jwt.VerificationKeySet
+	for _, key := range v.keys {
+		if key.alg != alg || (kid != "" && key.kid != "" && key.kid != kid) {
+			continue
+		}
+		keySet.Keys = append(keySet.Keys, key.key)
+	}
+	if len(keySet.Keys) == 0 {
+		return nil, fmt.Errorf("no %s key with kid '%s'", alg, kid)
+	}
+	return keySet, nil
+}
+
+type claimsKey struct{}
+
+// WithClaims: context carrying claims of authenticated request
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext: claims put by WithClaims, false on unauthenticated request
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+// testRSAKey: signing key of RS256 token on tests
+var testRSAKey = mustRSAKey()
+
+func mustRSAKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// signToken: token signed by key, kid header is set when it is not empty
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func validClaims() Claims {
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    "ralali",
+			Audience:  jwt.ClaimStrings{"cakes"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Scope: "cakes:write",
+	}
+}
+
+func writePublicKeyFile(t *testing.T, key *rsa.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "public.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestNewVerifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  VerifierConfig
+		wantErr bool
+	}{
+		{
+			name:   "hs256 secret",
+			config: VerifierConfig{HS256Secret: testSecret},
+		},
+		{
+			name:   "rs256 public key file",
+			config: VerifierConfig{RS256PublicKeyFile: writePublicKeyFile(t, &testRSAKey.PublicKey)},
+		},
+		{
+			name:    "without key",
+			config:  VerifierConfig{Issuer: "ralali"},
+			wantErr: true,
+		},
+		{
+			name:    "missing public key file",
+			config:  VerifierConfig{RS256PublicKeyFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVerifier(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewVerifier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := NewVerifier(VerifierConfig{}); !errors.Is(err, ErrNoVerificationKey) {
+		t.Errorf("NewVerifier() error = %v, want %v", err, ErrNoVerificationKey)
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	otherKey := mustRSAKey()
+	verifier, err := NewVerifier(VerifierConfig{
+		HS256Secret:        testSecret,
+		RS256PublicKeyFile: writePublicKeyFile(t, &testRSAKey.PublicKey),
+		Issuer:             "ralali",
+		Audience:           "cakes",
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	withoutExp := validClaims()
+	withoutExp.ExpiresAt = nil
+	otherIssuer := validClaims()
+	otherIssuer.Issuer = "other"
+	otherAudience := validClaims()
+	otherAudience.Audience = jwt.ClaimStrings{"other"}
+	tests := []struct {
+		name      string
+		token     string
+		wantScope string
+		wantErr   bool
+	}{
+		{
+			name:      "hs256 token",
+			token:     signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()),
+			wantScope: "cakes:write",
+		},
+		{
+			name:      "rs256 token",
+			token:     signToken(t, jwt.SigningMethodRS256, testRSAKey, "", validClaims()),
+			wantScope: "cakes:write",
+		},
+		{
+			name:    "hs256 token of other secret",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte("other-secret"), "", validClaims()),
+			wantErr: true,
+		},
+		{
+			name:    "rs256 token of other key",
+			token:   signToken(t, jwt.SigningMethodRS256, otherKey, "", validClaims()),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported algorithm",
+			token:   signToken(t, jwt.SigningMethodHS512, []byte(testSecret), "", validClaims()),
+			wantErr: true,
+		},
+		{
+			name:    "unsigned token",
+			token:   signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims()),
+			wantErr: true,
+		},
+		{
+			name:    "expired token",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", expired),
+			wantErr: true,
+		},
+		{
+			name:    "token without exp",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", withoutExp),
+			wantErr: true,
+		},
+		{
+			name:    "other issuer",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", otherIssuer),
+			wantErr: true,
+		},
+		{
+			name:    "other audience",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", otherAudience),
+			wantErr: true,
+		},
+		{
+			name:    "malformed token",
+			token:   "not-a-token",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.Verify(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Scope != tt.wantScope {
+				t.Errorf("Verify() scope = %v, want %v", got.Scope, tt.wantScope)
+			}
+		})
+	}
+}
+
+func TestVerifier_VerifyLeeway(t *testing.T) {
+	verifier, err := NewVerifier(VerifierConfig{HS256Secret: testSecret, Leeway: time.Minute})
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	claims := validClaims()
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-30 * time.Second))
+	if _, err := verifier.Verify(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims)); err != nil {
+		t.Errorf("Verify() error = %v, want token expired within leeway to be valid", err)
+	}
+}
+
+func TestClaimsFromContext(t *testing.T) {
+	if _, ok := ClaimsFromContext(context.Background()); ok {
+		t.Errorf("ClaimsFromContext() ok = true on context without claims")
+	}
+	claims := validClaims()
+	got, ok := ClaimsFromContext(WithClaims(context.Background(), &claims))
+	if !ok || got != &claims {
+		t.Errorf("ClaimsFromContext() = %v, %v, want %v, true", got, ok, &claims)
+	}
+}
+
+func TestClaims_HasScope(t *testing.T) {
+	claims := Claims{Scope: "cakes:read  api_keys:admin"}
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{scope: "cakes:read", want: true},
+		{scope: "api_keys:admin", want: true},
+		{scope: "cakes:write", want: false},
+		{scope: "cakes", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			if got := claims.HasScope(tt.scope); got != tt.want {
+				t.Errorf("Claims.HasScope(%v) = %v, want %v", tt.scope, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package delivery
+
+import (
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// APIVersion1: response shape of /v1 and deprecated unversioned routes
+	APIVersion1 = 1
+	// APIVersion2: every success response is wrapped on data (see model.CakeV2Response)
+	APIVersion2 = 2
+
+	// apiVersionKey: key of response shape version on gin context
+	apiVersionKey = "api_version"
+)
+
+// APIVersionMiddleware: set response shape of cake handlers, the same handler serve every version
+func APIVersionMiddleware(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiVersionKey, version)
+		c.Next()
+	}
+}
+
+// apiVersion: version set by APIVersionMiddleware, APIVersion1 when the middleware is not used
+func apiVersion(c *gin.Context) int {
+	if version := c.GetInt(apiVersionKey); version != 0 {
+		return version
+	}
+	return APIVersion1
+}
+
+// cakeBody: response body of single cake by API version, timestamps are on time zone of request
+func cakeBody(c *gin.Context, cake model.CakeResponse) interface{} {
+	if apiVersion(c) == APIVersion2 {
+		return model.CakeV2DataResponse{Data: cakeV2(cake, timeZone(c))}
+	}
+	return zonedCake(c, cake)
+}
+
+// cakesBody: response body of cakes listing by API version
+func cakesBody(c *gin.Context, response model.GetCakesResponse) interface{} {
+	if apiVersion(c) != APIVersion2 {
+		for i := range response.Data {
+			response.Data[i] = zonedCake(c, response.Data[i])
+		}
+		return response
+	}
+	body := model.CakesV2Response{
+		Data: make([]model.CakeV2Response, 0, len(response.Data)),
+		Meta: model.MetaV2Pagination{
+			PageCount:  response.Meta.PageCount,
+			TotalCount: response.Meta.TotalData,
+			NextCursor: response.Meta.NextCursor,
+			PrevCursor: response.Meta.PrevCursor,
+		},
+	}
+	for _, cake := range response.Data {
+		body.Data = append(body.Data, cakeV2(cake, timeZone(c)))
+	}
+	return body
+}
+
+// cakeDeleteBody: response body of deleted cake by API version
+func cakeDeleteBody(c *gin.Context, response model.CakeDeleteResponse) interface{} {
+	response.DeletedAt = zonedTime(response.DeletedAt, timeZone(c))
+	if apiVersion(c) == APIVersion2 {
+		return model.CakeDeleteV2Response{Data: response}
+	}
+	return response
+}
+
+// batchBody: response body of batch operations by API version
+func batchBody(c *gin.Context, response model.BatchCakesResponse) interface{} {
+	if apiVersion(c) != APIVersion2 {
+		for i := range response.Results {
+			if response.Results[i].Cake != nil {
+				cake := zonedCake(c, *response.Results[i].Cake)
+				response.Results[i].Cake = &cake
+			}
+		}
+		return response
+	}
+	body := model.BatchCakesV2Response{
+		Data: make([]model.BatchCakeV2Result, 0, len(response.Results)),
+		Meta: model.MetaV2Batch{
+			Succeeded: response.Succeeded,
+			Failed:    response.Failed,
+		},
+	}
+	for _, result := range response.Results {
+		item := model.BatchCakeV2Result{
+			Index:        result.Index,
+			Op:           result.Op,
+			Status:       result.Status,
+			ID:           result.ID,
+			ErrorCode:    result.ErrorCode,
+			ErrorMessage: result.ErrorMessage,
+			Errors:       result.Errors,
+		}
+		if result.Cake != nil {
+			cake := cakeV2(*result.Cake, timeZone(c))
+			item.Cake = &cake
+		}
+		body.Data = append(body.Data, item)
+	}
+	return body
+}
+
+// importBody: response body of import report by API version
+func importBody(c *gin.Context, response model.ImportCakesResponse) interface{} {
+	if apiVersion(c) == APIVersion2 {
+		return model.ImportCakesV2Response{Data: response}
+	}
+	return response
+}
+
+func cakeV2(cake model.CakeResponse, location *time.Location) model.CakeV2Response {
+	response := model.CakeV2Response{
+		ID:          cake.ID,
+		Title:       cake.Title,
+		Description: cake.Description,
+		Rating:      cake.Rating,
+		Image:       cake.Image,
+		Images:      cake.Images,
+		CreatedAt:   zonedTime(cake.CreatedAt, location),
+		UpdatedAt:   zonedTime(cake.UpdatedAt, location),
+		Version:     cake.Version,
+	}
+	if cake.DeletedAt != nil {
+		deletedAt := zonedTime(*cake.DeletedAt, location)
+		response.DeletedAt = &deletedAt
+	}
+	return response
+}
@@ -0,0 +1,171 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	updatedAt := time.Date(2023, 1, 3, 8, 4, 5, 0, time.UTC)
+	cake := model.CakeResponse{
+		ID:        1,
+		Title:     "title",
+		Rating:    4.5,
+		CreatedAt: "2023-01-02T08:04:05Z",
+		UpdatedAt: "2023-01-03T08:04:05Z",
+		Version:   2,
+	}
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return &cake, nil
+	}
+	mockCakeUsecase.GetCakesFunc = func(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+		return &model.GetCakesResponse{
+			Meta: model.MetaPagination{PageCount: 1, TotalData: 1},
+			Data: []model.CakeResponse{cake},
+		}, nil
+	}
+	mockCakeUsecase.GetCakesModifiedFunc = func(ctx context.Context, filter model.CakesFilterQuery) (*model.CakesModified, *model.ErrorResponse) {
+		return &model.CakesModified{Count: 1, VersionSum: 2, ModifiedAt: updatedAt}, nil
+	}
+	mockCakeUsecase.DeleteCakeFunc = func(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
+		return &model.CakeDeleteResponse{ID: id, DeletedAt: "2023-01-04T08:04:05Z"}, nil
+	}
+	mockCakeUsecase.BatchCakesFunc = func(ctx context.Context, param model.BatchCakesUsecaseParam) (*model.BatchCakesResponse, *model.ErrorResponse) {
+		return &model.BatchCakesResponse{
+			Succeeded: 1,
+			Results:   []model.BatchCakeResult{{Index: 0, Op: model.BatchOpCreate, ID: 1, Cake: &cake}},
+		}, nil
+	}
+	mockCakeUsecase.ImportCakesFunc = func(ctx context.Context, param model.ImportCakesUsecaseParam) (*model.ImportCakesResponse, *model.ErrorResponse) {
+		return &model.ImportCakesResponse{DryRun: true, Errors: []model.ImportCakeError{}}, nil
+	}
+	mockCakeUsecase.ExportCakesFunc = func(ctx context.Context, param model.ExportCakesUsecaseParam, fn func(cake model.CakeResponse) error) *model.ErrorResponse {
+		if err := fn(cake); err != nil {
+			return &model.ErrorResponse{Kind: model.ErrInternal, Err: err, Code: model.ErrCodeExportCakesFailed}
+		}
+		return nil
+	}
+	d := NewCakeDelivery(mockCakeUsecase, 0, nil)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	for _, group := range []*gin.RouterGroup{
+		router.Group("/cakes"),
+		router.Group("/v1/cakes", APIVersionMiddleware(APIVersion1)),
+		router.Group("/v2/cakes", APIVersionMiddleware(APIVersion2)),
+	} {
+		group.GET("", d.GetCakes)
+		group.GET("/:id", d.GetCake)
+		group.DELETE("/:id", d.DeleteCake)
+		group.POST("/batch", d.BatchCakes)
+		group.POST("/import", d.ImportCakes)
+		group.GET("/export", d.ExportCakes)
+	}
+	v1Cake := `{"id":1,"title":"title","description":null,"rating":4.5,"image":null,"images":null,"created_at":"2023-01-02T08:04:05Z","updated_at":"2023-01-03T08:04:05Z","version":2}`
+	v2Cake := v1Cake
+	batchBody := `{"operations":[{"op":"create","data":{"title":"title","rating":4.5}}]}`
+	importBody := "title,description,rating,image\n"
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantBody string
+	}{
+		{
+			name:     "unversioned cake",
+			method:   http.MethodGet,
+			path:     "/cakes/1",
+			wantBody: v1Cake,
+		},
+		{
+			name:     "v1 cake",
+			method:   http.MethodGet,
+			path:     "/v1/cakes/1",
+			wantBody: v1Cake,
+		},
+		{
+			name:     "v2 cake",
+			method:   http.MethodGet,
+			path:     "/v2/cakes/1",
+			wantBody: `{"data":` + v2Cake + `}`,
+		},
+		{
+			name:     "v1 cakes",
+			method:   http.MethodGet,
+			path:     "/v1/cakes?page_size=10",
+			wantBody: `{"meta":{"page_count":1,"total_data":1},"cakes":[` + v1Cake + `]}`,
+		},
+		{
+			name:     "v2 cakes",
+			method:   http.MethodGet,
+			path:     "/v2/cakes?page_size=10",
+			wantBody: `{"data":[` + v2Cake + `],"meta":{"page_count":1,"total_count":1,"next_cursor":null,"prev_cursor":null}}`,
+		},
+		{
+			name:     "v1 delete",
+			method:   http.MethodDelete,
+			path:     "/v1/cakes/1",
+			wantBody: `{"id":1,"deleted_at":"2023-01-04T08:04:05Z"}`,
+		},
+		{
+			name:     "v2 delete",
+			method:   http.MethodDelete,
+			path:     "/v2/cakes/1",
+			wantBody: `{"data":{"id":1,"deleted_at":"2023-01-04T08:04:05Z"}}`,
+		},
+		{
+			name:     "v1 batch",
+			method:   http.MethodPost,
+			path:     "/v1/cakes/batch",
+			body:     batchBody,
+			wantBody: `{"succeeded":1,"failed":0,"results":[{"index":0,"op":"create","status":201,"id":1,"cake":` + v1Cake + `}]}`,
+		},
+		{
+			name:     "v2 batch",
+			method:   http.MethodPost,
+			path:     "/v2/cakes/batch",
+			body:     batchBody,
+			wantBody: `{"data":[{"index":0,"op":"create","status":201,"id":1,"cake":` + v2Cake + `}],"meta":{"succeeded":1,"failed":0}}`,
+		},
+		{
+			name:     "v1 import",
+			method:   http.MethodPost,
+			path:     "/v1/cakes/import?format=csv&dry_run=true",
+			body:     importBody,
+			wantBody: `{"dry_run":true,"total_rows":0,"valid_rows":0,"imported_rows":0,"rejected_rows":0,"errors":[],"errors_truncated":false}`,
+		},
+		{
+			name:     "v2 import",
+			method:   http.MethodPost,
+			path:     "/v2/cakes/import?format=csv&dry_run=true",
+			body:     importBody,
+			wantBody: `{"data":{"dry_run":true,"total_rows":0,"valid_rows":0,"imported_rows":0,"rejected_rows":0,"errors":[],"errors_truncated":false}}`,
+		},
+		{
+			name:     "v2 export",
+			method:   http.MethodGet,
+			path:     "/v2/cakes/export?format=ndjson",
+			wantBody: v2Cake,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("If-Match", `"2"`)
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
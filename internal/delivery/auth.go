@@ -0,0 +1,146 @@
+package delivery
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/auth"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// authEnabledKey: key on gin context marking the request pass through auth middleware with verifier
+const authEnabledKey = "auth_enabled"
+
+// AuthMiddleware: verify bearer token (see auth.Verifier) and put its claims into request context,
+// token is required on every method except GET, HEAD and OPTIONS. request authenticated by ApiKeyMiddleware
+// does not need token. nil verifier disable authentication
+func AuthMiddleware(verifier *auth.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authenticate(c, verifier, !safeMethod(c.Request.Method)) {
+			c.Next()
+		}
+	}
+}
+
+// OptionalAuthMiddleware: same as AuthMiddleware but token is never required, handler decide it by authRequired
+// e.g. GraphQL only require token on mutation
+func OptionalAuthMiddleware(verifier *auth.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authenticate(c, verifier, false) {
+			c.Next()
+		}
+	}
+}
+
+// AdminAuthMiddleware: token holding scope is required on every method, api key is not accepted.
+// nil verifier reject every request since admin routes cannot be public
+func AdminAuthMiddleware(verifier *auth.Verifier, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if verifier == nil {
+			unauthorizedResponse(c)
+			c.Abort()
+			return
+		}
+		if !authenticate(c, verifier, true) {
+			return
+		}
+		claims, ok := auth.ClaimsFromContext(c.Request.Context())
+		if !ok {
+			unauthorizedResponse(c)
+			c.Abort()
+			return
+		}
+		if !claims.HasScope(scope) {
+			c.Header("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+			errorResponse(c, http.StatusForbidden, model.ErrCodeInsufficientScope, map[string]interface{}{"scope": scope}, nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireScope: request authenticated by api key must be granted scope, bearer token and anonymous request
+// are not limited by scope
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !scopeGranted(c, scope) {
+			errorResponse(c, http.StatusForbidden, model.ErrCodeInsufficientScope, map[string]interface{}{"scope": scope}, nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// authenticate: token which is sent is always verified, even on request which does not require it.
+// false when error response is written and request is aborted
+func authenticate(c *gin.Context, verifier *auth.Verifier, required bool) bool {
+	if verifier == nil {
+		return true
+	}
+	c.Set(authEnabledKey, true)
+	if _, ok := auth.ApiKeyFromContext(c.Request.Context()); ok {
+		return true
+	}
+	token, ok := bearerToken(c.GetHeader("Authorization"))
+	if !ok {
+		if required {
+			unauthorizedResponse(c)
+			c.Abort()
+			return false
+		}
+		return true
+	}
+	claims, err := verifier.Verify(token)
+	if err != nil {
+		c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+		errorResponse(c, http.StatusUnauthorized, model.ErrCodeInvalidToken, map[string]interface{}{"detail": err.Error()}, nil)
+		c.Abort()
+		return false
+	}
+	c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
+	return true
+}
+
+// authRequired: request pass through auth middleware with verifier but carry no valid token or api key
+func authRequired(c *gin.Context) bool {
+	if !c.GetBool(authEnabledKey) {
+		return false
+	}
+	if _, ok := auth.ApiKeyFromContext(c.Request.Context()); ok {
+		return false
+	}
+	_, ok := auth.ClaimsFromContext(c.Request.Context())
+	return !ok
+}
+
+// scopeGranted: false only when request is authenticated by api key without scope
+func scopeGranted(c *gin.Context, scope string) bool {
+	apiKey, ok := auth.ApiKeyFromContext(c.Request.Context())
+	return !ok || apiKey.HasScope(scope)
+}
+
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func unauthorizedResponse(c *gin.Context) {
+	c.Header("WWW-Authenticate", "Bearer")
+	errorResponse(c, http.StatusUnauthorized, model.ErrCodeUnauthorized, nil, nil)
+}
+
+// bearerToken: token of Authorization header with Bearer scheme (case insensitive), false on other scheme
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,331 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/auth"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const testJWTSecret = "test-secret"
+
+// newTestVerifier: verifier of HS256 token signed by testJWTSecret
+func newTestVerifier(t *testing.T) *auth.Verifier {
+	verifier, err := auth.NewVerifier(auth.VerifierConfig{HS256Secret: testJWTSecret})
+	if err != nil {
+		t.Fatalf("auth.NewVerifier() error = %v", err)
+	}
+	return verifier
+}
+
+// signTestToken: HS256 token of subject expiring after expiresIn, negative expiresIn give expired token
+func signTestToken(t *testing.T, secret string, subject string, expiresIn time.Duration) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return token
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var gotSubject string
+	handler := func(c *gin.Context) {
+		gotSubject = ""
+		if claims, ok := auth.ClaimsFromContext(c.Request.Context()); ok {
+			gotSubject = claims.Subject
+		}
+		c.Status(http.StatusOK)
+	}
+	router := gin.New()
+	cakeRoutes := router.Group("/v1/cakes", AuthMiddleware(newTestVerifier(t)))
+	cakeRoutes.GET("", handler)
+	cakeRoutes.POST("", handler)
+	cakeRoutes.DELETE("/:id", handler)
+	publicRoutes := router.Group("/public/cakes", AuthMiddleware(nil))
+	publicRoutes.POST("", handler)
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		authorization    string
+		wantCode         int
+		wantSubject      string
+		wantErrCode      string
+		wantAuthenticate string
+	}{
+		{
+			name:     "get without token",
+			method:   http.MethodGet,
+			path:     "/v1/cakes",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:          "get with token",
+			method:        http.MethodGet,
+			path:          "/v1/cakes",
+			authorization: "Bearer " + signTestToken(t, testJWTSecret, "user-1", time.Hour),
+			wantCode:      http.StatusOK,
+			wantSubject:   "user-1",
+		},
+		{
+			name:             "post without token",
+			method:           http.MethodPost,
+			path:             "/v1/cakes",
+			wantCode:         http.StatusUnauthorized,
+			wantErrCode:      model.ErrCodeUnauthorized,
+			wantAuthenticate: "Bearer",
+		},
+		{
+			name:             "post with other scheme",
+			method:           http.MethodPost,
+			path:             "/v1/cakes",
+			authorization:    "Basic dXNlcjpwYXNz",
+			wantCode:         http.StatusUnauthorized,
+			wantErrCode:      model.ErrCodeUnauthorized,
+			wantAuthenticate: "Bearer",
+		},
+		{
+			name:          "post with token",
+			method:        http.MethodPost,
+			path:          "/v1/cakes",
+			authorization: "bearer " + signTestToken(t, testJWTSecret, "user-1", time.Hour),
+			wantCode:      http.StatusOK,
+			wantSubject:   "user-1",
+		},
+		{
+			name:             "delete with expired token",
+			method:           http.MethodDelete,
+			path:             "/v1/cakes/1",
+			authorization:    "Bearer " + signTestToken(t, testJWTSecret, "user-1", -time.Hour),
+			wantCode:         http.StatusUnauthorized,
+			wantErrCode:      model.ErrCodeInvalidToken,
+			wantAuthenticate: `Bearer error="invalid_token"`,
+		},
+		{
+			name:             "get with token of other secret",
+			method:           http.MethodGet,
+			path:             "/v1/cakes",
+			authorization:    "Bearer " + signTestToken(t, "other-secret", "user-1", time.Hour),
+			wantCode:         http.StatusUnauthorized,
+			wantErrCode:      model.ErrCodeInvalidToken,
+			wantAuthenticate: `Bearer error="invalid_token"`,
+		},
+		{
+			name:     "authentication disabled",
+			method:   http.MethodPost,
+			path:     "/public/cakes",
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			assert.EqualValues(t, tt.wantAuthenticate, w.Header().Get("WWW-Authenticate"))
+			if tt.wantErrCode != "" {
+				var problem model.ProblemDetails
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+				assert.EqualValues(t, "about:blank", problem.Type)
+				assert.Contains(t, w.Body.String(), tt.wantErrCode)
+				return
+			}
+			assert.EqualValues(t, tt.wantSubject, gotSubject)
+		})
+	}
+}
+
+func TestOptionalAuthMiddleware_graphqlMutation(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{ID: id}, nil
+	}
+	mockCakeUsecase.RestoreCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{ID: id}, nil
+	}
+	d := NewGraphqlDelivery(mockCakeUsecase, nil, 1000)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/graphql", OptionalAuthMiddleware(newTestVerifier(t)), d.Query)
+	tests := []struct {
+		name          string
+		query         string
+		authorization string
+		wantCode      int
+		wantErrCode   string
+	}{
+		{
+			name:     "query without token",
+			query:    `{ cake(id: 1) { id } }`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:        "mutation without token",
+			query:       `mutation { restoreCake(id: 1) { id } }`,
+			wantCode:    http.StatusUnauthorized,
+			wantErrCode: model.ErrCodeUnauthorized,
+		},
+		{
+			name:          "mutation with token",
+			query:         `mutation { restoreCake(id: 1) { id } }`,
+			authorization: "Bearer " + signTestToken(t, testJWTSecret, "user-1", time.Hour),
+			wantCode:      http.StatusOK,
+		},
+		{
+			name:          "query with invalid token",
+			query:         `{ cake(id: 1) { id } }`,
+			authorization: "Bearer invalid",
+			wantCode:      http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]interface{}{"query": tt.query})
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			if tt.wantErrCode != "" {
+				var response graphqlTestResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				if assert.Len(t, response.Errors, 1) {
+					assert.EqualValues(t, tt.wantErrCode, response.Errors[0].Extensions.Code)
+				}
+			}
+		})
+	}
+}
+
+// signTestScopedToken: HS256 token signed by testJWTSecret holding scope claim
+func signTestScopedToken(t *testing.T, scope string) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "admin-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Scope: scope,
+	}).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return token
+}
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router := gin.New()
+	router.GET("/admin/api-keys", NewApiKeyDelivery(newTestApiKeyUsecase()).ApiKeyMiddleware(), AdminAuthMiddleware(newTestVerifier(t), model.ScopeApiKeysAdmin), handler)
+	router.GET("/disabled/api-keys", AdminAuthMiddleware(nil, model.ScopeApiKeysAdmin), handler)
+	tests := []struct {
+		name             string
+		path             string
+		authorization    string
+		apiKey           string
+		wantCode         int
+		wantErrCode      string
+		wantAuthenticate string
+	}{
+		{
+			name:          "token with admin scope",
+			path:          "/admin/api-keys",
+			authorization: "Bearer " + signTestScopedToken(t, "cakes:read api_keys:admin"),
+			wantCode:      http.StatusOK,
+		},
+		{
+			name:             "get without token",
+			path:             "/admin/api-keys",
+			wantCode:         http.StatusUnauthorized,
+			wantErrCode:      model.ErrCodeUnauthorized,
+			wantAuthenticate: "Bearer",
+		},
+		{
+			name:             "token without admin scope",
+			path:             "/admin/api-keys",
+			authorization:    "Bearer " + signTestScopedToken(t, "cakes:read"),
+			wantCode:         http.StatusForbidden,
+			wantErrCode:      model.ErrCodeInsufficientScope,
+			wantAuthenticate: `Bearer error="insufficient_scope", scope="api_keys:admin"`,
+		},
+		{
+			name:             "api key is not accepted",
+			path:             "/admin/api-keys",
+			apiKey:           "write-key",
+			wantCode:         http.StatusUnauthorized,
+			wantErrCode:      model.ErrCodeUnauthorized,
+			wantAuthenticate: "Bearer",
+		},
+		{
+			name:             "authentication disabled",
+			path:             "/disabled/api-keys",
+			authorization:    "Bearer " + signTestScopedToken(t, "api_keys:admin"),
+			wantCode:         http.StatusUnauthorized,
+			wantErrCode:      model.ErrCodeUnauthorized,
+			wantAuthenticate: "Bearer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set(apiKeyHeader, tt.apiKey)
+			}
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			assert.EqualValues(t, tt.wantAuthenticate, w.Header().Get("WWW-Authenticate"))
+			if tt.wantErrCode != "" {
+				assert.Contains(t, w.Body.String(), tt.wantErrCode)
+			}
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantToken string
+		wantOk    bool
+	}{
+		{name: "bearer token", header: "Bearer abc", wantToken: "abc", wantOk: true},
+		{name: "case insensitive scheme", header: "BEARER abc", wantToken: "abc", wantOk: true},
+		{name: "empty header", header: ""},
+		{name: "empty token", header: "Bearer  "},
+		{name: "other scheme", header: "Basic abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, ok := bearerToken(tt.header)
+			assert.EqualValues(t, tt.wantToken, token)
+			assert.EqualValues(t, tt.wantOk, ok)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ErrorFormatProblem: RFC 7807 problem details (default)
+	ErrorFormatProblem = "problem"
+	// ErrorFormatLegacy: model.JsonErrorResp, kept for existing clients
+	ErrorFormatLegacy = "legacy"
+
+	problemContentType = "application/problem+json"
+	// errorFormatKey: key of error response format on gin context
+	errorFormatKey = "error_format"
+)
+
+type errorFormat struct {
+	legacy bool
+	// problemTypeBaseURL: problem type is problemTypeBaseURL + error code, empty use about:blank
+	problemTypeBaseURL string
+}
+
+// ErrorFormatMiddleware: set format of error response, problem details is used unless format is legacy
+func ErrorFormatMiddleware(format string, problemTypeBaseURL string) gin.HandlerFunc {
+	f := errorFormat{
+		legacy:             format == ErrorFormatLegacy,
+		problemTypeBaseURL: problemTypeBaseURL,
+	}
+	return func(c *gin.Context) {
+		c.Set(errorFormatKey, f)
+		c.Next()
+	}
+}
+
+// errorResponse: central error mapper, write error of code with message translated into request language.
+// errData holding invalid fields is written as errors member of problem details, other data as data member
+func errorResponse(c *gin.Context, status int, code string, params map[string]interface{}, errData interface{}) {
+	writeError(c, status, code, i18n.Message(language(c), code, params), errData)
+}
+
+// usecaseErrorResponse: map error returned by usecase, raw error detail (e.g. driver message) is only logged
+// and never written to client. error without code keep its english message
+func usecaseErrorResponse(c *gin.Context, errResponse *model.ErrorResponse) {
+	errData := errResponse.ErrData
+	if detail, ok := errData.(model.ErrorDetailResponse); ok {
+		logrus.Errorf("%s: %s", errResponse.Err, detail.Detail)
+		errData = nil
+	}
+	status := httpStatus(errResponse)
+	if errResponse.Code == "" {
+		writeError(c, status, model.ErrCodeInternal, errResponse.Err.Error(), errData)
+		return
+	}
+	lang := language(c)
+	params := errResponse.Params
+	errData = localizeErrData(lang, errData)
+	if failed, ok := errData.(*model.BatchCakeResult); ok && errResponse.Code == model.ErrCodeBatchFailed {
+		failed.Status = batchResultStatus(*failed)
+		params = withParam(params, "detail", failed.ErrorMessage)
+	}
+	errorResponse(c, status, errResponse.Code, params, errData)
+}
+
+// httpStatus: map domain error into HTTP status
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, model.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, model.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrUnsupported):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, model.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// batchResultStatus: HTTP status of batch operation result, status already set (e.g. invalid payload) is kept
+func batchResultStatus(result model.BatchCakeResult) int {
+	switch {
+	case result.Status != 0:
+		return result.Status
+	case result.Err != nil:
+		return httpStatus(result.Err)
+	case result.Op == model.BatchOpCreate:
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+func writeError(c *gin.Context, status int, code string, message string, errData interface{}) {
+	c.Header("Content-Language", language(c))
+	var format errorFormat
+	if value, ok := c.Get(errorFormatKey); ok {
+		format = value.(errorFormat)
+	}
+	if format.legacy {
+		c.JSON(status, model.JsonErrorResp{
+			ErrorCode:    code,
+			ErrorMessage: message,
+			ErrData:      errData,
+		})
+		return
+	}
+	problem := model.ProblemDetails{
+		Type:   problemType(format.problemTypeBaseURL, code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: message,
+		Code:   code,
+	}
+	if c.Request != nil && c.Request.URL != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+	if errs, ok := errData.(model.ValidationErrors); ok {
+		problem.Errors = errs
+	} else {
+		problem.Data = errData
+	}
+	// JSON render keep content type which is already set
+	c.Header("Content-Type", problemContentType)
+	c.JSON(status, problem)
+}
+
+func problemType(baseURL string, code string) string {
+	if baseURL == "" {
+		return "about:blank"
+	}
+	if !strings.HasSuffix(baseURL, "/") && !strings.HasSuffix(baseURL, "#") {
+		baseURL += "/"
+	}
+	return baseURL + code
+}
@@ -0,0 +1,136 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorFormatMiddleware(t *testing.T) {
+	driverErr := &model.ErrorResponse{
+		Err:  errors.New("error get cake data"),
+		Kind: model.ErrInternal,
+		Code: model.ErrCodeGetCakeFailed,
+		ErrData: model.ErrorDetailResponse{
+			Detail: "Error 1146 (42S02): Table 'ralali.cakes' doesn't exist",
+		},
+	}
+	tests := []struct {
+		name               string
+		format             string
+		problemTypeBaseURL string
+		errResponse        *model.ErrorResponse
+		wantStatus         int
+		wantContentType    string
+		wantBody           string
+	}{
+		{
+			name:            "problem details without type base url",
+			format:          ErrorFormatProblem,
+			errResponse:     driverErr,
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: "application/problem+json",
+			wantBody:        `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"error get cake data","instance":"/cakes/1","code":"get_cake_failed"}`,
+		},
+		{
+			name:               "problem details with type base url",
+			format:             ErrorFormatProblem,
+			problemTypeBaseURL: "https://api.example.com/problems",
+			errResponse: &model.ErrorResponse{
+				Err:    errors.New("cake data with id 1 not found"),
+				Kind:   model.ErrCakeNotFound,
+				Code:   model.ErrCodeCakeNotFound,
+				Params: map[string]interface{}{"id": 1},
+			},
+			wantStatus:      http.StatusNotFound,
+			wantContentType: "application/problem+json",
+			wantBody:        `{"type":"https://api.example.com/problems/cake_not_found","title":"Not Found","status":404,"detail":"cake data with id 1 not found","instance":"/cakes/1","code":"cake_not_found"}`,
+		},
+		{
+			name:            "legacy format",
+			format:          ErrorFormatLegacy,
+			errResponse:     driverErr,
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: "application/json; charset=utf-8",
+			wantBody:        `{"error_code":"get_cake_failed","error_message":"error get cake data"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(ErrorFormatMiddleware(tt.format, tt.problemTypeBaseURL))
+			router.GET("/cakes/:id", func(c *gin.Context) {
+				usecaseErrorResponse(c, tt.errResponse)
+			})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/cakes/1", nil))
+			assert.EqualValues(t, tt.wantStatus, w.Code)
+			assert.EqualValues(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestHttpStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "cake not found", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrCakeNotFound}, want: http.StatusNotFound},
+		{name: "version mismatch", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrVersionMismatch}, want: http.StatusPreconditionFailed},
+		{name: "conflict", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrConflict}, want: http.StatusConflict},
+		{name: "validation", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrValidation}, want: http.StatusBadRequest},
+		{name: "invalid fields", err: model.ValidationErrors{{Field: "title", Code: "required"}}, want: http.StatusBadRequest},
+		{name: "unsupported", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrUnsupported}, want: http.StatusUnsupportedMediaType},
+		{name: "unauthenticated", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrUnauthenticated}, want: http.StatusUnauthorized},
+		{name: "internal", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrInternal}, want: http.StatusInternalServerError},
+		{name: "without kind", err: errors.New("mock"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualValues(t, tt.want, httpStatus(tt.err))
+		})
+	}
+}
+
+func TestBatchResultStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		result model.BatchCakeResult
+		want   int
+	}{
+		{name: "created", result: model.BatchCakeResult{Op: model.BatchOpCreate}, want: http.StatusCreated},
+		{name: "updated", result: model.BatchCakeResult{Op: model.BatchOpUpdate}, want: http.StatusOK},
+		{name: "failed", result: model.BatchCakeResult{Op: model.BatchOpDelete, Err: model.ErrVersionMismatch}, want: http.StatusPreconditionFailed},
+		{name: "status already set", result: model.BatchCakeResult{Op: model.BatchOpCreate, Status: http.StatusUnprocessableEntity}, want: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualValues(t, tt.want, batchResultStatus(tt.result))
+		})
+	}
+}
+
+func TestInvalidQuery(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/cakes?min_rating=9&pagination=offset", nil)
+	var query model.ApiGetCakesQuery
+	invalidQuery(ctx, ctx.ShouldBindQuery(&query), &query)
+	assert.EqualValues(t, http.StatusBadRequest, w.Code)
+	var response model.ProblemDetails
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.EqualValues(t, model.ErrCodeInvalidQuery, response.Code)
+	fields := make([]string, 0, len(response.Errors))
+	for _, fieldErr := range response.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	assert.ElementsMatch(t, []string{"min_rating", "page_size", "pagination"}, fields)
+}
@@ -0,0 +1,207 @@
+package delivery
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/model"
+)
+
+// cakeExportFormat: content type and file extension of export format
+type cakeExportFormat struct {
+	ContentType string
+	Extension   string
+}
+
+var cakeExportFormats = map[string]cakeExportFormat{
+	"csv":    {ContentType: "text/csv; charset=utf-8", Extension: "csv"},
+	"ndjson": {ContentType: "application/x-ndjson", Extension: "ndjson"},
+	"xlsx":   {ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Extension: "xlsx"},
+}
+
+// cakeExportColumns: column header of csv and xlsx export
+var cakeExportColumns = []string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "version"}
+
+// cakeExportEncoder: write cake one by one into export file, Close must be called to complete the file
+type cakeExportEncoder interface {
+	Encode(cake model.CakeResponse) error
+	Close() error
+}
+
+func newCakeExportEncoder(format string, w io.Writer) (cakeExportEncoder, error) {
+	switch format {
+	case "csv":
+		return newCsvCakeExportEncoder(w)
+	case "ndjson":
+		return &ndjsonCakeExportEncoder{encoder: json.NewEncoder(w)}, nil
+	case "xlsx":
+		return newXlsxCakeExportEncoder(w)
+	}
+	return nil, fmt.Errorf("unsupported export format '%s'", format)
+}
+
+// cakeExportRecord: cake as text cells on cakeExportColumns order, null field is empty cell.
+// user given text is neutralised so spreadsheet does not evaluate it as formula
+func cakeExportRecord(cake model.CakeResponse) []string {
+	description, image := "", ""
+	if cake.Description != nil {
+		description = neutraliseFormula(*cake.Description)
+	}
+	if cake.Image != nil {
+		image = neutraliseFormula(*cake.Image)
+	}
+	return []string{
+		strconv.Itoa(cake.ID),
+		neutraliseFormula(cake.Title),
+		description,
+		strconv.FormatFloat(float64(cake.Rating), 'f', -1, 32),
+		image,
+		cake.CreatedAt,
+		cake.UpdatedAt,
+		strconv.Itoa(cake.Version),
+	}
+}
+
+// neutraliseFormula: prefix cell starting with formula trigger (=, +, -, @, tab or carriage return) by single quote
+// so spreadsheet read it as text (CSV / formula injection)
+func neutraliseFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+type csvCakeExportEncoder struct {
+	writer *csv.Writer
+}
+
+func newCsvCakeExportEncoder(w io.Writer) (*csvCakeExportEncoder, error) {
+	writer := csv.NewWriter(w)
+	err := writer.Write(cakeExportColumns)
+	if err != nil {
+		return nil, err
+	}
+	return &csvCakeExportEncoder{writer: writer}, nil
+}
+
+func (e *csvCakeExportEncoder) Encode(cake model.CakeResponse) error {
+	return e.writer.Write(cakeExportRecord(cake))
+}
+
+func (e *csvCakeExportEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonCakeExportEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonCakeExportEncoder) Encode(cake model.CakeResponse) error {
+	return e.encoder.Encode(cake)
+}
+
+func (e *ndjsonCakeExportEncoder) Close() error {
+	return nil
+}
+
+// xlsx static parts of workbook with single worksheet, the worksheet itself is streamed by xlsxCakeExportEncoder
+var xlsxStaticParts = []struct {
+	Name    string
+	Content string
+}{
+	{
+		Name:    "[Content_Types].xml",
+		Content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`,
+	},
+	{
+		Name:    "_rels/.rels",
+		Content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`,
+	},
+	{
+		Name:    "xl/workbook.xml",
+		Content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="cakes" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+	},
+	{
+		Name:    "xl/_rels/workbook.xml.rels",
+		Content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+	},
+}
+
+// xlsxNumericColumns: index of cakeExportColumns written as number cell
+var xlsxNumericColumns = map[int]bool{0: true, 3: true, 7: true}
+
+// xlsxCakeExportEncoder: minimal streaming xlsx writer, rows are written to zip entry as they come
+// using inline string so no shared string table has to be kept on memory
+type xlsxCakeExportEncoder struct {
+	zipWriter *zip.Writer
+	sheet     *bufio.Writer
+	rowCount  int
+}
+
+func newXlsxCakeExportEncoder(w io.Writer) (*xlsxCakeExportEncoder, error) {
+	zipWriter := zip.NewWriter(w)
+	for _, part := range xlsxStaticParts {
+		f, err := zipWriter.Create(part.Name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.WriteString(f, part.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	f, err := zipWriter.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	e := &xlsxCakeExportEncoder{
+		zipWriter: zipWriter,
+		sheet:     bufio.NewWriter(f),
+	}
+	e.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return e, e.writeRow(cakeExportColumns, false)
+}
+
+func (e *xlsxCakeExportEncoder) Encode(cake model.CakeResponse) error {
+	return e.writeRow(cakeExportRecord(cake), true)
+}
+
+func (e *xlsxCakeExportEncoder) writeRow(cells []string, numeric bool) error {
+	e.rowCount++
+	fmt.Fprintf(e.sheet, `<row r="%d">`, e.rowCount)
+	for i, cell := range cells {
+		if numeric && xlsxNumericColumns[i] {
+			fmt.Fprintf(e.sheet, `<c><v>%s</v></c>`, cell)
+			continue
+		}
+		if cell == "" {
+			e.sheet.WriteString(`<c/>`)
+			continue
+		}
+		e.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		err := xml.EscapeText(e.sheet, []byte(cell))
+		if err != nil {
+			return err
+		}
+		e.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := e.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (e *xlsxCakeExportEncoder) Close() error {
+	e.sheet.WriteString(`</sheetData></worksheet>`)
+	err := e.sheet.Flush()
+	if err != nil {
+		return err
+	}
+	return e.zipWriter.Close()
+}
@@ -0,0 +1,132 @@
+package delivery
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+)
+
+var exportCakesMock = []model.CakeResponse{
+	{
+		ID:          1,
+		Title:       "cheese, \"cake\"",
+		Description: null.StringFrom("soft & <sweet>").Ptr(),
+		Rating:      4.5,
+		CreatedAt:   "2006-01-02 22:04:05",
+		UpdatedAt:   "2006-01-02 22:04:05",
+		Version:     2,
+	},
+	{
+		ID:          2,
+		Title:       "brownies",
+		Description: null.StringFrom("=1+1").Ptr(),
+		Rating:      3,
+		Image:       null.StringFrom("image.png").Ptr(),
+		CreatedAt:   "2006-01-02 22:04:05",
+		UpdatedAt:   "2006-01-02 22:04:05",
+		Version:     1,
+	},
+}
+
+// exportCakes: encode cakes on given format into buffer
+func exportCakes(t *testing.T, format string, cakes []model.CakeResponse) *bytes.Buffer {
+	var buf bytes.Buffer
+	encoder, err := newCakeExportEncoder(format, &buf)
+	if !assert.NoError(t, err) {
+		return &buf
+	}
+	for _, cake := range cakes {
+		assert.NoError(t, encoder.Encode(cake))
+	}
+	assert.NoError(t, encoder.Close())
+	return &buf
+}
+
+func Test_csvCakeExportEncoder(t *testing.T) {
+	got := exportCakes(t, "csv", exportCakesMock).String()
+	want := "id,title,description,rating,image,created_at,updated_at,version\n" +
+		"1,\"cheese, \"\"cake\"\"\",soft & <sweet>,4.5,,2006-01-02 22:04:05,2006-01-02 22:04:05,2\n" +
+		"2,brownies,'=1+1,3,image.png,2006-01-02 22:04:05,2006-01-02 22:04:05,1\n"
+	assert.EqualValues(t, want, got)
+}
+
+func Test_cakeExportRecord_neutraliseFormula(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "plain text", title: "cheese cake", want: "cheese cake"},
+		{name: "formula", title: "=HYPERLINK(\"http://evil.example\")", want: "'=HYPERLINK(\"http://evil.example\")"},
+		{name: "plus", title: "+1", want: "'+1"},
+		{name: "minus", title: "-1+2", want: "'-1+2"},
+		{name: "at sign", title: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "tab", title: "\t=1", want: "'\t=1"},
+		{name: "carriage return", title: "\r=1", want: "'\r=1"},
+		{name: "trigger not on first character", title: "cake = love", want: "cake = love"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cake := model.CakeResponse{
+				Title:       tt.title,
+				Description: null.StringFrom(tt.title).Ptr(),
+				Image:       null.StringFrom(tt.title).Ptr(),
+			}
+			got := cakeExportRecord(cake)
+			assert.EqualValues(t, tt.want, got[1])
+			assert.EqualValues(t, tt.want, got[2])
+			assert.EqualValues(t, tt.want, got[4])
+		})
+	}
+}
+
+func Test_ndjsonCakeExportEncoder(t *testing.T) {
+	got := exportCakes(t, "ndjson", exportCakesMock).String()
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], `"title":"brownies"`)
+}
+
+func Test_xlsxCakeExportEncoder(t *testing.T) {
+	buf := exportCakes(t, "xlsx", exportCakesMock)
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if !assert.NoError(t, err) {
+		return
+	}
+	names := make([]string, 0)
+	var sheet []byte
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		assert.NoError(t, err)
+		rc.Close()
+		// every part must be well formed xml
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if !assert.NoError(t, err, f.Name) {
+				break
+			}
+		}
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			sheet = content
+		}
+	}
+	assert.EqualValues(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"}, names)
+	assert.Equal(t, 3, strings.Count(string(sheet), "<row "))
+	assert.Contains(t, string(sheet), "soft &amp; &lt;sweet&gt;")
+	assert.Contains(t, string(sheet), "<c><v>4.5</v></c>")
+	assert.Contains(t, string(sheet), "&#39;=1+1")
+}
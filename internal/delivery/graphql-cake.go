@@ -0,0 +1,206 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/sirupsen/logrus"
+)
+
+// GraphqlDelivery: GraphQL endpoint of cake usecase, argument is validated by the same rules as REST API
+type GraphqlDelivery struct {
+	cakeUsecase usecase.CakeUsecaseInterface
+	// imageURLValidator: policy of image url on input, nil only apply model validation
+	imageURLValidator *ImageURLValidator
+	// maxComplexity: maximum complexity of operation (see graphqlComplexity), 0 disable the limit
+	maxComplexity int
+	schema        graphql.Schema
+}
+
+func NewGraphqlDelivery(cakeUsecase usecase.CakeUsecaseInterface, imageURLValidator *ImageURLValidator, maxComplexity int) *GraphqlDelivery {
+	d := &GraphqlDelivery{
+		cakeUsecase:       cakeUsecase,
+		imageURLValidator: imageURLValidator,
+		maxComplexity:     maxComplexity,
+	}
+	schema, err := newGraphqlSchema(d)
+	if err != nil {
+		logrus.Panic("error build graphql schema: ", err)
+	}
+	d.schema = schema
+	return d
+}
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphqlLanguageKey: key of error message language on resolver context
+type graphqlLanguageKey struct{}
+
+// Query: execute GraphQL request from json body (POST) or query parameter (GET), mutation is only executed on POST
+// and require token when OptionalAuthMiddleware has verifier.
+// request which cannot be executed respond 400 with errors only, error of resolver respond 200 with partial data
+func (d *GraphqlDelivery) Query(c *gin.Context) {
+	lang := language(c)
+	var request graphqlRequest
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				graphqlRequestError(c, http.StatusBadRequest, lang, model.ErrCodeInvalidGraphqlRequest, map[string]interface{}{"detail": "variables must be a json object"})
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&request); err != nil {
+		graphqlRequestError(c, http.StatusBadRequest, lang, model.ErrCodeInvalidGraphqlRequest, map[string]interface{}{"detail": err.Error()})
+		return
+	}
+	if strings.TrimSpace(request.Query) == "" {
+		graphqlRequestError(c, http.StatusBadRequest, lang, model.ErrCodeInvalidGraphqlRequest, map[string]interface{}{"detail": "query is required"})
+		return
+	}
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(request.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, graphql.Result{Errors: withErrorCode(gqlerrors.FormatErrors(err), model.ErrCodeInvalidGraphqlRequest)})
+		return
+	}
+	validation := graphql.ValidateDocument(&d.schema, document, nil)
+	if !validation.IsValid {
+		c.JSON(http.StatusBadRequest, graphql.Result{Errors: withErrorCode(validation.Errors, model.ErrCodeInvalidGraphqlRequest)})
+		return
+	}
+	operation := graphqlOperation(document, request.OperationName)
+	if operation != nil && operation.Operation == ast.OperationTypeMutation && c.Request.Method == http.MethodGet {
+		c.Header("Allow", http.MethodPost)
+		graphqlRequestError(c, http.StatusMethodNotAllowed, lang, model.ErrCodeMutationNotAllowed, nil)
+		return
+	}
+	if operation != nil && operation.Operation == ast.OperationTypeMutation && authRequired(c) {
+		c.Header("WWW-Authenticate", "Bearer")
+		graphqlRequestError(c, http.StatusUnauthorized, lang, model.ErrCodeUnauthorized, nil)
+		return
+	}
+	scope := model.ScopeCakesRead
+	if operation != nil && operation.Operation == ast.OperationTypeMutation {
+		scope = model.ScopeCakesWrite
+	}
+	if !scopeGranted(c, scope) {
+		graphqlRequestError(c, http.StatusForbidden, lang, model.ErrCodeInsufficientScope, map[string]interface{}{"scope": scope})
+		return
+	}
+	if complexity := graphqlComplexity(document, request.OperationName, request.Variables); d.maxComplexity > 0 && complexity > d.maxComplexity {
+		graphqlRequestError(c, http.StatusBadRequest, lang, model.ErrCodeQueryTooComplex, map[string]interface{}{"complexity": complexity, "max": d.maxComplexity})
+		return
+	}
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        d.schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       context.WithValue(c.Request.Context(), graphqlLanguageKey{}, lang),
+	})
+	c.JSON(http.StatusOK, result)
+}
+
+// Playground: GraphiQL page to explore schema and try query against /graphql, only registered on dev mode
+func (d *GraphqlDelivery) Playground(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(graphiqlPage))
+}
+
+// graphqlError: error of resolver with stable error code on extensions, invalid fields are listed on extensions errors
+type graphqlError struct {
+	code      string
+	message   string
+	fieldErrs model.ValidationErrors
+}
+
+func (e *graphqlError) Error() string {
+	return e.message
+}
+
+func (e *graphqlError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.code}
+	if len(e.fieldErrs) > 0 {
+		extensions["errors"] = e.fieldErrs
+	}
+	return extensions
+}
+
+// graphqlLanguage: language of error message picked by handler, default language when resolver is called directly
+func graphqlLanguage(ctx context.Context) string {
+	if lang, ok := ctx.Value(graphqlLanguageKey{}).(string); ok {
+		return lang
+	}
+	return i18n.DefaultLanguage
+}
+
+// graphqlUsecaseError: map error returned by usecase into resolver error
+func graphqlUsecaseError(ctx context.Context, errResponse *model.ErrorResponse) error {
+	code, message, fieldErrs := localizeUsecaseError(graphqlLanguage(ctx), errResponse)
+	return &graphqlError{code: code, message: message, fieldErrs: fieldErrs}
+}
+
+// graphqlInvalidArgument: invalid argument as resolver error, same as invalidPayload every invalid field is listed
+func graphqlInvalidArgument(ctx context.Context, code string, err error) error {
+	message, fieldErrs := localizeInvalidArgument(graphqlLanguage(ctx), code, err)
+	return &graphqlError{code: code, message: message, fieldErrs: fieldErrs}
+}
+
+// graphqlVersionRequired: version 0 means skipping version check on usecase, so mutation must send version of cake
+func graphqlVersionRequired(ctx context.Context) error {
+	lang := graphqlLanguage(ctx)
+	return &graphqlError{code: model.ErrCodeVersionRequired, message: i18n.Message(lang, model.ErrCodeVersionRequired, nil)}
+}
+
+// graphqlRequestError: respond request which cannot be executed, body follow GraphQL response without data
+func graphqlRequestError(c *gin.Context, status int, lang string, code string, params map[string]interface{}) {
+	err := gqlerrors.FormatError(&graphqlError{code: code, message: i18n.Message(lang, code, params)})
+	err.Extensions = map[string]interface{}{"code": code}
+	c.JSON(status, graphql.Result{Errors: []gqlerrors.FormattedError{err}})
+}
+
+// withErrorCode: add error code on extensions of parse / validation errors
+func withErrorCode(errs []gqlerrors.FormattedError, code string) []gqlerrors.FormattedError {
+	for i := range errs {
+		errs[i].Extensions = map[string]interface{}{"code": code}
+	}
+	return errs
+}
+
+const graphiqlPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>GraphiQL - Ralali App</title>
+	<link rel="stylesheet" href="https://unpkg.com/graphiql@3.0.6/graphiql.min.css">
+	<style>body { margin: 0; height: 100vh; } #graphiql { height: 100vh; }</style>
+</head>
+<body>
+	<div id="graphiql">Loading...</div>
+	<script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+	<script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+	<script crossorigin src="https://unpkg.com/graphiql@3.0.6/graphiql.min.js"></script>
+	<script>
+		const fetcher = GraphiQL.createFetcher({ url: "/graphql" });
+		ReactDOM.createRoot(document.getElementById("graphiql")).render(React.createElement(GraphiQL, { fetcher: fetcher }));
+	</script>
+</body>
+</html>`
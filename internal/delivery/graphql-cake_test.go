@@ -0,0 +1,445 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type graphqlTestResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code   string                 `json:"code"`
+			Errors model.ValidationErrors `json:"errors"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// serveGraphql: send query to GraphqlDelivery, GET request send query as query parameter
+func serveGraphql(t *testing.T, d *GraphqlDelivery, method string, query string, variables string) (int, graphqlTestResponse) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	if method == http.MethodGet {
+		params := url.Values{"query": {query}}
+		if variables != "" {
+			params.Set("variables", variables)
+		}
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+	} else {
+		body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": json.RawMessage(variables)})
+		if variables == "" {
+			body, _ = json.Marshal(map[string]interface{}{"query": query})
+		}
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+	}
+	d.Query(ctx)
+	var response graphqlTestResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v, body = %s", err, w.Body.String())
+	}
+	return w.Code, response
+}
+
+func TestGraphqlDelivery_Query(t *testing.T) {
+	description := "description"
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		if id != 1 {
+			return nil, &model.ErrorResponse{
+				Err:    errors.New("error mock"),
+				Kind:   model.ErrCakeNotFound,
+				Code:   model.ErrCodeCakeNotFound,
+				Params: map[string]interface{}{"id": id},
+			}
+		}
+		return &model.CakeResponse{
+			ID:          1,
+			Title:       "title",
+			Description: &description,
+			Rating:      4.5,
+			Images: &model.CakeImagesResponse{
+				Original: "http://127.0.0.1/images/cakes/1/a.png",
+				Variants: []model.CakeImageVariantResponse{{Width: 256, Format: "jpeg", URL: "http://127.0.0.1/images/cakes/1/a.png_256.jpg"}},
+			},
+			CreatedAt: "2023-01-02 15:04:05",
+			UpdatedAt: "2023-01-03 15:04:05",
+			Version:   2,
+		}, nil
+	}
+	mockCakeUsecase.DeleteCakeFunc = func(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
+		if version != 2 {
+			return nil, &model.ErrorResponse{
+				Err:    errors.New("error mock"),
+				Kind:   model.ErrVersionMismatch,
+				Code:   model.ErrCodeCakeModified,
+				Params: map[string]interface{}{"id": id, "version": 2},
+			}
+		}
+		return &model.CakeDeleteResponse{ID: id}, nil
+	}
+	d := NewGraphqlDelivery(mockCakeUsecase, nil, 1000)
+	tests := []struct {
+		name      string
+		method    string
+		query     string
+		variables string
+		wantCode  int
+		wantData  map[string]interface{}
+		wantErr   string
+	}{
+		{
+			name:     "only selected fields",
+			method:   http.MethodPost,
+			query:    `{ cake(id: 1) { id title createdAt images { variants { width url } } } }`,
+			wantCode: http.StatusOK,
+			wantData: map[string]interface{}{"cake": map[string]interface{}{
+				"id":        float64(1),
+				"title":     "title",
+				"createdAt": "2023-01-02 15:04:05",
+				"images": map[string]interface{}{"variants": []interface{}{
+					map[string]interface{}{"width": float64(256), "url": "http://127.0.0.1/images/cakes/1/a.png_256.jpg"},
+				}},
+			}},
+		},
+		{
+			name:      "query on GET request",
+			method:    http.MethodGet,
+			query:     `query ($id: Int!) { cake(id: $id) { rating version } }`,
+			variables: `{"id": 1}`,
+			wantCode:  http.StatusOK,
+			wantData:  map[string]interface{}{"cake": map[string]interface{}{"rating": 4.5, "version": float64(2)}},
+		},
+		{
+			name:     "cake not found",
+			method:   http.MethodPost,
+			query:    `{ cake(id: 2) { id } }`,
+			wantCode: http.StatusOK,
+			wantData: map[string]interface{}{"cake": nil},
+			wantErr:  model.ErrCodeCakeNotFound,
+		},
+		{
+			name:     "delete cake",
+			method:   http.MethodPost,
+			query:    `mutation { deleteCake(id: 1, version: 2) }`,
+			wantCode: http.StatusOK,
+			wantData: map[string]interface{}{"deleteCake": float64(1)},
+		},
+		{
+			name:     "delete modified cake",
+			method:   http.MethodPost,
+			query:    `mutation { deleteCake(id: 1, version: 1) }`,
+			wantCode: http.StatusOK,
+			wantErr:  model.ErrCodeCakeModified,
+		},
+		{
+			name:     "mutation on GET request",
+			method:   http.MethodGet,
+			query:    `mutation { deleteCake(id: 1, version: 2) }`,
+			wantCode: http.StatusMethodNotAllowed,
+			wantErr:  model.ErrCodeMutationNotAllowed,
+		},
+		{
+			name:     "syntax error",
+			method:   http.MethodPost,
+			query:    `{ cake(id: 1) { id }`,
+			wantCode: http.StatusBadRequest,
+			wantErr:  model.ErrCodeInvalidGraphqlRequest,
+		},
+		{
+			name:     "unknown field",
+			method:   http.MethodPost,
+			query:    `{ cake(id: 1) { price } }`,
+			wantCode: http.StatusBadRequest,
+			wantErr:  model.ErrCodeInvalidGraphqlRequest,
+		},
+		{
+			name:     "empty query",
+			method:   http.MethodPost,
+			query:    " ",
+			wantCode: http.StatusBadRequest,
+			wantErr:  model.ErrCodeInvalidGraphqlRequest,
+		},
+		{
+			name:     "query too complex",
+			method:   http.MethodPost,
+			query:    `{ cakes(first: 100) { edges { cursor node { id title rating description image version createdAt updatedAt images { original } } } } }`,
+			wantCode: http.StatusBadRequest,
+			wantErr:  model.ErrCodeQueryTooComplex,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, response := serveGraphql(t, d, tt.method, tt.query, tt.variables)
+			assert.EqualValues(t, tt.wantCode, code)
+			if tt.wantData != nil {
+				assert.EqualValues(t, tt.wantData, response.Data)
+			}
+			if tt.wantErr == "" {
+				assert.Empty(t, response.Errors)
+				return
+			}
+			if assert.Len(t, response.Errors, 1) {
+				assert.EqualValues(t, tt.wantErr, response.Errors[0].Extensions.Code)
+			}
+		})
+	}
+}
+
+func TestGraphqlDelivery_Query_Cakes(t *testing.T) {
+	nextCursor := "next"
+	prevCursor := "prev"
+	var gotParam model.GetCakesUsecaseParam
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetCakesFunc = func(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+		gotParam = param
+		if param.Cursor == "invalid" {
+			return nil, &model.ErrorResponse{
+				Err:  errors.New("error mock"),
+				Kind: model.ErrValidation,
+				Code: model.ErrCodeInvalidCursor,
+			}
+		}
+		return &model.GetCakesResponse{
+			Meta: model.MetaPagination{NextCursor: &nextCursor, PrevCursor: &prevCursor},
+			Data: []model.CakeResponse{{ID: 2, Title: "second"}, {ID: 1, Title: "first"}},
+		}, nil
+	}
+	// cursor of edge point at the cake itself
+	c2 := usecase.CakeCursor(model.CakeResponse{ID: 2, Title: "second"})
+	c1 := usecase.CakeCursor(model.CakeResponse{ID: 1, Title: "first"})
+	d := NewGraphqlDelivery(mockCakeUsecase, nil, 1000)
+	rating := float32(4)
+	hasImage := true
+	tests := []struct {
+		name       string
+		query      string
+		wantParam  model.GetCakesUsecaseParam
+		wantData   map[string]interface{}
+		wantErr    string
+		wantFields []string
+	}{
+		{
+			name:  "first page with default size",
+			query: `{ cakes { edges { cursor node { id } } pageInfo { hasNextPage hasPreviousPage startCursor endCursor } } }`,
+			wantParam: model.GetCakesUsecaseParam{
+				PageSize:   graphqlDefaultPageSize,
+				CursorMode: true,
+			},
+			wantData: map[string]interface{}{"cakes": map[string]interface{}{
+				"edges": []interface{}{
+					map[string]interface{}{"cursor": c2, "node": map[string]interface{}{"id": float64(2)}},
+					map[string]interface{}{"cursor": c1, "node": map[string]interface{}{"id": float64(1)}},
+				},
+				"pageInfo": map[string]interface{}{"hasNextPage": true, "hasPreviousPage": true, "startCursor": c2, "endCursor": c1},
+			}},
+		},
+		{
+			name:  "forward page with filter",
+			query: `{ cakes(first: 2, after: "c3", filter: {keyword: " cake ", minRating: 4, hasImage: true}) { nodes { title } } }`,
+			wantParam: model.GetCakesUsecaseParam{
+				PageSize:   2,
+				Filter:     model.CakesFilterQuery{Keyword: "cake", MinRating: &rating, HasImage: &hasImage},
+				CursorMode: true,
+				Cursor:     "c3",
+			},
+			wantData: map[string]interface{}{"cakes": map[string]interface{}{"nodes": []interface{}{
+				map[string]interface{}{"title": "second"},
+				map[string]interface{}{"title": "first"},
+			}}},
+		},
+		{
+			name:  "backward page",
+			query: `{ cakes(last: 5, before: "c0") { nodes { id } } }`,
+			wantParam: model.GetCakesUsecaseParam{
+				PageSize:   5,
+				CursorMode: true,
+				Cursor:     "c0",
+				Backward:   true,
+			},
+		},
+		{
+			name:    "first combined with last",
+			query:   `{ cakes(first: 5, last: 5) { nodes { id } } }`,
+			wantErr: model.ErrCodeInvalidQuery,
+		},
+		{
+			name:       "page size out of range",
+			query:      `{ cakes(last: 101) { nodes { id } } }`,
+			wantErr:    model.ErrCodeInvalidQuery,
+			wantFields: []string{"last"},
+		},
+		{
+			name:       "invalid filter",
+			query:      `{ cakes(filter: {minRating: 6}) { nodes { id } } }`,
+			wantErr:    model.ErrCodeInvalidQuery,
+			wantFields: []string{"min_rating"},
+		},
+		{
+			name:    "invalid cursor",
+			query:   `{ cakes(after: "invalid") { nodes { id } } }`,
+			wantErr: model.ErrCodeInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotParam = model.GetCakesUsecaseParam{}
+			code, response := serveGraphql(t, d, http.MethodPost, tt.query, "")
+			assert.EqualValues(t, http.StatusOK, code)
+			if tt.wantErr != "" {
+				if assert.Len(t, response.Errors, 1) {
+					assert.EqualValues(t, tt.wantErr, response.Errors[0].Extensions.Code)
+					var fields []string
+					for _, fieldErr := range response.Errors[0].Extensions.Errors {
+						fields = append(fields, fieldErr.Field)
+					}
+					assert.EqualValues(t, tt.wantFields, fields)
+				}
+				return
+			}
+			assert.Empty(t, response.Errors)
+			assert.EqualValues(t, tt.wantParam, gotParam)
+			if tt.wantData != nil {
+				assert.EqualValues(t, tt.wantData, response.Data)
+			}
+		})
+	}
+}
+
+func TestGraphqlDelivery_Query_Mutation(t *testing.T) {
+	var gotPayload model.CakePayloadQuery
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.CreateCakeFunc = func(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
+		gotPayload = payload
+		return &model.CakeResponse{ID: 1, Title: payload.Title, Rating: payload.Rating, Version: 1}, nil
+	}
+	mockCakeUsecase.UpdateCakeFunc = func(ctx context.Context, id int, version int, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
+		gotPayload = payload
+		return &model.CakeResponse{ID: id, Title: payload.Title, Rating: payload.Rating, Version: version + 1}, nil
+	}
+	mockCakeUsecase.RestoreCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return nil, &model.ErrorResponse{
+			Err:    errors.New("error mock"),
+			Kind:   model.ErrConflict,
+			Code:   model.ErrCodeCakeNotDeleted,
+			Params: map[string]interface{}{"id": id},
+		}
+	}
+	d := NewGraphqlDelivery(mockCakeUsecase, nil, 1000)
+	description := "description"
+	tests := []struct {
+		name        string
+		query       string
+		variables   string
+		wantPayload model.CakePayloadQuery
+		wantData    map[string]interface{}
+		wantErr     string
+		wantFields  []string
+	}{
+		{
+			name:        "create cake",
+			query:       `mutation ($input: CakeInput!) { createCake(input: $input) { id title version } }`,
+			variables:   `{"input": {"title": " title ", "description": "description", "rating": 4}}`,
+			wantPayload: model.CakePayloadQuery{Title: "title", Description: &description, Rating: 4},
+			wantData: map[string]interface{}{"createCake": map[string]interface{}{
+				"id": float64(1), "title": "title", "version": float64(1),
+			}},
+		},
+		{
+			name:        "update cake",
+			query:       `mutation { updateCake(id: 3, version: 1, input: {title: "title", rating: 5}) { id version } }`,
+			wantPayload: model.CakePayloadQuery{Title: "title", Rating: 5},
+			wantData: map[string]interface{}{"updateCake": map[string]interface{}{
+				"id": float64(3), "version": float64(2),
+			}},
+		},
+		{
+			name:    "update cake without version",
+			query:   `mutation { updateCake(id: 3, version: 0, input: {title: "title", rating: 5}) { id version } }`,
+			wantErr: model.ErrCodeVersionRequired,
+		},
+		{
+			name:    "update cake with negative version",
+			query:   `mutation { updateCake(id: 3, version: -1, input: {title: "title", rating: 5}) { id version } }`,
+			wantErr: model.ErrCodeVersionRequired,
+		},
+		{
+			name:    "delete cake without version",
+			query:   `mutation { deleteCake(id: 3, version: 0) }`,
+			wantErr: model.ErrCodeVersionRequired,
+		},
+		{
+			name:    "delete cake with negative version",
+			query:   `mutation { deleteCake(id: 3, version: -2) }`,
+			wantErr: model.ErrCodeVersionRequired,
+		},
+		{
+			name:       "invalid input",
+			query:      `mutation { createCake(input: {title: "", rating: 6}) { id } }`,
+			wantErr:    model.ErrCodeValidationFailed,
+			wantFields: []string{"title", "rating"},
+		},
+		{
+			name:    "restore cake which is not deleted",
+			query:   `mutation { restoreCake(id: 1) { id } }`,
+			wantErr: model.ErrCodeCakeNotDeleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPayload = model.CakePayloadQuery{}
+			code, response := serveGraphql(t, d, http.MethodPost, tt.query, tt.variables)
+			assert.EqualValues(t, http.StatusOK, code)
+			if tt.wantErr != "" {
+				if assert.Len(t, response.Errors, 1) {
+					assert.EqualValues(t, tt.wantErr, response.Errors[0].Extensions.Code)
+					var fields []string
+					for _, fieldErr := range response.Errors[0].Extensions.Errors {
+						fields = append(fields, fieldErr.Field)
+					}
+					assert.EqualValues(t, tt.wantFields, fields)
+				}
+				return
+			}
+			assert.Empty(t, response.Errors)
+			assert.EqualValues(t, tt.wantPayload, gotPayload)
+			assert.EqualValues(t, tt.wantData, response.Data)
+		})
+	}
+}
+
+func TestGraphqlDelivery_Query_Language(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return nil, &model.ErrorResponse{
+			Err:    errors.New("error mock"),
+			Kind:   model.ErrCakeNotFound,
+			Code:   model.ErrCodeCakeNotFound,
+			Params: map[string]interface{}{"id": id},
+		}
+	}
+	d := NewGraphqlDelivery(mockCakeUsecase, nil, 1000)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ cake(id: 7) { id } }"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Request.Header.Set("Accept-Language", "id")
+	d.Query(ctx)
+	var response graphqlTestResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Errors, 1) {
+		assert.EqualValues(t, "data kue dengan id 7 tidak ditemukan", response.Errors[0].Message)
+	}
+}
@@ -0,0 +1,82 @@
+package delivery
+
+import (
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// graphqlComplexity: cost of executing operation, every field cost 1 and selection of list field (argument first /
+// last) is multiplied by its size so nested connections cannot be used to fetch unbounded data.
+// document must already be validated (e.g. no fragment cycle)
+func graphqlComplexity(document *ast.Document, operationName string, variables map[string]interface{}) int {
+	operation := graphqlOperation(document, operationName)
+	if operation == nil {
+		return 0
+	}
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	return selectionComplexity(operation.SelectionSet, fragments, variables)
+}
+
+func selectionComplexity(selectionSet *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, variables map[string]interface{}) int {
+	if selectionSet == nil {
+		return 0
+	}
+	complexity := 0
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			children := selectionComplexity(selection.SelectionSet, fragments, variables)
+			complexity += 1 + children*listSize(selection, variables)
+		case *ast.InlineFragment:
+			complexity += selectionComplexity(selection.SelectionSet, fragments, variables)
+		case *ast.FragmentSpread:
+			if fragment, ok := fragments[selection.Name.Value]; ok {
+				complexity += selectionComplexity(fragment.SelectionSet, fragments, variables)
+			}
+		}
+	}
+	return complexity
+}
+
+// listSize: number of item fetched by field from its first / last argument, connection field without the argument
+// fetch graphqlDefaultPageSize items
+func listSize(field *ast.Field, variables map[string]interface{}) int {
+	size := 1
+	if graphqlConnectionFields[field.Name.Value] {
+		size = graphqlDefaultPageSize
+	}
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "first" && argument.Name.Value != "last" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			// variable of json request body is decoded as float64
+			if n, ok := variables[value.Name.Value].(float64); ok && n > 0 {
+				return int(n)
+			}
+		}
+	}
+	return size
+}
+
+// graphqlOperation: operation executed by request, the first operation is executed when operationName is empty
+func graphqlOperation(document *ast.Document, operationName string) *ast.OperationDefinition {
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if ok && (operationName == "" || (operation.Name != nil && operation.Name.Value == operationName)) {
+			return operation
+		}
+	}
+	return nil
+}
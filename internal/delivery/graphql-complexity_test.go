@@ -0,0 +1,66 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphqlComplexity(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]interface{}
+		want          int
+	}{
+		{
+			name:  "single cake",
+			query: `{ cake(id: 1) { id title images { original } } }`,
+			want:  5,
+		},
+		{
+			name:  "connection with default page size",
+			query: `{ cakes { nodes { id title } } }`,
+			want:  1 + (1+2)*graphqlDefaultPageSize,
+		},
+		{
+			name:  "connection with first argument",
+			query: `{ cakes(first: 50) { edges { node { id } } pageInfo { hasNextPage } } }`,
+			want:  1 + (2+1+2)*50,
+		},
+		{
+			name:      "connection with last variable",
+			query:     `query ($last: Int) { cakes(last: $last) { nodes { id } } }`,
+			variables: map[string]interface{}{"last": float64(20)},
+			want:      1 + 2*20,
+		},
+		{
+			name:  "fragments",
+			query: `{ cakes(first: 2) { nodes { ...cake ... on Cake { rating } } } } fragment cake on Cake { id title }`,
+			want:  1 + (1+3)*2,
+		},
+		{
+			name:          "selected operation",
+			query:         `query small { cake(id: 1) { id } } query big { cakes(first: 100) { nodes { id } } }`,
+			operationName: "big",
+			want:          1 + 2*100,
+		},
+		{
+			name:          "unknown operation",
+			query:         `query small { cake(id: 1) { id } }`,
+			operationName: "big",
+			want:          0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("parser.Parse() error = %v", err)
+			}
+			assert.EqualValues(t, tt.want, graphqlComplexity(document, tt.operationName, tt.variables))
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package delivery
+
+import (
+	"errors"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	// graphqlDefaultPageSize: number of cakes of connection when first / last argument is not given
+	graphqlDefaultPageSize = 10
+	graphqlMaxPageSize     = 100
+)
+
+// graphqlConnectionFields: list field paginated by first / last argument, used on complexity calculation
+var graphqlConnectionFields = map[string]bool{"cakes": true}
+
+// graphqlCakeConnection: relay style connection of cakes, nodes is shortcut of edges node
+type graphqlCakeConnection struct {
+	Edges    []graphqlCakeEdge
+	Nodes    []model.CakeResponse
+	PageInfo graphqlPageInfo
+}
+
+type graphqlCakeEdge struct {
+	Cursor string
+	Node   model.CakeResponse
+}
+
+type graphqlPageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
+}
+
+// newGraphqlSchema: schema of cake domain, field of object type is resolved from struct field with the same name
+// (case insensitive)
+func newGraphqlSchema(d *GraphqlDelivery) (graphql.Schema, error) {
+	imageVariantType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CakeImageVariant",
+		Fields: graphql.Fields{
+			"width":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"format": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"url":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	imagesType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CakeImages",
+		Fields: graphql.Fields{
+			"original": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"variants": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(imageVariantType)))},
+		},
+	})
+	cakeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Cake",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.String},
+			"rating":      &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"image":       &graphql.Field{Type: graphql.String},
+			"images": &graphql.Field{
+				Type:        imagesType,
+				Description: "resized variants of image, null when cake has no image",
+			},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"version": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "current version of cake, required by updateCake and deleteCake",
+			},
+		},
+	})
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CakeEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(cakeType)},
+		},
+	})
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"hasPreviousPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"startCursor":     &graphql.Field{Type: graphql.String},
+			"endCursor":       &graphql.Field{Type: graphql.String},
+		},
+	})
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CakeConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType)))},
+			"nodes":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(cakeType)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CakesFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"keyword":       &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "search on title and description"},
+			"minRating":     &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"maxRating":     &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"hasImage":      &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"createdAfter":  &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"createdBefore": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		},
+	})
+	cakeInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CakeInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"rating":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+			"image":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"cake": &graphql.Field{
+				Type: cakeType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: d.resolveCake,
+			},
+			"cakes": &graphql.Field{
+				Type:        graphql.NewNonNull(connectionType),
+				Description: "cakes ordered by newest, paginated forward by first / after or backward by last / before",
+				Args: graphql.FieldConfigArgument{
+					"first":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"after":  &graphql.ArgumentConfig{Type: graphql.String},
+					"last":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"before": &graphql.ArgumentConfig{Type: graphql.String},
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+				},
+				Resolve: d.resolveCakes,
+			},
+		},
+	})
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createCake": &graphql.Field{
+				Type: graphql.NewNonNull(cakeType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(cakeInputType)},
+				},
+				Resolve: d.resolveCreateCake,
+			},
+			"updateCake": &graphql.Field{
+				Type: graphql.NewNonNull(cakeType),
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"version": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"input":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(cakeInputType)},
+				},
+				Resolve: d.resolveUpdateCake,
+			},
+			"deleteCake": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "soft delete cake, return id of deleted cake",
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"version": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: d.resolveDeleteCake,
+			},
+			"restoreCake": &graphql.Field{
+				Type: graphql.NewNonNull(cakeType),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: d.resolveRestoreCake,
+			},
+		},
+	})
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func (d *GraphqlDelivery) resolveCake(p graphql.ResolveParams) (interface{}, error) {
+	response, errResponse := d.cakeUsecase.GetDetailCake(p.Context, p.Args["id"].(int))
+	if errResponse != nil {
+		return nil, graphqlUsecaseError(p.Context, errResponse)
+	}
+	return response, nil
+}
+
+// resolveCakes: cakes connection on cursor pagination, cursor of edge can be used as after or before argument
+func (d *GraphqlDelivery) resolveCakes(p graphql.ResolveParams) (interface{}, error) {
+	first, hasFirst := p.Args["first"].(int)
+	last, hasLast := p.Args["last"].(int)
+	after, _ := p.Args["after"].(string)
+	before, _ := p.Args["before"].(string)
+	backward := hasLast || before != ""
+	if (hasFirst || after != "") && backward {
+		return nil, graphqlInvalidArgument(p.Context, model.ErrCodeInvalidQuery, errors.New("first / after cannot be combined with last / before"))
+	}
+	size, sizeField := graphqlDefaultPageSize, "first"
+	if hasFirst {
+		size = first
+	} else if hasLast {
+		size, sizeField = last, "last"
+	}
+	if size < 1 || size > graphqlMaxPageSize {
+		return nil, graphqlInvalidArgument(p.Context, model.ErrCodeInvalidQuery, &model.FieldError{
+			Field:  sizeField,
+			Code:   "out_of_range",
+			Params: map[string]interface{}{"min": 1, "max": graphqlMaxPageSize},
+		})
+	}
+	filter := graphqlCakesFilter(p.Args["filter"])
+	if err := validateQuery(&filter); err != nil {
+		return nil, graphqlInvalidArgument(p.Context, model.ErrCodeInvalidQuery, err)
+	}
+	cursor := after
+	if backward {
+		cursor = before
+	}
+	response, errResponse := d.cakeUsecase.GetCakes(p.Context, model.GetCakesUsecaseParam{
+		PageSize:   size,
+		Filter:     filter.Filter(),
+		CursorMode: true,
+		Cursor:     cursor,
+		Backward:   backward,
+	})
+	if errResponse != nil {
+		return nil, graphqlUsecaseError(p.Context, errResponse)
+	}
+	connection := graphqlCakeConnection{
+		Edges: make([]graphqlCakeEdge, 0, len(response.Data)),
+		Nodes: response.Data,
+		PageInfo: graphqlPageInfo{
+			HasNextPage:     response.Meta.NextCursor != nil,
+			HasPreviousPage: response.Meta.PrevCursor != nil,
+		},
+	}
+	for _, cake := range response.Data {
+		connection.Edges = append(connection.Edges, graphqlCakeEdge{Cursor: usecase.CakeCursor(cake), Node: cake})
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+	return connection, nil
+}
+
+func (d *GraphqlDelivery) resolveCreateCake(p graphql.ResolveParams) (interface{}, error) {
+	payload, err := validateMutationPayload(p.Context, d.imageURLValidator, graphqlCakeInput(p.Args["input"]))
+	if err != nil {
+		return nil, graphqlInvalidArgument(p.Context, model.ErrCodeValidationFailed, err)
+	}
+	response, errResponse := d.cakeUsecase.CreateCake(p.Context, payload)
+	if errResponse != nil {
+		return nil, graphqlUsecaseError(p.Context, errResponse)
+	}
+	return response, nil
+}
+
+func (d *GraphqlDelivery) resolveUpdateCake(p graphql.ResolveParams) (interface{}, error) {
+	if p.Args["version"].(int) <= 0 {
+		return nil, graphqlVersionRequired(p.Context)
+	}
+	payload, err := validateMutationPayload(p.Context, d.imageURLValidator, graphqlCakeInput(p.Args["input"]))
+	if err != nil {
+		return nil, graphqlInvalidArgument(p.Context, model.ErrCodeValidationFailed, err)
+	}
+	response, errResponse := d.cakeUsecase.UpdateCake(p.Context, p.Args["id"].(int), p.Args["version"].(int), payload)
+	if errResponse != nil {
+		return nil, graphqlUsecaseError(p.Context, errResponse)
+	}
+	return response, nil
+}
+
+func (d *GraphqlDelivery) resolveDeleteCake(p graphql.ResolveParams) (interface{}, error) {
+	if p.Args["version"].(int) <= 0 {
+		return nil, graphqlVersionRequired(p.Context)
+	}
+	response, errResponse := d.cakeUsecase.DeleteCake(p.Context, p.Args["id"].(int), p.Args["version"].(int))
+	if errResponse != nil {
+		return nil, graphqlUsecaseError(p.Context, errResponse)
+	}
+	return response.ID, nil
+}
+
+func (d *GraphqlDelivery) resolveRestoreCake(p graphql.ResolveParams) (interface{}, error) {
+	response, errResponse := d.cakeUsecase.RestoreCake(p.Context, p.Args["id"].(int))
+	if errResponse != nil {
+		return nil, graphqlUsecaseError(p.Context, errResponse)
+	}
+	return response, nil
+}
+
+// graphqlCakesFilter: CakesFilter input as REST filter query, so it is validated by the same rules
+func graphqlCakesFilter(arg interface{}) model.ApiCakesFilterQuery {
+	input, _ := arg.(map[string]interface{})
+	var query model.ApiCakesFilterQuery
+	query.Keyword, _ = input["keyword"].(string)
+	if minRating, ok := input["minRating"].(float64); ok {
+		rating := float32(minRating)
+		query.MinRating = &rating
+	}
+	if maxRating, ok := input["maxRating"].(float64); ok {
+		rating := float32(maxRating)
+		query.MaxRating = &rating
+	}
+	if hasImage, ok := input["hasImage"].(bool); ok {
+		query.HasImage = &hasImage
+	}
+	if createdAfter, ok := input["createdAfter"].(time.Time); ok {
+		query.CreatedAfter = &createdAfter
+	}
+	if createdBefore, ok := input["createdBefore"].(time.Time); ok {
+		query.CreatedBefore = &createdBefore
+	}
+	return query
+}
+
+func graphqlCakeInput(arg interface{}) model.ApiMutationCakePayload {
+	input, _ := arg.(map[string]interface{})
+	var payload model.ApiMutationCakePayload
+	payload.Title, _ = input["title"].(string)
+	if rating, ok := input["rating"].(float64); ok {
+		payload.Rating = float32(rating)
+	}
+	if description, ok := input["description"].(string); ok {
+		payload.Description = &description
+	}
+	if image, ok := input["image"].(string); ok {
+		payload.Image = &image
+	}
+	return payload
+}
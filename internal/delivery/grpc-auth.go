@@ -0,0 +1,50 @@
+package delivery
+
+import (
+	"context"
+
+	"github.com/forderation/ralali-test/internal/auth"
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	cakev1 "github.com/forderation/ralali-test/proto/cake/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// grpcPublicMethods: unary rpc which does not require token, same as GET on REST API. streaming rpc is read only
+// and is not intercepted
+var grpcPublicMethods = map[string]bool{
+	cakev1.CakeService_ListCakes_FullMethodName: true,
+	cakev1.CakeService_GetCake_FullMethodName:   true,
+}
+
+// GrpcAuthInterceptor: same as AuthMiddleware, verify bearer token of authorization metadata and put its claims
+// into context, token is required on mutation rpc. nil verifier disable authentication
+func GrpcAuthInterceptor(verifier *auth.Verifier, defaultLanguage string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if verifier == nil {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		var token string
+		var ok bool
+		if values := md.Get("authorization"); len(values) > 0 {
+			token, ok = bearerToken(values[0])
+		}
+		if !ok {
+			if grpcPublicMethods[info.FullMethod] {
+				return handler(ctx, req)
+			}
+			lang := grpcLanguage(ctx, defaultLanguage)
+			return nil, grpcStatus(codes.Unauthenticated, model.ErrCodeUnauthorized, i18n.Message(lang, model.ErrCodeUnauthorized, nil), nil)
+		}
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			lang := grpcLanguage(ctx, defaultLanguage)
+			message := i18n.Message(lang, model.ErrCodeInvalidToken, map[string]interface{}{"detail": err.Error()})
+			return nil, grpcStatus(codes.Unauthenticated, model.ErrCodeInvalidToken, message, nil)
+		}
+		return handler(auth.WithClaims(ctx, claims), req)
+	}
+}
@@ -0,0 +1,80 @@
+package delivery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/auth"
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	cakev1 "github.com/forderation/ralali-test/proto/cake/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpcAuthInterceptor(t *testing.T) {
+	interceptor := GrpcAuthInterceptor(newTestVerifier(t), i18n.English)
+	var gotSubject string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		gotSubject = ""
+		if claims, ok := auth.ClaimsFromContext(ctx); ok {
+			gotSubject = claims.Subject
+		}
+		return nil, nil
+	}
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		wantSubject   string
+		wantCode      codes.Code
+		wantReason    string
+	}{
+		{
+			name:   "public method without token",
+			method: cakev1.CakeService_GetCake_FullMethodName,
+		},
+		{
+			name:       "mutation without token",
+			method:     cakev1.CakeService_CreateCake_FullMethodName,
+			wantCode:   codes.Unauthenticated,
+			wantReason: model.ErrCodeUnauthorized,
+		},
+		{
+			name:          "mutation with token",
+			method:        cakev1.CakeService_DeleteCake_FullMethodName,
+			authorization: "Bearer " + signTestToken(t, testJWTSecret, "user-1", time.Hour),
+			wantSubject:   "user-1",
+		},
+		{
+			name:          "public method with expired token",
+			method:        cakev1.CakeService_ListCakes_FullMethodName,
+			authorization: "Bearer " + signTestToken(t, testJWTSecret, "user-1", -time.Hour),
+			wantCode:      codes.Unauthenticated,
+			wantReason:    model.ErrCodeInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+			gotSubject = ""
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.EqualValues(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				reason, _ := grpcErrorReason(err)
+				assert.EqualValues(t, tt.wantReason, reason)
+				return
+			}
+			assert.EqualValues(t, tt.wantSubject, gotSubject)
+		})
+	}
+	_, err := GrpcAuthInterceptor(nil, i18n.English)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: cakev1.CakeService_CreateCake_FullMethodName}, handler)
+	assert.NoError(t, err)
+}
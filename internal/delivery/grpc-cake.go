@@ -0,0 +1,224 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	cakev1 "github.com/forderation/ralali-test/proto/cake/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CakeGrpcServer: gRPC transport of cake usecase, request is validated by the same rules as REST API
+type CakeGrpcServer struct {
+	cakev1.UnimplementedCakeServiceServer
+	cakeUsecase usecase.CakeUsecaseInterface
+	// imageURLValidator: policy of image url on payload, nil only apply model validation
+	imageURLValidator *ImageURLValidator
+	// defaultLanguage: language of error message when accept-language metadata is missing or not supported
+	defaultLanguage string
+}
+
+func NewCakeGrpcServer(cakeUsecase usecase.CakeUsecaseInterface, imageURLValidator *ImageURLValidator, defaultLanguage string) *CakeGrpcServer {
+	return &CakeGrpcServer{
+		cakeUsecase:       cakeUsecase,
+		imageURLValidator: imageURLValidator,
+		defaultLanguage:   defaultLanguage,
+	}
+}
+
+func (s *CakeGrpcServer) ListCakes(ctx context.Context, req *cakev1.ListCakesRequest) (*cakev1.ListCakesResponse, error) {
+	lang := grpcLanguage(ctx, s.defaultLanguage)
+	query := model.ApiGetCakesQuery{
+		ApiCakesFilterQuery: cakesFilterQuery(req.GetFilter()),
+		Page:                int(req.GetPage()),
+		PageSize:            int(req.GetPageSize()),
+		Sort:                req.GetSort(),
+		Cursor:              req.GetCursor(),
+	}
+	if req.GetCursorPagination() {
+		query.Pagination = "cursor"
+	}
+	if err := validateQuery(&query); err != nil {
+		return nil, grpcInvalidArgument(lang, model.ErrCodeInvalidQuery, err)
+	}
+	if query.PageSize > 100 {
+		message := i18n.Message(lang, model.ErrCodePageSizeTooLarge, map[string]interface{}{"max": 100})
+		return nil, grpcStatus(codes.InvalidArgument, model.ErrCodePageSizeTooLarge, message, nil)
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	response, errResponse := s.cakeUsecase.GetCakes(ctx, model.GetCakesUsecaseParam{
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+		Filter:     query.Filter(),
+		Sort:       query.Sort,
+		CursorMode: query.IsCursorMode(),
+		Cursor:     query.Cursor,
+	})
+	if errResponse != nil {
+		return nil, grpcUsecaseError(lang, errResponse)
+	}
+	cakes := make([]*cakev1.Cake, 0, len(response.Data))
+	for _, cake := range response.Data {
+		cakes = append(cakes, cakeMessage(cake))
+	}
+	return &cakev1.ListCakesResponse{
+		Cakes:      cakes,
+		PageCount:  int32(response.Meta.PageCount),
+		TotalData:  response.Meta.TotalData,
+		NextCursor: response.Meta.NextCursor,
+		PrevCursor: response.Meta.PrevCursor,
+	}, nil
+}
+
+// StreamCakes: send every cake matching filter while it is read from database, same as ExportCakes
+func (s *CakeGrpcServer) StreamCakes(req *cakev1.StreamCakesRequest, stream cakev1.CakeService_StreamCakesServer) error {
+	ctx := stream.Context()
+	lang := grpcLanguage(ctx, s.defaultLanguage)
+	query := cakesFilterQuery(req.GetFilter())
+	if err := validateQuery(&query); err != nil {
+		return grpcInvalidArgument(lang, model.ErrCodeInvalidQuery, err)
+	}
+	var sendErr error
+	errResponse := s.cakeUsecase.ExportCakes(ctx, model.ExportCakesUsecaseParam{
+		Filter: query.Filter(),
+		Sort:   req.GetSort(),
+	}, func(cake model.CakeResponse) error {
+		sendErr = stream.Send(cakeMessage(cake))
+		return sendErr
+	})
+	// client is gone or stream is broken, its status is returned as it is
+	if sendErr != nil {
+		return sendErr
+	}
+	if errResponse != nil {
+		return grpcUsecaseError(lang, errResponse)
+	}
+	return nil
+}
+
+func (s *CakeGrpcServer) GetCake(ctx context.Context, req *cakev1.GetCakeRequest) (*cakev1.Cake, error) {
+	response, errResponse := s.cakeUsecase.GetDetailCake(ctx, int(req.GetId()))
+	if errResponse != nil {
+		return nil, grpcUsecaseError(grpcLanguage(ctx, s.defaultLanguage), errResponse)
+	}
+	return cakeMessage(*response), nil
+}
+
+func (s *CakeGrpcServer) CreateCake(ctx context.Context, req *cakev1.CreateCakeRequest) (*cakev1.Cake, error) {
+	lang := grpcLanguage(ctx, s.defaultLanguage)
+	payload, err := validateMutationPayload(ctx, s.imageURLValidator, mutationPayload(req.GetCake()))
+	if err != nil {
+		return nil, grpcInvalidArgument(lang, model.ErrCodeValidationFailed, err)
+	}
+	response, errResponse := s.cakeUsecase.CreateCake(ctx, payload)
+	if errResponse != nil {
+		return nil, grpcUsecaseError(lang, errResponse)
+	}
+	return cakeMessage(*response), nil
+}
+
+func (s *CakeGrpcServer) UpdateCake(ctx context.Context, req *cakev1.UpdateCakeRequest) (*cakev1.Cake, error) {
+	lang := grpcLanguage(ctx, s.defaultLanguage)
+	if req.GetVersion() <= 0 {
+		return nil, grpcVersionRequired(lang)
+	}
+	payload, err := validateMutationPayload(ctx, s.imageURLValidator, mutationPayload(req.GetCake()))
+	if err != nil {
+		return nil, grpcInvalidArgument(lang, model.ErrCodeValidationFailed, err)
+	}
+	response, errResponse := s.cakeUsecase.UpdateCake(ctx, int(req.GetId()), int(req.GetVersion()), payload)
+	if errResponse != nil {
+		return nil, grpcUsecaseError(lang, errResponse)
+	}
+	return cakeMessage(*response), nil
+}
+
+func (s *CakeGrpcServer) DeleteCake(ctx context.Context, req *cakev1.DeleteCakeRequest) (*cakev1.DeleteCakeResponse, error) {
+	lang := grpcLanguage(ctx, s.defaultLanguage)
+	if req.GetVersion() <= 0 {
+		return nil, grpcVersionRequired(lang)
+	}
+	response, errResponse := s.cakeUsecase.DeleteCake(ctx, int(req.GetId()), int(req.GetVersion()))
+	if errResponse != nil {
+		return nil, grpcUsecaseError(lang, errResponse)
+	}
+	return &cakev1.DeleteCakeResponse{Id: int64(response.ID), DeletedAt: timestampMessage(response.DeletedAt)}, nil
+}
+
+// grpcVersionRequired: version is required on mutation same as If-Match on REST, proto3 send omitted version as 0
+// so 0 cannot mean skipping version check
+func grpcVersionRequired(lang string) error {
+	return grpcStatus(codes.InvalidArgument, model.ErrCodeVersionRequired, i18n.Message(lang, model.ErrCodeVersionRequired, nil), nil)
+}
+
+func cakesFilterQuery(filter *cakev1.CakesFilter) model.ApiCakesFilterQuery {
+	if filter == nil {
+		return model.ApiCakesFilterQuery{}
+	}
+	query := model.ApiCakesFilterQuery{
+		Keyword:   filter.Keyword,
+		MinRating: filter.MinRating,
+		MaxRating: filter.MaxRating,
+		HasImage:  filter.HasImage,
+	}
+	if filter.GetCreatedAfter() != nil {
+		createdAfter := filter.GetCreatedAfter().AsTime()
+		query.CreatedAfter = &createdAfter
+	}
+	if filter.GetCreatedBefore() != nil {
+		createdBefore := filter.GetCreatedBefore().AsTime()
+		query.CreatedBefore = &createdBefore
+	}
+	return query
+}
+
+func mutationPayload(cake *cakev1.CakePayload) model.ApiMutationCakePayload {
+	if cake == nil {
+		return model.ApiMutationCakePayload{}
+	}
+	return model.ApiMutationCakePayload{
+		Title:       cake.Title,
+		Description: cake.Description,
+		Rating:      cake.Rating,
+		Image:       cake.Image,
+	}
+}
+
+func cakeMessage(cake model.CakeResponse) *cakev1.Cake {
+	message := &cakev1.Cake{
+		Id:          int64(cake.ID),
+		Title:       cake.Title,
+		Description: cake.Description,
+		Rating:      cake.Rating,
+		Image:       cake.Image,
+		CreatedAt:   timestampMessage(cake.CreatedAt),
+		UpdatedAt:   timestampMessage(cake.UpdatedAt),
+		Version:     int64(cake.Version),
+	}
+	if cake.Images != nil {
+		message.Images = &cakev1.CakeImages{Original: cake.Images.Original}
+		for _, variant := range cake.Images.Variants {
+			message.Images.Variants = append(message.Images.Variants, &cakev1.CakeImageVariant{
+				Width:  int32(variant.Width),
+				Format: variant.Format,
+				Url:    variant.URL,
+			})
+		}
+	}
+	return message
+}
+
+// timestampMessage: RFC3339 timestamp of usecase response as protobuf timestamp, nil when it is empty
+func timestampMessage(value string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
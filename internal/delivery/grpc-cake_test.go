@@ -0,0 +1,425 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	cakev1 "github.com/forderation/ralali-test/proto/cake/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newCakeGrpcClient: client of CakeGrpcServer served on in-memory connection
+func newCakeGrpcClient(t *testing.T, cakeUsecase usecase.CakeUsecaseInterface) cakev1.CakeServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	cakev1.RegisterCakeServiceServer(server, NewCakeGrpcServer(cakeUsecase, nil, i18n.English))
+	go server.Serve(listener)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return cakev1.NewCakeServiceClient(conn)
+}
+
+// grpcErrorReason: error code and invalid fields of status details
+func grpcErrorReason(err error) (string, []string) {
+	var reason string
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			reason = detail.Reason
+		case *errdetails.BadRequest:
+			for _, violation := range detail.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	return reason, fields
+}
+
+func TestCakeGrpcServer_ListCakes(t *testing.T) {
+	nextCursor := "next"
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetCakesFunc = func(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+		if param.Sort == "unknown" {
+			return nil, &model.ErrorResponse{
+				Err:    errors.New("error mock"),
+				Kind:   model.ErrValidation,
+				Code:   model.ErrCodeInvalidSort,
+				Params: map[string]interface{}{"detail": "unknown"},
+			}
+		}
+		return &model.GetCakesResponse{
+			Meta: model.MetaPagination{PageCount: 1, TotalData: int64(param.Page), NextCursor: &nextCursor},
+			Data: []model.CakeResponse{{ID: 1, Title: "title", Version: 2}},
+		}, nil
+	}
+	client := newCakeGrpcClient(t, mockCakeUsecase)
+	minRating := float32(4)
+	maxRating := float32(3)
+	outOfRange := float32(9)
+	tests := []struct {
+		name       string
+		req        *cakev1.ListCakesRequest
+		want       *cakev1.ListCakesResponse
+		wantCode   codes.Code
+		wantReason string
+		wantFields []string
+	}{
+		{
+			name: "basic test",
+			req:  &cakev1.ListCakesRequest{PageSize: 10},
+			want: &cakev1.ListCakesResponse{
+				Cakes:      []*cakev1.Cake{{Id: 1, Title: "title", Version: 2}},
+				PageCount:  1,
+				TotalData:  1,
+				NextCursor: &nextCursor,
+			},
+		},
+		{
+			name:       "page size is required",
+			req:        &cakev1.ListCakesRequest{},
+			wantCode:   codes.InvalidArgument,
+			wantReason: model.ErrCodeInvalidQuery,
+			wantFields: []string{"page_size"},
+		},
+		{
+			name:       "rating out of range",
+			req:        &cakev1.ListCakesRequest{PageSize: 10, Filter: &cakev1.CakesFilter{MinRating: &outOfRange}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: model.ErrCodeInvalidQuery,
+			wantFields: []string{"min_rating"},
+		},
+		{
+			name:       "min rating greater than max rating",
+			req:        &cakev1.ListCakesRequest{PageSize: 10, Filter: &cakev1.CakesFilter{MinRating: &minRating, MaxRating: &maxRating}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: model.ErrCodeInvalidQuery,
+		},
+		{
+			name:       "page size too large",
+			req:        &cakev1.ListCakesRequest{PageSize: 101},
+			wantCode:   codes.InvalidArgument,
+			wantReason: model.ErrCodePageSizeTooLarge,
+		},
+		{
+			name:       "invalid sort",
+			req:        &cakev1.ListCakesRequest{PageSize: 10, Sort: "unknown"},
+			wantCode:   codes.InvalidArgument,
+			wantReason: model.ErrCodeInvalidSort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ListCakes(context.TODO(), tt.req)
+			assert.EqualValues(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				reason, fields := grpcErrorReason(err)
+				assert.EqualValues(t, tt.wantReason, reason)
+				assert.EqualValues(t, tt.wantFields, fields)
+				return
+			}
+			assert.EqualValues(t, fmt.Sprint(tt.want), fmt.Sprint(got))
+		})
+	}
+}
+
+func TestCakeGrpcServer_StreamCakes(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.ExportCakesFunc = func(ctx context.Context, param model.ExportCakesUsecaseParam, fn func(cake model.CakeResponse) error) *model.ErrorResponse {
+		for _, cake := range exportCakesMock {
+			if err := fn(cake); err != nil {
+				return &model.ErrorResponse{Err: err, Kind: model.ErrInternal, Code: model.ErrCodeExportCakesFailed}
+			}
+		}
+		if param.Sort == "broken" {
+			return &model.ErrorResponse{Err: errors.New("error mock"), Kind: model.ErrInternal, Code: model.ErrCodeExportCakesFailed}
+		}
+		return nil
+	}
+	client := newCakeGrpcClient(t, mockCakeUsecase)
+	tests := []struct {
+		name      string
+		req       *cakev1.StreamCakesRequest
+		wantCakes int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "basic test",
+			req:       &cakev1.StreamCakesRequest{},
+			wantCakes: len(exportCakesMock),
+		},
+		{
+			name:      "error after streaming started",
+			req:       &cakev1.StreamCakesRequest{Sort: "broken"},
+			wantCakes: len(exportCakesMock),
+			wantCode:  codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.StreamCakes(context.TODO(), tt.req)
+			if !assert.NoError(t, err) {
+				return
+			}
+			cakes := 0
+			for {
+				_, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				cakes++
+			}
+			assert.EqualValues(t, tt.wantCakes, cakes)
+			if tt.wantCode == codes.OK {
+				assert.ErrorIs(t, err, io.EOF)
+				return
+			}
+			assert.EqualValues(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestCakeGrpcServer_GetCake(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		if id != 1 {
+			return nil, &model.ErrorResponse{
+				Err:    fmt.Errorf("cake data with id %d not found", id),
+				Kind:   model.ErrCakeNotFound,
+				Code:   model.ErrCodeCakeNotFound,
+				Params: map[string]interface{}{"id": id},
+			}
+		}
+		return &model.CakeResponse{ID: id, Title: "title", CreatedAt: "2023-01-02T08:04:05Z", UpdatedAt: "2023-01-03T08:04:05Z", Version: 3}, nil
+	}
+	client := newCakeGrpcClient(t, mockCakeUsecase)
+	tests := []struct {
+		name           string
+		id             int64
+		acceptLanguage string
+		want           *cakev1.Cake
+		wantCode       codes.Code
+		wantMessage    string
+	}{
+		{
+			name: "basic test",
+			id:   1,
+			want: &cakev1.Cake{
+				Id:        1,
+				Title:     "title",
+				CreatedAt: timestamppb.New(time.Date(2023, 1, 2, 8, 4, 5, 0, time.UTC)),
+				UpdatedAt: timestamppb.New(time.Date(2023, 1, 3, 8, 4, 5, 0, time.UTC)),
+				Version:   3,
+			},
+		},
+		{
+			name:        "cake not found",
+			id:          9,
+			wantCode:    codes.NotFound,
+			wantMessage: "cake data with id 9 not found",
+		},
+		{
+			name:           "message is translated by accept-language metadata",
+			id:             9,
+			acceptLanguage: "id-ID,id;q=0.9",
+			wantCode:       codes.NotFound,
+			wantMessage:    "data kue dengan id 9 tidak ditemukan",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			if tt.acceptLanguage != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "accept-language", tt.acceptLanguage)
+			}
+			got, err := client.GetCake(ctx, &cakev1.GetCakeRequest{Id: tt.id})
+			assert.EqualValues(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				reason, _ := grpcErrorReason(err)
+				assert.EqualValues(t, model.ErrCodeCakeNotFound, reason)
+				assert.EqualValues(t, tt.wantMessage, status.Convert(err).Message())
+				return
+			}
+			assert.EqualValues(t, fmt.Sprint(tt.want), fmt.Sprint(got))
+		})
+	}
+}
+
+func TestCakeGrpcServer_CreateCake(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.CreateCakeFunc = func(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{ID: 1, Title: payload.Title, Rating: payload.Rating, Version: 1}, nil
+	}
+	client := newCakeGrpcClient(t, mockCakeUsecase)
+	image := "ftp://example.com/cake.png"
+	tests := []struct {
+		name       string
+		req        *cakev1.CreateCakeRequest
+		want       *cakev1.Cake
+		wantCode   codes.Code
+		wantFields []string
+	}{
+		{
+			name: "basic test",
+			req:  &cakev1.CreateCakeRequest{Cake: &cakev1.CakePayload{Title: "  title ", Rating: 4}},
+			want: &cakev1.Cake{Id: 1, Title: "title", Rating: 4, Version: 1},
+		},
+		{
+			name:       "missing cake",
+			req:        &cakev1.CreateCakeRequest{},
+			wantCode:   codes.InvalidArgument,
+			wantFields: []string{"title", "rating"},
+		},
+		{
+			name:       "every invalid field",
+			req:        &cakev1.CreateCakeRequest{Cake: &cakev1.CakePayload{Title: "title", Rating: 7, Image: &image}},
+			wantCode:   codes.InvalidArgument,
+			wantFields: []string{"image", "rating"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.CreateCake(context.TODO(), tt.req)
+			assert.EqualValues(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				reason, fields := grpcErrorReason(err)
+				assert.EqualValues(t, model.ErrCodeValidationFailed, reason)
+				assert.ElementsMatch(t, tt.wantFields, fields)
+				return
+			}
+			assert.EqualValues(t, fmt.Sprint(tt.want), fmt.Sprint(got))
+		})
+	}
+}
+
+func TestCakeGrpcServer_UpdateCake(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.UpdateCakeFunc = func(ctx context.Context, id int, version int, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
+		if version != 2 {
+			return nil, &model.ErrorResponse{
+				Err:    errors.New("error mock"),
+				Kind:   model.ErrVersionMismatch,
+				Code:   model.ErrCodeCakeModified,
+				Params: map[string]interface{}{"id": id, "version": 2},
+			}
+		}
+		return &model.CakeResponse{ID: id, Title: payload.Title, Rating: payload.Rating, Version: 3}, nil
+	}
+	client := newCakeGrpcClient(t, mockCakeUsecase)
+	tests := []struct {
+		name     string
+		req      *cakev1.UpdateCakeRequest
+		wantCode codes.Code
+	}{
+		{
+			name: "basic test",
+			req:  &cakev1.UpdateCakeRequest{Id: 1, Version: 2, Cake: &cakev1.CakePayload{Title: "title", Rating: 4}},
+		},
+		{
+			name:     "missing version",
+			req:      &cakev1.UpdateCakeRequest{Id: 1, Cake: &cakev1.CakePayload{Title: "title", Rating: 4}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "negative version",
+			req:      &cakev1.UpdateCakeRequest{Id: 1, Version: -1, Cake: &cakev1.CakePayload{Title: "title", Rating: 4}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "version not match",
+			req:      &cakev1.UpdateCakeRequest{Id: 1, Version: 1, Cake: &cakev1.CakePayload{Title: "title", Rating: 4}},
+			wantCode: codes.Aborted,
+		},
+		{
+			name:     "invalid payload",
+			req:      &cakev1.UpdateCakeRequest{Id: 1, Version: 2, Cake: &cakev1.CakePayload{Rating: 4}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UpdateCake(context.TODO(), tt.req)
+			assert.EqualValues(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestCakeGrpcServer_DeleteCake(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.DeleteCakeFunc = func(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
+		if id != 1 {
+			return nil, &model.ErrorResponse{
+				Err:     errors.New("error delete cake"),
+				Kind:    model.ErrInternal,
+				Code:    model.ErrCodeDeleteCakeFailed,
+				ErrData: model.ErrorDetailResponse{Detail: "driver error"},
+			}
+		}
+		return &model.CakeDeleteResponse{ID: id, DeletedAt: "2023-01-04T08:04:05Z"}, nil
+	}
+	client := newCakeGrpcClient(t, mockCakeUsecase)
+	tests := []struct {
+		name     string
+		id       int64
+		version  int64
+		want     int64
+		wantCode codes.Code
+	}{
+		{
+			name:    "basic test",
+			id:      1,
+			version: 1,
+			want:    1,
+		},
+		{
+			name:     "error test",
+			id:       2,
+			version:  1,
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "missing version",
+			id:       1,
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.DeleteCake(context.TODO(), &cakev1.DeleteCakeRequest{Id: tt.id, Version: tt.version})
+			assert.EqualValues(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				assert.NotContains(t, status.Convert(err).Message(), "driver error")
+				return
+			}
+			assert.EqualValues(t, tt.want, got.GetId())
+			assert.EqualValues(t, time.Date(2023, 1, 4, 8, 4, 5, 0, time.UTC), got.GetDeletedAt().AsTime())
+		})
+	}
+}
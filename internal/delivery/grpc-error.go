@@ -0,0 +1,81 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcErrorDomain: domain of google.rpc.ErrorInfo, its reason is the error code
+const grpcErrorDomain = "cake.v1"
+
+// grpcLanguage: pick language of error message from accept-language metadata, same as LanguageMiddleware
+func grpcLanguage(ctx context.Context, defaultLanguage string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return i18n.MatchLanguage(strings.Join(md.Get("accept-language"), ","), defaultLanguage)
+}
+
+// grpcCode: map domain error into gRPC status code
+func grpcCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, model.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, model.ErrVersionMismatch):
+		return codes.Aborted
+	case errors.Is(err, model.ErrConflict):
+		return codes.FailedPrecondition
+	case errors.Is(err, model.ErrValidation), errors.Is(err, model.ErrUnsupported):
+		return codes.InvalidArgument
+	case errors.Is(err, model.ErrUnauthenticated):
+		return codes.Unauthenticated
+	}
+	return codes.Internal
+}
+
+// grpcUsecaseError: map error returned by usecase into status
+func grpcUsecaseError(lang string, errResponse *model.ErrorResponse) error {
+	code, message, fieldErrs := localizeUsecaseError(lang, errResponse)
+	return grpcStatus(grpcCode(errResponse), code, message, fieldErrs)
+}
+
+// grpcInvalidArgument: invalid request as InvalidArgument status, same as invalidPayload every invalid field is
+// listed as google.rpc.BadRequest field violation
+func grpcInvalidArgument(lang string, code string, err error) error {
+	message, fieldErrs := localizeInvalidArgument(lang, code, err)
+	return grpcStatus(codes.InvalidArgument, code, message, fieldErrs)
+}
+
+// grpcStatus: status with error code as google.rpc.ErrorInfo reason
+func grpcStatus(code codes.Code, errCode string, message string, fieldErrs model.ValidationErrors) error {
+	st := status.New(code, message)
+	info := &errdetails.ErrorInfo{Reason: errCode, Domain: grpcErrorDomain}
+	var detailed *status.Status
+	var err error
+	if len(fieldErrs) == 0 {
+		detailed, err = st.WithDetails(info)
+	} else {
+		badRequest := &errdetails.BadRequest{}
+		for _, fieldErr := range fieldErrs {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fieldErr.Field,
+				Description: fieldErr.Message,
+			})
+		}
+		detailed, err = st.WithDetails(info, badRequest)
+	}
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
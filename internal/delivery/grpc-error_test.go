@@ -0,0 +1,35 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGrpcCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "cake not found", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrCakeNotFound}, want: codes.NotFound},
+		{name: "version mismatch", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrVersionMismatch}, want: codes.Aborted},
+		{name: "conflict", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrConflict}, want: codes.FailedPrecondition},
+		{name: "validation", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrValidation}, want: codes.InvalidArgument},
+		{name: "unsupported", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrUnsupported}, want: codes.InvalidArgument},
+		{name: "unauthenticated", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrUnauthenticated}, want: codes.Unauthenticated},
+		{name: "internal", err: &model.ErrorResponse{Err: errors.New("mock"), Kind: model.ErrInternal}, want: codes.Internal},
+		{name: "cancelled request", err: &model.ErrorResponse{Err: fmt.Errorf("error get cake data: %w", context.Canceled), Kind: model.ErrInternal}, want: codes.Canceled},
+		{name: "without kind", err: errors.New("mock"), want: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualValues(t, tt.want, grpcCode(tt.err))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/forderation/ralali-test/internal/auth"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyHeader: request header of api key, used by machine client which cannot obtain bearer token
+const apiKeyHeader = "X-API-Key"
+
+type ApiKeyDelivery struct {
+	apiKeyUsecase usecase.ApiKeyUsecaseInterface
+}
+
+func NewApiKeyDelivery(apiKeyUsecase usecase.ApiKeyUsecaseInterface) *ApiKeyDelivery {
+	return &ApiKeyDelivery{
+		apiKeyUsecase: apiKeyUsecase,
+	}
+}
+
+// ApiKeyMiddleware: authenticate X-API-Key header and put the key into request context, scope of the key is checked
+// per route by RequireScope. request without the header is left to AuthMiddleware
+func (d *ApiKeyDelivery) ApiKeyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(apiKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		apiKey, errResponse := d.apiKeyUsecase.AuthenticateApiKey(c.Request.Context(), key)
+		if errResponse != nil {
+			usecaseErrorResponse(c, errResponse)
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(auth.WithApiKey(c.Request.Context(), apiKey))
+		c.Next()
+	}
+}
+
+// CreateApiKey godoc
+//
+//	@Summary		CreateApiKey
+//	@Description	create api key of machine client, key is only returned on this response
+//	@Tags			api-keys
+//	@Param			data	body	model.ApiCreateApiKeyPayload	true	"body data".
+//	@Produce		json
+//	@Success		201	{object}	model.CreateApiKeyResponse
+//	@Failure		401	{object}	model.ProblemDetails
+//	@Failure		403	{object}	model.ProblemDetails
+//	@Failure		422	{object}	model.ProblemDetails{errors=model.ValidationErrors}
+//	@Security		BearerAuth
+//	@Router			/admin/api-keys [post]
+func (d *ApiKeyDelivery) CreateApiKey(c *gin.Context) {
+	var payload model.ApiCreateApiKeyPayload
+	err := validatePayload(c.ShouldBindJSON(&payload), &payload)
+	if err != nil {
+		invalidPayload(c, err)
+		return
+	}
+	response, errResponse := d.apiKeyUsecase.CreateApiKey(c.Request.Context(), model.CreateApiKeyUsecaseParam{
+		Name:   payload.Name,
+		Scopes: payload.Scopes,
+	})
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetApiKeys godoc
+//
+//	@Summary		GetApiKeys
+//	@Description	every api key including revoked one, secret is never returned
+//	@Tags			api-keys
+//	@Produce		json
+//	@Success		200	{object}	model.ApiKeysResponse
+//	@Failure		401	{object}	model.ProblemDetails
+//	@Failure		403	{object}	model.ProblemDetails
+//	@Security		BearerAuth
+//	@Router			/admin/api-keys [get]
+func (d *ApiKeyDelivery) GetApiKeys(c *gin.Context) {
+	response, errResponse := d.apiKeyUsecase.GetApiKeys(c.Request.Context())
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeApiKey godoc
+//
+//	@Summary		RevokeApiKey
+//	@Description	revoked key cannot authenticate anymore, revoking revoked key respond it as it is
+//	@Tags			api-keys
+//	@Param			id	path	string	true	"param id (api key record)"
+//	@Produce		json
+//	@Success		200	{object}	model.ApiKeyResponse
+//	@Failure		401	{object}	model.ProblemDetails
+//	@Failure		403	{object}	model.ProblemDetails
+//	@Failure		404	{object}	model.ProblemDetails
+//	@Security		BearerAuth
+//	@Router			/admin/api-keys/{id} [delete]
+func (d *ApiKeyDelivery) RevokeApiKey(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidParameterID, nil, nil)
+		return
+	}
+	response, errResponse := d.apiKeyUsecase.RevokeApiKey(c.Request.Context(), id)
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
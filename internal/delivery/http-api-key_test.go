@@ -0,0 +1,300 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestApiKeyUsecase: "read-key" is granted cakes:read, "write-key" is granted cakes:write, other key is invalid
+func newTestApiKeyUsecase() *usecase.ApiKeyUsecaseInterfaceMock {
+	mockApiKeyUsecase := &usecase.ApiKeyUsecaseInterfaceMock{}
+	mockApiKeyUsecase.AuthenticateApiKeyFunc = func(ctx context.Context, key string) (*model.AuthenticatedApiKey, *model.ErrorResponse) {
+		switch key {
+		case "read-key":
+			return &model.AuthenticatedApiKey{ID: 1, Scopes: []string{model.ScopeCakesRead}}, nil
+		case "write-key":
+			return &model.AuthenticatedApiKey{ID: 2, Scopes: []string{model.ScopeCakesWrite}}, nil
+		}
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrUnauthenticated,
+			Err:  errors.New("invalid api key"),
+			Code: model.ErrCodeInvalidApiKey,
+		}
+	}
+	return mockApiKeyUsecase
+}
+
+func TestApiKeyDelivery_ApiKeyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	d := NewApiKeyDelivery(newTestApiKeyUsecase())
+	router := gin.New()
+	cakeRoutes := router.Group("/v1/cakes", d.ApiKeyMiddleware(), AuthMiddleware(newTestVerifier(t)))
+	cakeRoutes.GET("", RequireScope(model.ScopeCakesRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	cakeRoutes.POST("", RequireScope(model.ScopeCakesWrite), func(c *gin.Context) { c.Status(http.StatusCreated) })
+	tests := []struct {
+		name        string
+		method      string
+		apiKey      string
+		wantCode    int
+		wantErrCode string
+	}{
+		{
+			name:     "get without api key",
+			method:   http.MethodGet,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "get with read scope",
+			method:   http.MethodGet,
+			apiKey:   "read-key",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:        "get without read scope",
+			method:      http.MethodGet,
+			apiKey:      "write-key",
+			wantCode:    http.StatusForbidden,
+			wantErrCode: model.ErrCodeInsufficientScope,
+		},
+		{
+			name:     "post with write scope does not need bearer token",
+			method:   http.MethodPost,
+			apiKey:   "write-key",
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:        "post without write scope",
+			method:      http.MethodPost,
+			apiKey:      "read-key",
+			wantCode:    http.StatusForbidden,
+			wantErrCode: model.ErrCodeInsufficientScope,
+		},
+		{
+			name:        "invalid api key",
+			method:      http.MethodGet,
+			apiKey:      "unknown-key",
+			wantCode:    http.StatusUnauthorized,
+			wantErrCode: model.ErrCodeInvalidApiKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/v1/cakes", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(apiKeyHeader, tt.apiKey)
+			}
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			if tt.wantErrCode != "" {
+				assert.Contains(t, w.Body.String(), tt.wantErrCode)
+			}
+		})
+	}
+}
+
+func TestApiKeyDelivery_ApiKeyMiddleware_graphql(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{ID: id}, nil
+	}
+	mockCakeUsecase.RestoreCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{ID: id}, nil
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/graphql", NewApiKeyDelivery(newTestApiKeyUsecase()).ApiKeyMiddleware(), OptionalAuthMiddleware(newTestVerifier(t)), NewGraphqlDelivery(mockCakeUsecase, nil, 1000).Query)
+	tests := []struct {
+		name        string
+		query       string
+		apiKey      string
+		wantCode    int
+		wantErrCode string
+	}{
+		{
+			name:     "query with read scope",
+			query:    `{ cake(id: 1) { id } }`,
+			apiKey:   "read-key",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:        "query without read scope",
+			query:       `{ cake(id: 1) { id } }`,
+			apiKey:      "write-key",
+			wantCode:    http.StatusForbidden,
+			wantErrCode: model.ErrCodeInsufficientScope,
+		},
+		{
+			name:     "mutation with write scope",
+			query:    `mutation { restoreCake(id: 1) { id } }`,
+			apiKey:   "write-key",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:        "mutation without write scope",
+			query:       `mutation { restoreCake(id: 1) { id } }`,
+			apiKey:      "read-key",
+			wantCode:    http.StatusForbidden,
+			wantErrCode: model.ErrCodeInsufficientScope,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]interface{}{"query": tt.query})
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(apiKeyHeader, tt.apiKey)
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			if tt.wantErrCode != "" {
+				var response graphqlTestResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				if assert.Len(t, response.Errors, 1) {
+					assert.EqualValues(t, tt.wantErrCode, response.Errors[0].Extensions.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestApiKeyDelivery_CreateApiKey(t *testing.T) {
+	var gotParam model.CreateApiKeyUsecaseParam
+	mockApiKeyUsecase := &usecase.ApiKeyUsecaseInterfaceMock{}
+	mockApiKeyUsecase.CreateApiKeyFunc = func(ctx context.Context, param model.CreateApiKeyUsecaseParam) (*model.CreateApiKeyResponse, *model.ErrorResponse) {
+		gotParam = param
+		return &model.CreateApiKeyResponse{ApiKeyResponse: model.ApiKeyResponse{ID: 1, Name: param.Name, Scopes: param.Scopes}, Key: "rk_abcd_secret"}, nil
+	}
+	tests := []struct {
+		name       string
+		body       string
+		wantCode   int
+		wantParam  model.CreateApiKeyUsecaseParam
+		wantNoSave bool
+	}{
+		{
+			name:      "basic test",
+			body:      `{"name":" partner ","scopes":["cakes:read","cakes:read","cakes:write"]}`,
+			wantCode:  http.StatusCreated,
+			wantParam: model.CreateApiKeyUsecaseParam{Name: "partner", Scopes: []string{model.ScopeCakesRead, model.ScopeCakesWrite}},
+		},
+		{
+			name:       "unknown scope",
+			body:       `{"name":"partner","scopes":["api_keys:admin"]}`,
+			wantCode:   http.StatusUnprocessableEntity,
+			wantNoSave: true,
+		},
+		{
+			name:       "empty scopes",
+			body:       `{"name":"partner","scopes":[]}`,
+			wantCode:   http.StatusUnprocessableEntity,
+			wantNoSave: true,
+		},
+		{
+			name:       "invalid body",
+			body:       `{"name":`,
+			wantCode:   http.StatusBadRequest,
+			wantNoSave: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotParam = model.CreateApiKeyUsecaseParam{}
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/admin/api-keys", bytes.NewBufferString(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			d := &ApiKeyDelivery{
+				apiKeyUsecase: mockApiKeyUsecase,
+			}
+			d.CreateApiKey(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			if tt.wantNoSave {
+				assert.Empty(t, gotParam.Name)
+				return
+			}
+			assert.EqualValues(t, tt.wantParam, gotParam)
+			assert.EqualValues(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.Contains(t, w.Body.String(), `"key":"rk_abcd_secret"`)
+		})
+	}
+}
+
+func TestApiKeyDelivery_GetApiKeys(t *testing.T) {
+	mockApiKeyUsecase := &usecase.ApiKeyUsecaseInterfaceMock{}
+	mockApiKeyUsecase.GetApiKeysFunc = func(ctx context.Context) (*model.ApiKeysResponse, *model.ErrorResponse) {
+		return &model.ApiKeysResponse{Data: []model.ApiKeyResponse{{ID: 1, Name: "partner"}}}, nil
+	}
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+	d := &ApiKeyDelivery{
+		apiKeyUsecase: mockApiKeyUsecase,
+	}
+	d.GetApiKeys(ctx)
+	assert.EqualValues(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret_hash")
+}
+
+func TestApiKeyDelivery_RevokeApiKey(t *testing.T) {
+	mockApiKeyUsecase := &usecase.ApiKeyUsecaseInterfaceMock{}
+	mockApiKeyUsecase.RevokeApiKeyFunc = func(ctx context.Context, id int) (*model.ApiKeyResponse, *model.ErrorResponse) {
+		if id == 1 {
+			revokedAt := "2006-01-02T15:04:05Z"
+			return &model.ApiKeyResponse{ID: id, RevokedAt: &revokedAt}, nil
+		}
+		return nil, &model.ErrorResponse{
+			Err:  errors.New("error mock"),
+			Kind: model.ErrApiKeyNotFound,
+			Code: model.ErrCodeApiKeyNotFound,
+		}
+	}
+	tests := []struct {
+		name     string
+		id       string
+		wantCode int
+	}{
+		{
+			name:     "basic test",
+			id:       "1",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "not found",
+			id:       "2",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "invalid id",
+			id:       "abc",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: http.MethodDelete,
+			}
+			ctx.AddParam("id", tt.id)
+			d := &ApiKeyDelivery{
+				apiKeyUsecase: mockApiKeyUsecase,
+			}
+			d.RevokeApiKey(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+		})
+	}
+}
@@ -1,22 +1,55 @@
 package delivery
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/forderation/ralali-test/internal/model"
 	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/forderation/ralali-test/util"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
 )
 
+// allowedImageTypes: content type of cake image which can be uploaded
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
 type CakeDelivery struct {
 	cakeUsecase usecase.CakeUsecaseInterface
+	// imageMaxSize: maximum size in bytes of uploaded cake image
+	imageMaxSize int64
+	// imageURLValidator: policy of image url on payload, nil only apply model validation
+	imageURLValidator *ImageURLValidator
 }
 
-func NewCakeDelivery(cakeUsecase usecase.CakeUsecaseInterface) *CakeDelivery {
+func NewCakeDelivery(cakeUsecase usecase.CakeUsecaseInterface, imageMaxSize int64, imageURLValidator *ImageURLValidator) *CakeDelivery {
 	return &CakeDelivery{
-		cakeUsecase: cakeUsecase,
+		cakeUsecase:       cakeUsecase,
+		imageMaxSize:      imageMaxSize,
+		imageURLValidator: imageURLValidator,
+	}
+}
+
+// validateImageURL: apply image url policy on validated payload image
+func (d *CakeDelivery) validateImageURL(ctx context.Context, image *string, checkExistence bool) error {
+	if image == nil || d.imageURLValidator == nil {
+		return nil
 	}
+	return d.imageURLValidator.Validate(ctx, *image, checkExistence)
 }
 
 // GetCakes godoc
@@ -25,33 +58,76 @@ func NewCakeDelivery(cakeUsecase usecase.CakeUsecaseInterface) *CakeDelivery {
 //	@Tags		cakes
 //	@Param		page		query	integer	false	"default page is at page 1"
 //	@Param		page_size	query	integer	true	"maximum value is 100"
+//	@Param		q			query	string	false	"search keyword on title and description"
+//	@Param		min_rating	query	number	false	"minimum rating, range 0-5"
+//	@Param		max_rating	query	number	false	"maximum rating, range 0-5"
+//	@Param		has_image	query	boolean	false	"filter cake with / without image"
+//	@Param		created_after	query	string	false	"RFC3339 datetime, e.g. 2023-01-02T15:04:05Z"
+//	@Param		created_before	query	string	false	"RFC3339 datetime, e.g. 2023-01-02T15:04:05Z"
+//	@Param		pagination		query	string	false	"page (default) or cursor, cursor mode use next_cursor / prev_cursor on meta instead of page"	Enums(page, cursor)
+//	@Param		cursor			query	string	false	"opaque cursor from meta next_cursor / prev_cursor, implies cursor pagination"
+//	@Param		sort			query	string	false	"comma separated of id, title, rating, created_at, updated_at, prefix '-' for descending e.g. -created_at,title (default -rating,title)"
 //	@Produce	json
+//	@Param		If-None-Match		header	string	false	"ETag from previous response, return 304 when listing not changed"
+//	@Param		If-Modified-Since	header	string	false	"Last-Modified from previous response, return 304 when listing not changed"
 //	@Success	200	{object}	model.GetCakesResponse
-//	@Router		/cakes [get]
+//	@Header		200	{string}	ETag			"weak entity tag of listing, changed by any mutation of cake matching filter"
+//	@Header		200	{string}	Last-Modified	"latest updated / deleted time of cake matching filter"
+//	@Success	304
+//	@Failure	400	{object}	model.ProblemDetails
+//	@Router		/v1/cakes [get]
 func (d *CakeDelivery) GetCakes(c *gin.Context) {
 	ctx := c.Request.Context()
 	var query model.ApiGetCakesQuery
 	err := c.ShouldBind(&query)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: "invalid query parameter:" + err.Error()})
+		invalidQuery(c, err, &query)
 		return
 	}
 	if query.PageSize > 100 {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: "maximum page_size is 100"})
+		errorResponse(c, http.StatusBadRequest, model.ErrCodePageSizeTooLarge, map[string]interface{}{"max": 100}, nil)
+		return
+	}
+	err = query.Validate()
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidQuery, map[string]interface{}{"detail": err.Error()}, nil)
 		return
 	}
 	if query.Page <= 0 {
 		query.Page = 1
 	}
+	// validator is read before the listing, so listing changed in between is only served under an older tag
+	// and the next conditional request miss, never the other way around
+	modified, errResponse := d.cakeUsecase.GetCakesModified(ctx, query.Filter())
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	etag := cakesETag(c, *modified)
+	c.Header("ETag", etag)
+	if !modified.ModifiedAt.IsZero() {
+		c.Header("Last-Modified", modified.ModifiedAt.UTC().Format(http.TimeFormat))
+	}
+	// If-Modified-Since is only evaluated when If-None-Match is absent (RFC 7232 section 6)
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if util.MatchIfNoneMatch(ifNoneMatch, etag) ||
+		(ifNoneMatch == "" && !modified.ModifiedAt.IsZero() && util.NotModifiedSince(c.GetHeader("If-Modified-Since"), modified.ModifiedAt)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
 	response, errResponse := d.cakeUsecase.GetCakes(ctx, model.GetCakesUsecaseParam{
-		Page:     query.Page,
-		PageSize: query.PageSize,
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+		Filter:     query.Filter(),
+		Sort:       query.Sort,
+		CursorMode: query.IsCursorMode(),
+		Cursor:     query.Cursor,
 	})
 	if errResponse != nil {
-		c.JSON(errResponse.HttpStatusCode, model.JsonErrorResp{ErrorMessage: errResponse.Err.Error(), ErrData: errResponse.ErrData})
+		usecaseErrorResponse(c, errResponse)
 		return
 	}
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, cakesBody(c, *response))
 	return
 }
 
@@ -59,24 +135,191 @@ func (d *CakeDelivery) GetCakes(c *gin.Context) {
 //
 //	@Summary	GetCake
 //	@Tags		cakes
-//	@Param		id	path	string	true	"param id (cake record)"
+//	@Param		id					path	string	true	"param id (cake record)"
+//	@Param		If-None-Match		header	string	false	"ETag from previous response, return 304 when cake not changed"
+//	@Param		If-Modified-Since	header	string	false	"Last-Modified from previous response, return 304 when cake not changed"
 //	@Produce	json
 //	@Success	200	{object}	model.CakeResponse
-//	@Router		/cakes/{id} [get]
+//	@Header		200	{string}	ETag			"version of cake, used on If-Match header of mutation"
+//	@Header		200	{string}	Last-Modified	"last updated time of cake"
+//	@Success	304
+//	@Router		/v1/cakes/{id} [get]
 func (d *CakeDelivery) GetCake(c *gin.Context) {
 	ctx := c.Request.Context()
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: "invalid parameter id"})
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidParameterID, nil, nil)
 		return
 	}
 	response, errResponse := d.cakeUsecase.GetDetailCake(ctx, id)
 	if errResponse != nil {
-		c.JSON(errResponse.HttpStatusCode, model.JsonErrorResp{ErrorMessage: errResponse.Err.Error(), ErrData: errResponse.ErrData})
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	etag := cakeETag(c, response.Version)
+	c.Header("ETag", etag)
+	// updated_at of usecase response is RFC3339 on second precision, the same precision of Last-Modified
+	modifiedAt, _ := time.Parse(time.RFC3339, response.UpdatedAt)
+	c.Header("Last-Modified", modifiedAt.UTC().Format(http.TimeFormat))
+	// If-Modified-Since is only evaluated when If-None-Match is absent (RFC 7232 section 6)
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if util.MatchIfNoneMatch(ifNoneMatch, etag) || (ifNoneMatch == "" && util.NotModifiedSince(c.GetHeader("If-Modified-Since"), modifiedAt)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, cakeBody(c, *response))
+	return
+}
+
+// ExportCakes godoc
+//
+//	@Summary		ExportCakes
+//	@Description	export every cake matching filter as file, rows are streamed from database.
+//	@Description	when error occurred after streaming started the file is truncated (xlsx become invalid)
+//	@Description	on csv and xlsx, text starting with =, +, -, @, tab or carriage return is prefixed by ' so it is not evaluated as formula
+//	@Tags			cakes
+//	@Param			format			query	string	true	"export format"	Enums(csv, ndjson, xlsx)
+//	@Param			q				query	string	false	"search keyword on title and description"
+//	@Param			min_rating		query	number	false	"minimum rating, range 0-5"
+//	@Param			max_rating		query	number	false	"maximum rating, range 0-5"
+//	@Param			has_image		query	boolean	false	"filter cake with / without image"
+//	@Param			created_after	query	string	false	"RFC3339 datetime, e.g. 2023-01-02T15:04:05Z"
+//	@Param			created_before	query	string	false	"RFC3339 datetime, e.g. 2023-01-02T15:04:05Z"
+//	@Param			sort			query	string	false	"same as GetCakes sort"
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	model.ProblemDetails
+//	@Router			/v1/cakes/export [get]
+func (d *CakeDelivery) ExportCakes(c *gin.Context) {
+	ctx := c.Request.Context()
+	var query model.ApiExportCakesQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		invalidQuery(c, err, &query)
+		return
+	}
+	err = query.Validate()
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidQuery, map[string]interface{}{"detail": err.Error()}, nil)
+		return
+	}
+	format := cakeExportFormats[query.Format]
+	var encoder cakeExportEncoder
+	// start: response is started on first row so error before it can still be returned as json
+	start := func() error {
+		c.Header("Content-Type", format.ContentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="cakes.%s"`, format.Extension))
+		c.Status(http.StatusOK)
+		encoder, err = newCakeExportEncoder(query.Format, c.Writer)
+		return err
+	}
+	errResponse := d.cakeUsecase.ExportCakes(ctx, model.ExportCakesUsecaseParam{
+		Filter: query.Filter(),
+		Sort:   query.Sort,
+	}, func(cake model.CakeResponse) error {
+		if encoder == nil {
+			err := start()
+			if err != nil {
+				return err
+			}
+		}
+		return encoder.Encode(zonedCake(c, cake))
+	})
+	if errResponse != nil {
+		if c.Writer.Written() {
+			logrus.Errorf("error export cakes after response started: %s %v", errResponse.Err, errResponse.ErrData)
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		usecaseErrorResponse(c, errResponse)
 		return
 	}
-	c.JSON(http.StatusOK, response)
+	if encoder == nil {
+		err = start()
+		if err != nil {
+			logrus.Error("error export cakes: ", err)
+			return
+		}
+	}
+	err = encoder.Close()
+	if err != nil {
+		logrus.Error("error export cakes: ", err)
+	}
+	return
+}
+
+// GetDeletedCakes godoc
+//
+//	@Summary		GetDeletedCakes
+//	@Description	listing of soft deleted cakes (trash) ordered by latest deleted
+//	@Tags			cakes
+//	@Param			page		query	integer	false	"default page is at page 1"
+//	@Param			page_size	query	integer	true	"maximum value is 100"
+//	@Produce		json
+//	@Success		200	{object}	model.GetCakesResponse
+//	@Failure		400	{object}	model.ProblemDetails
+//	@Router			/v1/cakes/trash [get]
+func (d *CakeDelivery) GetDeletedCakes(c *gin.Context) {
+	ctx := c.Request.Context()
+	var query model.ApiGetDeletedCakesQuery
+	err := c.ShouldBind(&query)
+	if err != nil {
+		invalidQuery(c, err, &query)
+		return
+	}
+	if query.PageSize > 100 {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodePageSizeTooLarge, map[string]interface{}{"max": 100}, nil)
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	response, errResponse := d.cakeUsecase.GetDeletedCakes(ctx, model.GetDeletedCakesUsecaseParam{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	})
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	c.JSON(http.StatusOK, cakesBody(c, *response))
+	return
+}
+
+// RestoreCake godoc
+//
+//	@Summary		RestoreCake
+//	@Description	restore soft deleted cake
+//	@Tags			cakes
+//	@Param			id	path	string	true	"param id (cake record)"
+//	@Produce		json
+//	@Success		200	{object}	model.CakeResponse
+//	@Header			200	{string}	ETag	"new version of cake"
+//	@Failure		401	{object}	model.ProblemDetails
+//	@Failure		404	{object}	model.ProblemDetails
+//	@Failure		409	{object}	model.ProblemDetails
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Router			/v1/cakes/{id}/restore [post]
+func (d *CakeDelivery) RestoreCake(c *gin.Context) {
+	ctx := c.Request.Context()
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidParameterID, nil, nil)
+		return
+	}
+	response, errResponse := d.cakeUsecase.RestoreCake(ctx, id)
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	c.Header("ETag", cakeETag(c, response.Version))
+	c.JSON(http.StatusOK, cakeBody(c, *response))
 	return
 }
 
@@ -86,19 +329,22 @@ func (d *CakeDelivery) GetCake(c *gin.Context) {
 //	@Tags		cakes
 //	@Param		data	body	model.ApiMutationCakePayload	true	"body data".
 //	@Produce	json
-//	@Success	200	{object}	model.CakeMutationResponse
-//	@Router		/cakes [post]
+//	@Success	201	{object}	model.CakeResponse
+//	@Header		201	{string}	Location	"path of created cake"
+//	@Failure	401	{object}	model.ProblemDetails
+//	@Failure	422	{object}	model.ProblemDetails{errors=model.ValidationErrors}
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Router		/v1/cakes [post]
 func (d *CakeDelivery) CreateCake(c *gin.Context) {
 	ctx := c.Request.Context()
 	var payload model.ApiMutationCakePayload
-	err := c.ShouldBind(&payload)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: err.Error()})
-		return
+	err := validatePayload(c.ShouldBind(&payload), &payload)
+	if err == nil {
+		err = d.validateImageURL(ctx, payload.Image, true)
 	}
-	err = payload.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: "invalid payload: " + err.Error()})
+		invalidPayload(c, err)
 		return
 	}
 	response, errResponse := d.cakeUsecase.CreateCake(ctx, model.CakePayloadQuery{
@@ -108,10 +354,190 @@ func (d *CakeDelivery) CreateCake(c *gin.Context) {
 		Image:       payload.Image,
 	})
 	if errResponse != nil {
-		c.JSON(errResponse.HttpStatusCode, model.JsonErrorResp{ErrorMessage: errResponse.Err.Error(), ErrData: errResponse.ErrData})
+		usecaseErrorResponse(c, errResponse)
 		return
 	}
-	c.JSON(http.StatusOK, response)
+	// created cake is located under the same version of create request
+	c.Header("Location", fmt.Sprintf("%s/%d", strings.TrimSuffix(c.Request.URL.Path, "/"), response.ID))
+	c.Header("ETag", cakeETag(c, response.Version))
+	c.JSON(http.StatusCreated, cakeBody(c, *response))
+	return
+}
+
+// BatchCakes godoc
+//
+//	@Summary		BatchCakes
+//	@Description	run create / update / delete operations on single transaction.
+//	@Description	atomic mode (default) roll back every operation when one of them failed,
+//	@Description	best_effort mode apply valid operations and report result of each operation
+//	@Tags			cakes
+//	@Param			data	body	model.ApiBatchCakesPayload	true	"body data".
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	model.BatchCakesResponse
+//	@Failure		400	{object}	model.ProblemDetails
+//	@Failure		401	{object}	model.ProblemDetails
+//	@Failure		422	{object}	model.ProblemDetails
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Router			/v1/cakes/batch [post]
+func (d *CakeDelivery) BatchCakes(c *gin.Context) {
+	ctx := c.Request.Context()
+	var payload model.ApiBatchCakesPayload
+	err := validatePayload(c.ShouldBindJSON(&payload), &payload)
+	if err != nil {
+		invalidPayload(c, err)
+		return
+	}
+	atomic := payload.Mode != model.BatchModeBestEffort
+	operations := make([]model.BatchCakeOperation, 0, len(payload.Operations))
+	invalidResults := make([]model.BatchCakeResult, 0)
+	for i := range payload.Operations {
+		item := payload.Operations[i]
+		err := validateBatchOperation(&item)
+		if err == nil && item.Data != nil && item.Op != model.BatchOpDelete {
+			err = d.validateImageURL(ctx, item.Data.Image, false)
+		}
+		if err != nil {
+			result := model.BatchCakeResult{
+				Index:        i,
+				Op:           item.Op,
+				ID:           item.ID,
+				Status:       http.StatusUnprocessableEntity,
+				ErrorCode:    model.ErrCodeValidationFailed,
+				ErrorMessage: "invalid payload: " + err.Error(),
+				ErrorParams:  map[string]interface{}{"detail": err.Error()},
+			}
+			errors.As(err, &result.Errors)
+			var fieldErr *model.FieldError
+			if errors.As(err, &fieldErr) {
+				result.Errors = model.ValidationErrors{*fieldErr}.Prefix("data")
+			}
+			invalidResults = append(invalidResults, result)
+			continue
+		}
+		operation := model.BatchCakeOperation{
+			Index: i,
+			Op:    item.Op,
+			ID:    item.ID,
+		}
+		if item.Version != nil {
+			operation.Version = *item.Version
+		}
+		if item.Data != nil {
+			operation.Payload = model.CakePayloadQuery{
+				Title:       item.Data.Title,
+				Description: item.Data.Description,
+				Rating:      item.Data.Rating,
+				Image:       item.Data.Image,
+			}
+		}
+		operations = append(operations, operation)
+	}
+	if atomic && len(invalidResults) > 0 {
+		errorResponse(c, http.StatusUnprocessableEntity, model.ErrCodeBatchInvalid, nil, localizeBatchResults(language(c), invalidResults))
+		return
+	}
+	response := &model.BatchCakesResponse{
+		Results: make([]model.BatchCakeResult, 0),
+	}
+	if len(operations) > 0 {
+		var errResponse *model.ErrorResponse
+		response, errResponse = d.cakeUsecase.BatchCakes(ctx, model.BatchCakesUsecaseParam{
+			Atomic:     atomic,
+			Operations: operations,
+		})
+		if errResponse != nil {
+			usecaseErrorResponse(c, errResponse)
+			return
+		}
+	}
+	if len(invalidResults) > 0 {
+		response.Failed += len(invalidResults)
+		response.Results = append(response.Results, invalidResults...)
+		sort.Slice(response.Results, func(i, j int) bool {
+			return response.Results[i].Index < response.Results[j].Index
+		})
+	}
+	response.Results = localizeBatchResults(language(c), response.Results)
+	for i := range response.Results {
+		response.Results[i].Status = batchResultStatus(response.Results[i])
+	}
+	c.JSON(http.StatusOK, batchBody(c, *response))
+	return
+}
+
+// validateBatchOperation: collect binding rule failure of data and operation validation
+func validateBatchOperation(item *model.ApiBatchCakeOperation) error {
+	var errs model.ValidationErrors
+	if item.Data != nil && item.Op != model.BatchOpDelete {
+		var validationErrs validator.ValidationErrors
+		if errors.As(binding.Validator.ValidateStruct(item.Data), &validationErrs) {
+			errs = bindingFieldErrors(validationErrs, item.Data, "json").Prefix("data")
+		}
+	}
+	var itemErrs model.ValidationErrors
+	if errors.As(item.Validate(), &itemErrs) {
+		errs = mergeFieldErrors(errs, itemErrs)
+	}
+	return errs.Err()
+}
+
+// ImportCakes godoc
+//
+//	@Summary		ImportCakes
+//	@Description	import cakes from CSV (header: title, description, rating, image) or NDJSON body.
+//	@Description	every row is validated like CreateCake, valid rows are inserted and rejected rows are listed on report
+//	@Tags			cakes
+//	@Param			format	query	string	false	"csv or ndjson, default is taken from Content-Type"	Enums(csv, ndjson)
+//	@Param			dry_run	query	boolean	false	"only validate rows without inserting it"
+//	@Param			data	body	string	true	"CSV or NDJSON content"
+//	@Accept			text/csv
+//	@Accept			application/x-ndjson
+//	@Produce		json
+//	@Success		200	{object}	model.ImportCakesResponse
+//	@Failure		400	{object}	model.ProblemDetails
+//	@Failure		401	{object}	model.ProblemDetails
+//	@Failure		415	{object}	model.ProblemDetails
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Router			/v1/cakes/import [post]
+func (d *CakeDelivery) ImportCakes(c *gin.Context) {
+	ctx := c.Request.Context()
+	var query model.ApiImportCakesQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		invalidQuery(c, err, &query)
+		return
+	}
+	format := query.Format
+	if format == "" {
+		format = importContentTypes[c.ContentType()]
+	}
+	if format == "" {
+		errorResponse(c, http.StatusUnsupportedMediaType, model.ErrCodeUnsupportedImportType, nil, nil)
+		return
+	}
+	decoder, err := newCakeImportDecoder(format, c.Request.Body)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidImportFile, map[string]interface{}{"detail": err.Error()}, nil)
+		return
+	}
+	response, errResponse := d.cakeUsecase.ImportCakes(ctx, model.ImportCakesUsecaseParam{
+		Next: func() (model.ImportCakeRow, error) {
+			row, err := decoder.Next()
+			if err == nil && row.Err == nil {
+				row.Err = d.validateImageURL(ctx, row.Payload.Image, false)
+			}
+			return row, err
+		},
+		DryRun: query.DryRun,
+	})
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	c.JSON(http.StatusOK, importBody(c, localizeImportResponse(language(c), *response)))
 	return
 }
 
@@ -119,24 +545,34 @@ func (d *CakeDelivery) CreateCake(c *gin.Context) {
 //
 //	@Summary	DeleteCake
 //	@Tags		cakes
-//	@Param		id	path	string	true	"param id (cake record)"
+//	@Param		id			path	string	true	"param id (cake record)"
+//	@Param		If-Match	header	string	true	"ETag of cake from GetCake, use * to skip version check"
 //	@Produce	json
 //	@Success	200	{object}	model.CakeDeleteResponse
-//	@Router		/cakes/{id} [delete]
+//	@Failure	401	{object}	model.ProblemDetails
+//	@Failure	412	{object}	model.ProblemDetails
+//	@Failure	428	{object}	model.ProblemDetails
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Router		/v1/cakes/{id} [delete]
 func (d *CakeDelivery) DeleteCake(c *gin.Context) {
 	ctx := c.Request.Context()
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: "invalid parameter id"})
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidParameterID, nil, nil)
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
-	response, errResponse := d.cakeUsecase.DeleteCake(ctx, id)
+	response, errResponse := d.cakeUsecase.DeleteCake(ctx, id, version)
 	if errResponse != nil {
-		c.JSON(errResponse.HttpStatusCode, model.JsonErrorResp{ErrorMessage: errResponse.Err.Error(), ErrData: errResponse.ErrData})
+		usecaseErrorResponse(c, errResponse)
 		return
 	}
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, cakeDeleteBody(c, *response))
 	return
 }
 
@@ -144,40 +580,208 @@ func (d *CakeDelivery) DeleteCake(c *gin.Context) {
 //
 //	@Summary	UpdateCake
 //	@Tags		cakes
-//	@Param		id		path	string							true	"param id (cake record)"
-//	@Param		data	body	model.ApiMutationCakePayload	true	"body data".
+//	@Param		id			path	string							true	"param id (cake record)"
+//	@Param		If-Match	header	string							true	"ETag of cake from GetCake, use * to skip version check"
+//	@Param		data		body	model.ApiMutationCakePayload	true	"body data".
 //	@Produce	json
-//	@Success	200	{object}	model.CakeMutationResponse
-//	@Router		/cakes/{id} [put]
+//	@Success	200	{object}	model.CakeResponse
+//	@Header		200	{string}	ETag	"new version of cake"
+//	@Failure	401	{object}	model.ProblemDetails
+//	@Failure	412	{object}	model.ProblemDetails
+//	@Failure	422	{object}	model.ProblemDetails{errors=model.ValidationErrors}
+//	@Failure	428	{object}	model.ProblemDetails
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Router		/v1/cakes/{id} [put]
 func (d *CakeDelivery) UpdateCake(c *gin.Context) {
 	ctx := c.Request.Context()
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: "invalid parameter id"})
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidParameterID, nil, nil)
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 	var payload model.ApiMutationCakePayload
-	err = c.ShouldBind(&payload)
+	err = validatePayload(c.ShouldBind(&payload), &payload)
+	if err == nil {
+		err = d.validateImageURL(ctx, payload.Image, true)
+	}
+	if err != nil {
+		invalidPayload(c, err)
+		return
+	}
+	response, errResponse := d.cakeUsecase.UpdateCake(ctx, id, version, model.CakePayloadQuery{
+		Title:       payload.Title,
+		Description: payload.Description,
+		Rating:      payload.Rating,
+		Image:       payload.Image,
+	})
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	c.Header("ETag", cakeETag(c, response.Version))
+	c.JSON(http.StatusOK, cakeBody(c, *response))
+	return
+}
+
+// PatchCake godoc
+//
+//	@Summary		PatchCake
+//	@Description	partial update using JSON Merge Patch (RFC 7396), missing field is left unchanged and null clear description / image
+//	@Tags			cakes
+//	@Param			id			path	string						true	"param id (cake record)"
+//	@Param			If-Match	header	string						true	"ETag of cake from GetCake, use * to skip version check"
+//	@Param			data		body	model.ApiPatchCakePayload	true	"body data".
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	model.CakeResponse
+//	@Header			200	{string}	ETag	"new version of cake"
+//	@Failure		400	{object}	model.ProblemDetails
+//	@Failure		401	{object}	model.ProblemDetails
+//	@Failure		412	{object}	model.ProblemDetails
+//	@Failure		415	{object}	model.ProblemDetails
+//	@Failure		422	{object}	model.ProblemDetails{errors=model.ValidationErrors}
+//	@Failure		428	{object}	model.ProblemDetails
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Router			/v1/cakes/{id} [patch]
+func (d *CakeDelivery) PatchCake(c *gin.Context) {
+	ctx := c.Request.Context()
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: err.Error()})
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidParameterID, nil, nil)
 		return
 	}
-	err = payload.Validate()
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	contentType := c.ContentType()
+	if contentType != "application/merge-patch+json" && contentType != binding.MIMEJSON {
+		errorResponse(c, http.StatusUnsupportedMediaType, model.ErrCodeUnsupportedPatchType, nil, nil)
+		return
+	}
+	var payload model.ApiPatchCakePayload
+	err = validatePayload(c.ShouldBindJSON(&payload), &payload)
+	if err == nil {
+		err = d.validateImageURL(ctx, payload.Image.Ptr(), true)
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.JsonErrorResp{ErrorMessage: "invalid payload: " + err.Error()})
+		invalidPayload(c, err)
 		return
 	}
-	response, errResponse := d.cakeUsecase.UpdateCake(ctx, id, model.CakePayloadQuery{
+	response, errResponse := d.cakeUsecase.PatchCake(ctx, id, version, model.CakePatchQuery{
 		Title:       payload.Title,
 		Description: payload.Description,
 		Rating:      payload.Rating,
 		Image:       payload.Image,
 	})
 	if errResponse != nil {
-		c.JSON(errResponse.HttpStatusCode, model.JsonErrorResp{ErrorMessage: errResponse.Err.Error(), ErrData: errResponse.ErrData})
+		usecaseErrorResponse(c, errResponse)
 		return
 	}
-	c.JSON(http.StatusOK, response)
+	c.Header("ETag", cakeETag(c, response.Version))
+	c.JSON(http.StatusOK, cakeBody(c, *response))
 	return
 }
+
+// UploadCakeImage godoc
+//
+//	@Summary		UploadCakeImage
+//	@Description	upload cake image (jpeg, png, gif or webp), the image url is saved as cake image
+//	@Tags			cakes
+//	@Param			id			path		string	true	"param id (cake record)"
+//	@Param			If-Match	header		string	true	"ETag of cake from GetCake, use * to skip version check"
+//	@Param			image		formData	file	true	"image file"
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Success		200	{object}	model.CakeResponse
+//	@Header			200	{string}	ETag	"new version of cake"
+//	@Failure		400	{object}	model.ProblemDetails
+//	@Failure		401	{object}	model.ProblemDetails
+//	@Failure		412	{object}	model.ProblemDetails
+//	@Failure		413	{object}	model.ProblemDetails
+//	@Failure		415	{object}	model.ProblemDetails
+//	@Failure		428	{object}	model.ProblemDetails
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Router			/v1/cakes/{id}/image [post]
+func (d *CakeDelivery) UploadCakeImage(c *gin.Context) {
+	ctx := c.Request.Context()
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidParameterID, nil, nil)
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	tooLarge := map[string]interface{}{"max_size": d.imageMaxSize}
+	// allow some overhead of multipart encoding on top of image size
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, d.imageMaxSize+64*1024)
+	file, header, err := c.Request.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			errorResponse(c, http.StatusRequestEntityTooLarge, model.ErrCodeImageTooLarge, tooLarge, nil)
+			return
+		}
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeImageRequired, nil, nil)
+		return
+	}
+	defer file.Close()
+	if header.Size > d.imageMaxSize {
+		errorResponse(c, http.StatusRequestEntityTooLarge, model.ErrCodeImageTooLarge, tooLarge, nil)
+		return
+	}
+	content, err := io.ReadAll(io.LimitReader(file, d.imageMaxSize+1))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeImageUploadFailed, map[string]interface{}{"detail": err.Error()}, nil)
+		return
+	}
+	if int64(len(content)) > d.imageMaxSize {
+		errorResponse(c, http.StatusRequestEntityTooLarge, model.ErrCodeImageTooLarge, tooLarge, nil)
+		return
+	}
+	// content type is detected from content since client header can not be trusted
+	contentType := http.DetectContentType(content)
+	if !allowedImageTypes[contentType] {
+		errorResponse(c, http.StatusUnsupportedMediaType, model.ErrCodeUnsupportedImageType, nil, nil)
+		return
+	}
+	response, errResponse := d.cakeUsecase.UploadCakeImage(ctx, id, version, model.UploadCakeImageParam{
+		Content:     content,
+		ContentType: contentType,
+	})
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	c.Header("ETag", cakeETag(c, response.Version))
+	c.JSON(http.StatusOK, cakeBody(c, *response))
+	return
+}
+
+// ifMatchVersion: get expected version of optimistic locking from If-Match header,
+// will write error response and return false if header is missing or invalid
+func ifMatchVersion(c *gin.Context) (int, bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		errorResponse(c, http.StatusPreconditionRequired, model.ErrCodeIfMatchRequired, nil, nil)
+		return 0, false
+	}
+	version, err := util.ParseIfMatch(header)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidIfMatch, map[string]interface{}{"detail": err.Error()}, nil)
+		return 0, false
+	}
+	return version, true
+}
@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/forderation/ralali-test/internal/model"
 	"github.com/forderation/ralali-test/internal/usecase"
@@ -33,13 +37,15 @@ func TestNewCakeDelivery(t *testing.T) {
 				cakeUsecase: &usecase.CakeUsecaseInterfaceMock{},
 			},
 			want: &CakeDelivery{
-				cakeUsecase: &usecase.CakeUsecaseInterfaceMock{},
+				cakeUsecase:       &usecase.CakeUsecaseInterfaceMock{},
+				imageMaxSize:      1024,
+				imageURLValidator: &ImageURLValidator{},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewCakeDelivery(tt.args.cakeUsecase); !reflect.DeepEqual(got, tt.want) {
+			if got := NewCakeDelivery(tt.args.cakeUsecase, 1024, &ImageURLValidator{}); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewCakeDelivery() = %v, want %v", got, tt.want)
 			}
 		})
@@ -64,6 +70,9 @@ func TestCakeDelivery_GetCakes(t *testing.T) {
 	mockCakeUsecase.GetCakesFunc = func(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
 		return &mockResponse, nil
 	}
+	mockCakeUsecase.GetCakesModifiedFunc = func(ctx context.Context, filter model.CakesFilterQuery) (*model.CakesModified, *model.ErrorResponse) {
+		return &model.CakesModified{}, nil
+	}
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = &http.Request{
@@ -152,23 +161,19 @@ func TestCakeDelivery_CreateCake(t *testing.T) {
 		c *gin.Context
 	}
 	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
-	mockCakeUsecase.CreateCakeFunc = func(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse) {
-		return &model.CakeMutationResponse{}, nil
+	mockCakeUsecase.CreateCakeFunc = func(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{ID: 1}, nil
 	}
 	payload := model.ApiMutationCakePayload{
 		Title:       "title",
 		Description: null.StringFrom("description").Ptr(),
 		Rating:      4.30,
-		Image:       null.StringFrom("image").Ptr(),
+		Image:       null.StringFrom("https://cdn.example.com/cake.png").Ptr(),
 	}
 	byteJson, _ := json.Marshal(payload)
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
-	ctx.Request = &http.Request{
-		Header: make(http.Header),
-		Method: http.MethodPost,
-		Body:   ioutil.NopCloser(bytes.NewBuffer(byteJson)),
-	}
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/cakes", bytes.NewBuffer(byteJson))
 	ctx.Request.Header.Set("Content-Type", "application/json")
 	tests := []struct {
 		name   string
@@ -191,7 +196,51 @@ func TestCakeDelivery_CreateCake(t *testing.T) {
 				cakeUsecase: tt.fields.cakeUsecase,
 			}
 			d.CreateCake(tt.args.c)
-			assert.EqualValues(t, http.StatusOK, w.Code)
+			assert.EqualValues(t, http.StatusCreated, w.Code)
+			assert.EqualValues(t, "/v1/cakes/1", w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestCakeDelivery_CreateCake_invalidImage(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	tests := []struct {
+		name     string
+		image    string
+		wantCode string
+	}{
+		{
+			name:     "not an url",
+			image:    "lol",
+			wantCode: "invalid_url",
+		},
+		{
+			name:     "host not allowed",
+			image:    "https://evil.example.org/cake.png",
+			wantCode: "host_not_allowed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/cakes", strings.NewReader(`{"title":"title","rating":4,"image":"`+tt.image+`"}`))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			d := &CakeDelivery{
+				cakeUsecase:       mockCakeUsecase,
+				imageURLValidator: NewImageURLValidator([]string{"*.example.com"}, false, time.Second, ""),
+			}
+			d.CreateCake(ctx)
+			assert.EqualValues(t, http.StatusUnprocessableEntity, w.Code)
+			var response struct {
+				Errors []model.FieldError `json:"errors"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			if assert.Len(t, response.Errors, 1) {
+				assert.EqualValues(t, "image", response.Errors[0].Field)
+				assert.EqualValues(t, tt.wantCode, response.Errors[0].Code)
+			}
+			assert.Len(t, mockCakeUsecase.CreateCakeCalls(), 0)
 		})
 	}
 }
@@ -204,15 +253,16 @@ func TestCakeDelivery_DeleteCake(t *testing.T) {
 		c *gin.Context
 	}
 	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
-	mockCakeUsecase.DeleteCakeFunc = func(ctx context.Context, id int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
+	mockCakeUsecase.DeleteCakeFunc = func(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
 		return &model.CakeDeleteResponse{}, nil
 	}
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = &http.Request{
 		Header: make(http.Header),
-		Method: http.MethodPost,
+		Method: http.MethodDelete,
 	}
+	ctx.Request.Header.Set("If-Match", `"1"`)
 	ctx.AddParam("id", "1")
 	tests := []struct {
 		name   string
@@ -235,6 +285,7 @@ func TestCakeDelivery_DeleteCake(t *testing.T) {
 				cakeUsecase: tt.fields.cakeUsecase,
 			}
 			d.DeleteCake(tt.args.c)
+			assert.EqualValues(t, http.StatusOK, w.Code)
 		})
 	}
 }
@@ -247,14 +298,14 @@ func TestCakeDelivery_UpdateCake(t *testing.T) {
 		c *gin.Context
 	}
 	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
-	mockCakeUsecase.UpdateCakeFunc = func(ctx context.Context, id int, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse) {
-		return &model.CakeMutationResponse{}, nil
+	mockCakeUsecase.UpdateCakeFunc = func(ctx context.Context, id int, version int, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{Version: version + 1}, nil
 	}
 	payload := model.ApiMutationCakePayload{
 		Title:       "title",
 		Description: null.StringFrom("description").Ptr(),
 		Rating:      4.30,
-		Image:       null.StringFrom("image").Ptr(),
+		Image:       null.StringFrom("https://cdn.example.com/cake.png").Ptr(),
 	}
 	byteJson, _ := json.Marshal(payload)
 	w := httptest.NewRecorder()
@@ -266,6 +317,7 @@ func TestCakeDelivery_UpdateCake(t *testing.T) {
 	}
 	ctx.AddParam("id", "1")
 	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Request.Header.Set("If-Match", `"1"`)
 	tests := []struct {
 		name   string
 		fields fields
@@ -288,6 +340,631 @@ func TestCakeDelivery_UpdateCake(t *testing.T) {
 			}
 			d.UpdateCake(tt.args.c)
 			assert.EqualValues(t, http.StatusOK, w.Code)
+			assert.EqualValues(t, `"2"`, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestCakeDelivery_PatchCake(t *testing.T) {
+	type fields struct {
+		cakeUsecase usecase.CakeUsecaseInterface
+	}
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.PatchCakeFunc = func(ctx context.Context, id int, version int, payload model.CakePatchQuery) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{}, nil
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		ifMatch     string
+		contentType string
+		body        string
+		wantCode    int
+	}{
+		{
+			name: "basic test, make sure not error / panic",
+			fields: fields{
+				cakeUsecase: mockCakeUsecase,
+			},
+			ifMatch:     `"1"`,
+			contentType: "application/merge-patch+json",
+			body:        `{"description": null, "rating": 4}`,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name: "missing if match header",
+			fields: fields{
+				cakeUsecase: mockCakeUsecase,
+			},
+			contentType: "application/merge-patch+json",
+			body:        `{"rating": 4}`,
+			wantCode:    http.StatusPreconditionRequired,
+		},
+		{
+			name: "weak if match header",
+			fields: fields{
+				cakeUsecase: mockCakeUsecase,
+			},
+			ifMatch:     `W/"1"`,
+			contentType: "application/merge-patch+json",
+			body:        `{"rating": 4}`,
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name: "unsupported content type",
+			fields: fields{
+				cakeUsecase: mockCakeUsecase,
+			},
+			ifMatch:     `"1"`,
+			contentType: "text/plain",
+			body:        `{"rating": 4}`,
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "invalid payload",
+			fields: fields{
+				cakeUsecase: mockCakeUsecase,
+			},
+			ifMatch:     `"1"`,
+			contentType: "application/merge-patch+json",
+			body:        `{"title": null}`,
+			wantCode:    http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: http.MethodPatch,
+				Body:   ioutil.NopCloser(bytes.NewBufferString(tt.body)),
+			}
+			ctx.AddParam("id", "1")
+			ctx.Request.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+			d := &CakeDelivery{
+				cakeUsecase: tt.fields.cakeUsecase,
+			}
+			d.PatchCake(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestCakeDelivery_GetCake_Conditional(t *testing.T) {
+	modifiedAt := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{ID: id, Version: 2, UpdatedAt: modifiedAt.Format(time.RFC3339)}, nil
+	}
+	tests := []struct {
+		name     string
+		header   map[string]string
+		wantCode int
+	}{
+		{
+			name:     "without condition",
+			header:   map[string]string{},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "entity tag match",
+			header:   map[string]string{"If-None-Match": `"2"`},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:     "entity tag not match take precedence over date",
+			header:   map[string]string{"If-None-Match": `"1"`, "If-Modified-Since": modifiedAt.Format(http.TimeFormat)},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "not modified since",
+			header:   map[string]string{"If-Modified-Since": modifiedAt.Format(http.TimeFormat)},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:     "modified since",
+			header:   map[string]string{"If-Modified-Since": modifiedAt.Add(-time.Hour).Format(http.TimeFormat)},
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: http.MethodGet,
+			}
+			for k, v := range tt.header {
+				ctx.Request.Header.Set(k, v)
+			}
+			ctx.AddParam("id", "1")
+			d := &CakeDelivery{
+				cakeUsecase: mockCakeUsecase,
+			}
+			d.GetCake(ctx)
+			ctx.Writer.WriteHeaderNow()
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			assert.EqualValues(t, `"2"`, w.Header().Get("ETag"))
+			assert.EqualValues(t, modifiedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+		})
+	}
+}
+
+func TestCakeDelivery_GetCakes_Conditional(t *testing.T) {
+	modifiedAt := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetCakesModifiedFunc = func(ctx context.Context, filter model.CakesFilterQuery) (*model.CakesModified, *model.ErrorResponse) {
+		return &model.CakesModified{Count: 1, VersionSum: 2, ModifiedAt: modifiedAt}, nil
+	}
+	mockCakeUsecase.GetCakesFunc = func(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+		return &model.GetCakesResponse{Data: []model.CakeResponse{{ID: 1}}}, nil
+	}
+	etag := `W/"1-2-1672671845"`
+	tests := []struct {
+		name     string
+		header   map[string]string
+		wantCode int
+	}{
+		{
+			name:     "without condition",
+			header:   map[string]string{},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "entity tag match",
+			header:   map[string]string{"If-None-Match": etag},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:     "entity tag not match take precedence over date",
+			header:   map[string]string{"If-None-Match": `W/"1-1-1672671845"`, "If-Modified-Since": modifiedAt.Format(http.TimeFormat)},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "not modified since",
+			header:   map[string]string{"If-Modified-Since": modifiedAt.Format(http.TimeFormat)},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name:     "modified since",
+			header:   map[string]string{"If-Modified-Since": modifiedAt.Add(-time.Hour).Format(http.TimeFormat)},
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := len(mockCakeUsecase.GetCakesCalls())
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/cakes?page_size=10", nil)
+			for k, v := range tt.header {
+				ctx.Request.Header.Set(k, v)
+			}
+			d := &CakeDelivery{
+				cakeUsecase: mockCakeUsecase,
+			}
+			d.GetCakes(ctx)
+			ctx.Writer.WriteHeaderNow()
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			assert.EqualValues(t, etag, w.Header().Get("ETag"))
+			assert.EqualValues(t, modifiedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+			if tt.wantCode == http.StatusNotModified {
+				// listing is not queried when it is not modified
+				assert.EqualValues(t, calls, len(mockCakeUsecase.GetCakesCalls()))
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCakeDelivery_GetDeletedCakes(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDeletedCakesFunc = func(ctx context.Context, param model.GetDeletedCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+		return &model.GetCakesResponse{}, nil
+	}
+	tests := []struct {
+		name     string
+		url      string
+		wantCode int
+	}{
+		{
+			name:     "basic test",
+			url:      "/cakes/trash?page=1&page_size=10",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "page size is required",
+			url:      "/cakes/trash",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "page size over maximum",
+			url:      "/cakes/trash?page_size=101",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, tt.url, nil)
+			d := &CakeDelivery{
+				cakeUsecase: mockCakeUsecase,
+			}
+			d.GetDeletedCakes(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestCakeDelivery_RestoreCake(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.RestoreCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		if id == 1 {
+			return &model.CakeResponse{ID: id, Version: 3}, nil
+		}
+		return nil, &model.ErrorResponse{
+			Err:  errors.New("error mock"),
+			Kind: model.ErrConflict,
+		}
+	}
+	tests := []struct {
+		name     string
+		id       string
+		wantCode int
+	}{
+		{
+			name:     "basic test",
+			id:       "1",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "cake is not deleted",
+			id:       "2",
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "invalid id",
+			id:       "abc",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: http.MethodPost,
+			}
+			ctx.AddParam("id", tt.id)
+			d := &CakeDelivery{
+				cakeUsecase: mockCakeUsecase,
+			}
+			d.RestoreCake(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestCakeDelivery_BatchCakes(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.BatchCakesFunc = func(ctx context.Context, param model.BatchCakesUsecaseParam) (*model.BatchCakesResponse, *model.ErrorResponse) {
+		response := &model.BatchCakesResponse{}
+		for _, operation := range param.Operations {
+			response.Succeeded++
+			response.Results = append(response.Results, model.BatchCakeResult{Index: operation.Index, Op: operation.Op, Status: http.StatusOK})
+		}
+		return response, nil
+	}
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    int
+		wantIndexes []int
+	}{
+		{
+			name:        "basic test",
+			body:        `{"operations":[{"op":"create","data":{"title":"cake","rating":4}},{"op":"delete","id":1,"version":2}]}`,
+			wantCode:    http.StatusOK,
+			wantIndexes: []int{0, 1},
+		},
+		{
+			name:     "atomic batch with invalid operation",
+			body:     `{"operations":[{"op":"create","data":{"title":"cake","rating":4}},{"op":"update","id":1,"data":{"title":"cake","rating":4}}]}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "best effort batch with invalid operation",
+			body:        `{"mode":"best_effort","operations":[{"op":"create","data":{"title":" ","rating":4}},{"op":"delete","id":1,"version":0},{"op":"drop"}]}`,
+			wantCode:    http.StatusOK,
+			wantIndexes: []int{0, 1, 2},
+		},
+		{
+			name:     "empty operations",
+			body:     `{"operations":[]}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "unknown mode",
+			body:     `{"mode":"partial","operations":[{"op":"delete","id":1,"version":2}]}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "malformed json",
+			body:     `{"operations":[`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/cakes/batch", bytes.NewBufferString(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			d := &CakeDelivery{
+				cakeUsecase: mockCakeUsecase,
+			}
+			d.BatchCakes(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var response model.BatchCakesResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			indexes := make([]int, 0)
+			for _, result := range response.Results {
+				indexes = append(indexes, result.Index)
+			}
+			assert.EqualValues(t, tt.wantIndexes, indexes)
+		})
+	}
+}
+
+func TestCakeDelivery_ImportCakes(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.ImportCakesFunc = func(ctx context.Context, param model.ImportCakesUsecaseParam) (*model.ImportCakesResponse, *model.ErrorResponse) {
+		response := &model.ImportCakesResponse{DryRun: param.DryRun}
+		for {
+			row, err := param.Next()
+			if err != nil {
+				return response, nil
+			}
+			response.TotalRows++
+			if row.Err != nil {
+				response.RejectedRows++
+			}
+		}
+	}
+	tests := []struct {
+		name         string
+		url          string
+		contentType  string
+		body         string
+		wantCode     int
+		wantTotal    int
+		wantRejected int
+	}{
+		{
+			name:         "csv body",
+			url:          "/cakes/import",
+			contentType:  "text/csv",
+			body:         "title,rating\ncake,4\n,4\n",
+			wantCode:     http.StatusOK,
+			wantTotal:    2,
+			wantRejected: 1,
+		},
+		{
+			name:        "ndjson body with format query",
+			url:         "/cakes/import?format=ndjson&dry_run=true",
+			contentType: "application/octet-stream",
+			body:        `{"title":"cake","rating":4}`,
+			wantCode:    http.StatusOK,
+			wantTotal:   1,
+		},
+		{
+			name:        "unsupported content type",
+			url:         "/cakes/import",
+			contentType: "application/json",
+			body:        `[]`,
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "invalid csv header",
+			url:         "/cakes/import",
+			contentType: "text/csv",
+			body:        "name\ncake\n",
+			wantCode:    http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, tt.url, bytes.NewBufferString(tt.body))
+			ctx.Request.Header.Set("Content-Type", tt.contentType)
+			d := &CakeDelivery{
+				cakeUsecase: mockCakeUsecase,
+			}
+			d.ImportCakes(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var response model.ImportCakesResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.wantTotal, response.TotalRows)
+			assert.EqualValues(t, tt.wantRejected, response.RejectedRows)
+		})
+	}
+}
+
+func TestCakeDelivery_ExportCakes(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.ExportCakesFunc = func(ctx context.Context, param model.ExportCakesUsecaseParam, fn func(cake model.CakeResponse) error) *model.ErrorResponse {
+		if param.Sort == "unknown" {
+			return &model.ErrorResponse{
+				Err:  errors.New("error mock"),
+				Kind: model.ErrValidation,
+			}
+		}
+		if param.Filter.Keyword == "none" {
+			return nil
+		}
+		for _, cake := range exportCakesMock {
+			err := fn(cake)
+			if err != nil {
+				return &model.ErrorResponse{
+					Err:  err,
+					Kind: model.ErrInternal,
+				}
+			}
+		}
+		return nil
+	}
+	tests := []struct {
+		name            string
+		url             string
+		wantCode        int
+		wantContentType string
+		wantLines       int
+	}{
+		{
+			name:            "csv export",
+			url:             "/cakes/export?format=csv",
+			wantCode:        http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantLines:       3,
+		},
+		{
+			name:            "empty export still has header",
+			url:             "/cakes/export?format=csv&q=none",
+			wantCode:        http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantLines:       1,
+		},
+		{
+			name:            "ndjson export",
+			url:             "/cakes/export?format=ndjson",
+			wantCode:        http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantLines:       2,
+		},
+		{
+			name:     "unknown format",
+			url:      "/cakes/export?format=pdf",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:            "error before streaming started",
+			url:             "/cakes/export?format=csv&sort=unknown",
+			wantCode:        http.StatusBadRequest,
+			wantContentType: "application/problem+json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, tt.url, nil)
+			d := &CakeDelivery{
+				cakeUsecase: mockCakeUsecase,
+			}
+			d.ExportCakes(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			if tt.wantContentType != "" {
+				assert.EqualValues(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			}
+			if tt.wantCode == http.StatusOK {
+				assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+				assert.EqualValues(t, tt.wantLines, strings.Count(w.Body.String(), "\n"))
+			}
+		})
+	}
+}
+
+func TestCakeDelivery_UploadCakeImage(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.UploadCakeImageFunc = func(ctx context.Context, id int, version int, param model.UploadCakeImageParam) (*model.CakeResponse, *model.ErrorResponse) {
+		if param.ContentType != "image/png" {
+			t.Errorf("UploadCakeImage() content type = %v, want image/png", param.ContentType)
+		}
+		return &model.CakeResponse{ID: id, Version: version + 1}, nil
+	}
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 100)...)
+	tests := []struct {
+		name      string
+		ifMatch   string
+		fieldName string
+		content   []byte
+		wantCode  int
+	}{
+		{
+			name:      "basic test",
+			ifMatch:   `"1"`,
+			fieldName: "image",
+			content:   png,
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "missing If-Match",
+			fieldName: "image",
+			content:   png,
+			wantCode:  http.StatusPreconditionRequired,
+		},
+		{
+			name:      "missing image field",
+			ifMatch:   `"1"`,
+			fieldName: "file",
+			content:   png,
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "not an image",
+			ifMatch:   `"1"`,
+			fieldName: "image",
+			content:   []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"),
+			wantCode:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:      "image too large",
+			ifMatch:   `"1"`,
+			fieldName: "image",
+			content:   append(png, make([]byte, 1024)...),
+			wantCode:  http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile(tt.fieldName, "cake.png")
+			part.Write(tt.content)
+			writer.Close()
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/cakes/1/image", body)
+			ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+			if tt.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+			ctx.AddParam("id", "1")
+			d := &CakeDelivery{
+				cakeUsecase:  mockCakeUsecase,
+				imageMaxSize: 1024,
+			}
+			d.UploadCakeImage(ctx)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				assert.EqualValues(t, `"2"`, w.Header().Get("ETag"))
+			}
 		})
 	}
 }
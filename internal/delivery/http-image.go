@@ -0,0 +1,79 @@
+package delivery
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/forderation/ralali-test/util"
+	"github.com/gin-gonic/gin"
+)
+
+type ImageDelivery struct {
+	cakeUsecase usecase.CakeUsecaseInterface
+	// cacheControl: Cache-Control header of served image, image key never change content so it can be cached long
+	cacheControl string
+}
+
+func NewImageDelivery(cakeUsecase usecase.CakeUsecaseInterface, cacheControl string) *ImageDelivery {
+	return &ImageDelivery{
+		cakeUsecase:  cakeUsecase,
+		cacheControl: cacheControl,
+	}
+}
+
+// GetImage godoc
+//
+//	@Summary		GetImage
+//	@Tags			images
+//	@Description	missing resized variant e.g. cakes/1/0f1e.png_512.jpg falls back to its original image with Cache-Control no-cache
+//	@Param			key				path	string	true	"image key e.g. cakes/1/0f1e2d3c4b5a69788796a5b4c3d2e1f0.png"
+//	@Param			If-None-Match	header	string	false	"ETag from previous response"
+//	@Produce		image/jpeg
+//	@Produce		image/png
+//	@Produce		image/gif
+//	@Produce		image/webp
+//	@Success		200	{file}		file
+//	@Header			200	{string}	ETag	"content hash of image"
+//	@Success		304
+//	@Failure		404	{object}	model.ProblemDetails
+//	@Router			/images/{key} [get]
+func (d *ImageDelivery) GetImage(c *gin.Context) {
+	ctx := c.Request.Context()
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	etag := imageETag(key)
+	if util.MatchIfNoneMatch(c.GetHeader("If-None-Match"), etag) {
+		d.setCacheHeader(c, etag)
+		c.Status(http.StatusNotModified)
+		return
+	}
+	image, errResponse := d.cakeUsecase.GetImage(ctx, key)
+	if errResponse != nil {
+		usecaseErrorResponse(c, errResponse)
+		return
+	}
+	defer image.Content.Close()
+	if image.Key != key {
+		// variant is not generated yet, serve original image without long caching so variant is picked up later
+		c.Header("ETag", imageETag(image.Key))
+		c.Header("Cache-Control", "no-cache")
+	} else {
+		d.setCacheHeader(c, etag)
+	}
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, image.Size, image.ContentType, image.Content, nil)
+	return
+}
+
+// imageETag: image key is named by content hash, so it is the entity tag
+func imageETag(key string) string {
+	return `"` + strings.TrimSuffix(path.Base(key), path.Ext(key)) + `"`
+}
+
+func (d *ImageDelivery) setCacheHeader(c *gin.Context, etag string) {
+	c.Header("ETag", etag)
+	if d.cacheControl != "" {
+		c.Header("Cache-Control", d.cacheControl)
+	}
+}
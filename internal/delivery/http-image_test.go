@@ -0,0 +1,93 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageDelivery_GetImage(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetImageFunc = func(ctx context.Context, key string) (*model.ImageObject, *model.ErrorResponse) {
+		if key == "cakes/1/0f1e2d3c.png_512.jpg" {
+			key = "cakes/1/0f1e2d3c.png"
+		}
+		if key != "cakes/1/0f1e2d3c.png" {
+			return nil, &model.ErrorResponse{
+				Err:  errors.New("image not found"),
+				Kind: model.ErrImageNotFound,
+			}
+		}
+		return &model.ImageObject{
+			Key:         key,
+			Content:     io.NopCloser(strings.NewReader("image")),
+			ContentType: "image/png",
+			Size:        5,
+		}, nil
+	}
+	tests := []struct {
+		name             string
+		key              string
+		ifNoneMatch      string
+		wantCode         int
+		wantCacheControl string
+		wantETag         string
+	}{
+		{
+			name:             "basic test",
+			key:              "/cakes/1/0f1e2d3c.png",
+			wantCode:         http.StatusOK,
+			wantCacheControl: "public, max-age=31536000, immutable",
+			wantETag:         `"0f1e2d3c"`,
+		},
+		{
+			name:             "missing variant falls back to original without long caching",
+			key:              "/cakes/1/0f1e2d3c.png_512.jpg",
+			wantCode:         http.StatusOK,
+			wantCacheControl: "no-cache",
+			wantETag:         `"0f1e2d3c"`,
+		},
+		{
+			name:             "not modified",
+			key:              "/cakes/1/0f1e2d3c.png",
+			ifNoneMatch:      `"0f1e2d3c"`,
+			wantCode:         http.StatusNotModified,
+			wantCacheControl: "public, max-age=31536000, immutable",
+		},
+		{
+			name:     "image not found is not cached",
+			key:      "/cakes/1/missing.png",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/images"+tt.key, nil)
+			if tt.ifNoneMatch != "" {
+				ctx.Request.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			ctx.AddParam("key", tt.key)
+			d := NewImageDelivery(mockCakeUsecase, "public, max-age=31536000, immutable")
+			d.GetImage(ctx)
+			ctx.Writer.WriteHeaderNow()
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			assert.EqualValues(t, tt.wantCacheControl, w.Header().Get("Cache-Control"))
+			if tt.wantCode == http.StatusOK {
+				assert.EqualValues(t, "image", w.Body.String())
+				assert.EqualValues(t, "image/png", w.Header().Get("Content-Type"))
+				assert.EqualValues(t, tt.wantETag, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
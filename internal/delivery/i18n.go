@@ -0,0 +1,133 @@
+package delivery
+
+import (
+	"errors"
+
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// languageKey: key of response language on gin context
+const languageKey = "language"
+
+// LanguageMiddleware: pick language of error message from Accept-Language header,
+// defaultLanguage is used when none of requested language is supported
+func LanguageMiddleware(defaultLanguage string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(languageKey, i18n.MatchLanguage(c.GetHeader("Accept-Language"), defaultLanguage))
+		c.Next()
+	}
+}
+
+// language: language picked by LanguageMiddleware, Accept-Language is matched directly when the middleware is not used
+func language(c *gin.Context) string {
+	if lang := c.GetString(languageKey); lang != "" {
+		return lang
+	}
+	return i18n.MatchLanguage(c.GetHeader("Accept-Language"), i18n.DefaultLanguage)
+}
+
+// localizeErrData: translate message of known error data, the given data is left unchanged
+func localizeErrData(lang string, errData interface{}) interface{} {
+	switch data := errData.(type) {
+	case model.ValidationErrors:
+		return localizeFieldErrors(lang, data)
+	case *model.BatchCakeResult:
+		result := localizeBatchResult(lang, *data)
+		return &result
+	case []model.BatchCakeResult:
+		return localizeBatchResults(lang, data)
+	case model.ImportCakesResponse:
+		return localizeImportResponse(lang, data)
+	}
+	return errData
+}
+
+func localizeFieldErrors(lang string, errs model.ValidationErrors) model.ValidationErrors {
+	localized := make(model.ValidationErrors, 0, len(errs))
+	for _, fieldErr := range errs {
+		fieldErr.Message = i18n.FieldMessage(lang, fieldErr.Field, fieldErr.Code, fieldErr.Params)
+		localized = append(localized, fieldErr)
+	}
+	return localized
+}
+
+func localizeBatchResults(lang string, results []model.BatchCakeResult) []model.BatchCakeResult {
+	localized := make([]model.BatchCakeResult, 0, len(results))
+	for _, result := range results {
+		localized = append(localized, localizeBatchResult(lang, result))
+	}
+	return localized
+}
+
+func localizeBatchResult(lang string, result model.BatchCakeResult) model.BatchCakeResult {
+	if result.ErrorCode == "" {
+		return result
+	}
+	params := result.ErrorParams
+	if len(result.Errors) > 0 {
+		result.Errors = localizeFieldErrors(lang, result.Errors)
+		params = withParam(params, "detail", result.Errors.Error())
+	}
+	result.ErrorMessage = i18n.Message(lang, result.ErrorCode, params)
+	return result
+}
+
+func localizeImportResponse(lang string, response model.ImportCakesResponse) model.ImportCakesResponse {
+	errs := make([]model.ImportCakeError, 0, len(response.Errors))
+	for _, rowErr := range response.Errors {
+		if rowErr.ErrorCode != "" {
+			params := rowErr.ErrorParams
+			if len(rowErr.Errors) > 0 {
+				rowErr.Errors = localizeFieldErrors(lang, rowErr.Errors)
+				params = withParam(params, "detail", rowErr.Errors.Error())
+			}
+			rowErr.ErrorMessage = i18n.Message(lang, rowErr.ErrorCode, params)
+		}
+		errs = append(errs, rowErr)
+	}
+	response.Errors = errs
+	return response
+}
+
+// localizeUsecaseError: code, translated message and invalid fields of usecase error for transport other than REST,
+// same as usecaseErrorResponse raw error detail is only logged and error without code keep its english message
+func localizeUsecaseError(lang string, errResponse *model.ErrorResponse) (string, string, model.ValidationErrors) {
+	if detail, ok := errResponse.ErrData.(model.ErrorDetailResponse); ok {
+		logrus.Errorf("%s: %s", errResponse.Err, detail.Detail)
+	}
+	if errResponse.Code == "" {
+		return model.ErrCodeInternal, errResponse.Err.Error(), nil
+	}
+	var fieldErrs model.ValidationErrors
+	if errs, ok := errResponse.ErrData.(model.ValidationErrors); ok {
+		fieldErrs = localizeFieldErrors(lang, errs)
+	}
+	return errResponse.Code, i18n.Message(lang, errResponse.Code, errResponse.Params), fieldErrs
+}
+
+// localizeInvalidArgument: translated message of code and invalid fields of request validation error,
+// same as invalidPayload error which is not field error is written as detail
+func localizeInvalidArgument(lang string, code string, err error) (string, model.ValidationErrors) {
+	var errs model.ValidationErrors
+	var fieldErr *model.FieldError
+	if errors.As(err, &fieldErr) {
+		errs = model.ValidationErrors{*fieldErr}
+	} else if !errors.As(err, &errs) {
+		return i18n.Message(lang, code, map[string]interface{}{"detail": err.Error()}), nil
+	}
+	errs = localizeFieldErrors(lang, errs)
+	return i18n.Message(lang, code, map[string]interface{}{"detail": errs.Error()}), errs
+}
+
+// withParam: copy of params with additional param, so params owned by caller is not modified
+func withParam(params map[string]interface{}, name string, value interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(params)+1)
+	for k, v := range params {
+		copied[k] = v
+	}
+	copied[name] = value
+	return copied
+}
@@ -0,0 +1,150 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/i18n"
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageMiddleware(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return nil, &model.ErrorResponse{
+			Err:    errors.New("cake data with id 9 not found"),
+			Kind:   model.ErrCakeNotFound,
+			Code:   model.ErrCodeCakeNotFound,
+			Params: map[string]interface{}{"id": id},
+		}
+	}
+	d := NewCakeDelivery(mockCakeUsecase, 1024, nil)
+	tests := []struct {
+		name            string
+		defaultLanguage string
+		acceptLanguage  string
+		wantLanguage    string
+		wantMessage     string
+	}{
+		{
+			name:            "indonesian is requested",
+			defaultLanguage: i18n.English,
+			acceptLanguage:  "id-ID,id;q=0.9,en;q=0.8",
+			wantLanguage:    i18n.Indonesian,
+			wantMessage:     "data kue dengan id 9 tidak ditemukan",
+		},
+		{
+			name:            "english is requested",
+			defaultLanguage: i18n.Indonesian,
+			acceptLanguage:  "en-US",
+			wantLanguage:    i18n.English,
+			wantMessage:     "cake data with id 9 not found",
+		},
+		{
+			name:            "missing header use default language",
+			defaultLanguage: i18n.Indonesian,
+			wantLanguage:    i18n.Indonesian,
+			wantMessage:     "data kue dengan id 9 tidak ditemukan",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(LanguageMiddleware(tt.defaultLanguage))
+			router.GET("/cakes/:id", d.GetCake)
+			req := httptest.NewRequest(http.MethodGet, "/cakes/9", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, http.StatusNotFound, w.Code)
+			assert.EqualValues(t, tt.wantLanguage, w.Header().Get("Content-Language"))
+			var response model.ProblemDetails
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.EqualValues(t, model.ErrCodeCakeNotFound, response.Code)
+			assert.EqualValues(t, tt.wantMessage, response.Detail)
+		})
+	}
+}
+
+func TestUsecaseErrorResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		errResponse *model.ErrorResponse
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name: "error without code keep its message",
+			errResponse: &model.ErrorResponse{
+				Err:  errors.New("something went wrong"),
+				Kind: model.ErrInternal,
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    model.ErrCodeInternal,
+			wantMessage: "something went wrong",
+		},
+		{
+			name: "failed batch operation is translated",
+			errResponse: &model.ErrorResponse{
+				Err:    errors.New("operation at index 0 failed"),
+				Kind:   model.ErrVersionMismatch,
+				Code:   model.ErrCodeBatchFailed,
+				Params: map[string]interface{}{"index": 0, "detail": "english detail"},
+				ErrData: &model.BatchCakeResult{
+					Index:        0,
+					Err:          model.ErrVersionMismatch,
+					ErrorCode:    model.ErrCodeCakeModified,
+					ErrorMessage: "english detail",
+					ErrorParams:  map[string]interface{}{"id": 1, "version": 3},
+				},
+			},
+			wantStatus:  http.StatusPreconditionFailed,
+			wantCode:    model.ErrCodeBatchFailed,
+			wantMessage: "operasi pada indeks 0 gagal, tidak ada operasi yang dijalankan: data kue dengan id 1 telah diubah, versi saat ini adalah 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/cakes/batch", nil)
+			ctx.Request.Header.Set("Accept-Language", "id")
+			usecaseErrorResponse(ctx, tt.errResponse)
+			assert.EqualValues(t, tt.wantStatus, w.Code)
+			var response model.ProblemDetails
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.EqualValues(t, tt.wantCode, response.Code)
+			assert.EqualValues(t, tt.wantMessage, response.Detail)
+		})
+	}
+}
+
+func TestInvalidPayload_localized(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/cakes", strings.NewReader(`{"rating":7}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Request.Header.Set("Accept-Language", "id")
+	var payload model.ApiMutationCakePayload
+	invalidPayload(ctx, validatePayload(ctx.ShouldBindJSON(&payload), &payload))
+	assert.EqualValues(t, http.StatusUnprocessableEntity, w.Code)
+	var response model.ProblemDetails
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.EqualValues(t, model.ErrCodeValidationFailed, response.Code)
+	assert.EqualValues(t, "payload tidak valid: kolom 'title' wajib diisi; kolom 'rating' harus di antara 0-5", response.Detail)
+	if assert.Len(t, response.Errors, 2) {
+		assert.EqualValues(t, "kolom 'title' wajib diisi", response.Errors[0].Message)
+		assert.EqualValues(t, "kolom 'rating' harus di antara 0-5", response.Errors[1].Message)
+	}
+}
@@ -0,0 +1,167 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+)
+
+// ImageURLValidator: policy of image url given on cake payload, applied after model.NormalizeImageURL
+type ImageURLValidator struct {
+	// allowedHosts: empty allows any host, entry "*.example.com" allows any subdomain of example.com
+	allowedHosts []string
+	// checkExistence: send HEAD request to make sure the image exists
+	checkExistence bool
+	// imageBaseURL: url of image uploaded to this service, its existence is not checked since it is
+	// served by the service itself which may listen on private address
+	imageBaseURL string
+	httpClient   *http.Client
+}
+
+func NewImageURLValidator(allowedHosts []string, checkExistence bool, checkTimeout time.Duration, imageBaseURL string) *ImageURLValidator {
+	hosts := make([]string, 0, len(allowedHosts))
+	for _, host := range allowedHosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	v := &ImageURLValidator{
+		allowedHosts:   hosts,
+		checkExistence: checkExistence,
+		imageBaseURL:   strings.TrimSuffix(imageBaseURL, "/"),
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// proxy is not used, otherwise the address checked by dialer is the proxy instead of the image host
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{Timeout: checkTimeout, Control: dialPublicAddress}).DialContext
+	v.httpClient = &http.Client{
+		Timeout:       checkTimeout,
+		Transport:     transport,
+		CheckRedirect: v.checkRedirect,
+	}
+	return v
+}
+
+// maxImageURLRedirects: redirects followed by existence check, same as default of http.Client
+const maxImageURLRedirects = 10
+
+// imageURLHostError: host refused while existence check is requesting the url, either target of redirect
+// which is not allowed or address on private network
+type imageURLHostError struct {
+	host string
+}
+
+func (e *imageURLHostError) Error() string {
+	return fmt.Sprintf("host '%s' is not allowed", e.host)
+}
+
+// checkRedirect: every hop of redirect must pass the same host policy as the url itself
+func (v *ImageURLValidator) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxImageURLRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxImageURLRedirects)
+	}
+	if (req.URL.Scheme != "http" && req.URL.Scheme != "https") || !v.hostAllowed(req.URL.Hostname()) {
+		return &imageURLHostError{host: req.URL.Hostname()}
+	}
+	return nil
+}
+
+// dialPublicAddress: refuse connection to loopback, private, link-local, multicast and unspecified address.
+// it is checked on resolved address so host name pointing to internal network cannot be used either
+func dialPublicAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return &imageURLHostError{host: host}
+	}
+	return nil
+}
+
+// Validate: check host of normalised image url is allowed, existence is checked only when it is enabled
+// and requested since it cost a request per url (it is skipped on batch and import)
+func (v *ImageURLValidator) Validate(ctx context.Context, imageURL string, checkExistence bool) error {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return &model.FieldError{Field: "image", Code: "invalid_url", Message: "field 'image' must be an absolute url"}
+	}
+	if !v.hostAllowed(u.Hostname()) {
+		return &model.FieldError{
+			Field:   "image",
+			Code:    "host_not_allowed",
+			Message: fmt.Sprintf("field 'image' host '%s' is not allowed", u.Hostname()),
+			Params:  map[string]interface{}{"host": u.Hostname()},
+		}
+	}
+	if !checkExistence || !v.checkExistence || v.uploadedImage(imageURL) {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, imageURL, nil)
+	if err != nil {
+		return &model.FieldError{Field: "image", Code: "invalid_url", Message: "field 'image' must be an absolute url"}
+	}
+	resp, err := v.httpClient.Do(req)
+	var hostErr *imageURLHostError
+	if errors.As(err, &hostErr) {
+		return &model.FieldError{
+			Field:   "image",
+			Code:    "host_not_allowed",
+			Message: fmt.Sprintf("field 'image' host '%s' is not allowed", hostErr.host),
+			Params:  map[string]interface{}{"host": hostErr.host},
+		}
+	}
+	if err != nil {
+		return &model.FieldError{Field: "image", Code: "unreachable", Message: "field 'image' url cannot be reached"}
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &model.FieldError{
+			Field:   "image",
+			Code:    "not_found",
+			Message: fmt.Sprintf("field 'image' url responded with status %d", resp.StatusCode),
+			Params:  map[string]interface{}{"status": resp.StatusCode},
+		}
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" && !strings.HasPrefix(contentType, "image/") {
+		return &model.FieldError{
+			Field:   "image",
+			Code:    "not_image",
+			Message: fmt.Sprintf("field 'image' url is not an image but '%s'", contentType),
+			Params:  map[string]interface{}{"content_type": contentType},
+		}
+	}
+	return nil
+}
+
+// uploadedImage: image url is under image base url, e.g. image of cake sent back on update
+func (v *ImageURLValidator) uploadedImage(imageURL string) bool {
+	return v.imageBaseURL != "" && strings.HasPrefix(imageURL, v.imageBaseURL+"/")
+}
+
+func (v *ImageURLValidator) hostAllowed(host string) bool {
+	if len(v.allowedHosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, allowed := range v.allowedHosts {
+		if host == allowed {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package delivery
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageURLValidator_Validate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("existence check method = %v, want HEAD", r.Method)
+		}
+		switch r.URL.Path {
+		case "/cake.png":
+			w.Header().Set("Content-Type", "image/png")
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+		case "/slow.png":
+			time.Sleep(200 * time.Millisecond)
+		case "/moved.png":
+			http.Redirect(w, r, "/cake.png", http.StatusFound)
+		case "/moved-away.png":
+			http.Redirect(w, r, "http://localhost"+strings.TrimPrefix(r.Host, "127.0.0.1")+"/cake.png", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	tests := []struct {
+		name           string
+		allowedHosts   []string
+		checkExistence bool
+		imageURL       string
+		// publicOnly: keep refusing private address, otherwise test server on loopback can be reached
+		publicOnly bool
+		wantCode   string
+	}{
+		{
+			name:     "any host allowed without allow-list",
+			imageURL: "https://cdn.example.org/cake.png",
+		},
+		{
+			name:         "exact host",
+			allowedHosts: []string{" CDN.example.com "},
+			imageURL:     "https://cdn.example.com/cake.png",
+		},
+		{
+			name:         "subdomain wildcard",
+			allowedHosts: []string{"*.example.com"},
+			imageURL:     "https://img.cdn.example.com/cake.png",
+		},
+		{
+			name:         "wildcard does not match other domain",
+			allowedHosts: []string{"*.example.com"},
+			imageURL:     "https://example.com.evil.org/cake.png",
+			wantCode:     "host_not_allowed",
+		},
+		{
+			name:           "existing image",
+			checkExistence: true,
+			imageURL:       server.URL + "/cake.png",
+		},
+		{
+			name:           "missing image",
+			checkExistence: true,
+			imageURL:       server.URL + "/missing.png",
+			wantCode:       "not_found",
+		},
+		{
+			name:           "not an image",
+			checkExistence: true,
+			imageURL:       server.URL + "/page.html",
+			wantCode:       "not_image",
+		},
+		{
+			name:           "redirect to allowed host",
+			allowedHosts:   []string{"127.0.0.1"},
+			checkExistence: true,
+			imageURL:       server.URL + "/moved.png",
+		},
+		{
+			name:           "redirect to host not allowed",
+			allowedHosts:   []string{"127.0.0.1"},
+			checkExistence: true,
+			imageURL:       server.URL + "/moved-away.png",
+			wantCode:       "host_not_allowed",
+		},
+		{
+			name:           "private address refused",
+			checkExistence: true,
+			imageURL:       server.URL + "/cake.png",
+			publicOnly:     true,
+			wantCode:       "host_not_allowed",
+		},
+		{
+			name:           "uploaded image is not requested",
+			checkExistence: true,
+			imageURL:       "http://127.0.0.1:1/images/cakes/1/0f1e.png",
+			publicOnly:     true,
+		},
+		{
+			name:           "url sharing prefix of image base url is requested",
+			checkExistence: true,
+			imageURL:       "http://127.0.0.1:1/images-other/cake.png",
+			publicOnly:     true,
+			wantCode:       "host_not_allowed",
+		},
+		{
+			name:           "check timeout",
+			checkExistence: true,
+			imageURL:       server.URL + "/slow.png",
+			wantCode:       "unreachable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewImageURLValidator(tt.allowedHosts, tt.checkExistence, 50*time.Millisecond, "http://127.0.0.1:1/images/")
+			if !tt.publicOnly {
+				v.httpClient.Transport.(*http.Transport).DialContext = (&net.Dialer{}).DialContext
+			}
+			err := v.Validate(context.TODO(), tt.imageURL, true)
+			gotCode := ""
+			if err != nil {
+				fieldErr, ok := err.(*model.FieldError)
+				if assert.True(t, ok) {
+					gotCode = fieldErr.Code
+				}
+			}
+			assert.EqualValues(t, tt.wantCode, gotCode)
+		})
+	}
+}
+
+func TestImageURLValidator_Validate_existenceNotRequested(t *testing.T) {
+	v := NewImageURLValidator(nil, true, 50*time.Millisecond, "")
+	// unroutable url is not requested when the caller skip existence check e.g. import
+	assert.Nil(t, v.Validate(context.TODO(), "http://127.0.0.1:1/cake.png", false))
+}
+
+func Test_dialPublicAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{name: "public address", address: "93.184.216.34:443"},
+		{name: "public ipv6 address", address: "[2606:2800:220:1::1]:443"},
+		{name: "loopback", address: "127.0.0.1:80", wantErr: true},
+		{name: "ipv6 loopback", address: "[::1]:80", wantErr: true},
+		{name: "ipv4 mapped loopback", address: "[::ffff:127.0.0.1]:80", wantErr: true},
+		{name: "private network", address: "10.1.2.3:80", wantErr: true},
+		{name: "cloud metadata", address: "169.254.169.254:80", wantErr: true},
+		{name: "ipv6 link-local", address: "[fe80::1]:80", wantErr: true},
+		{name: "unspecified", address: "0.0.0.0:80", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dialPublicAddress("tcp", tt.address, nil)
+			assert.EqualValues(t, tt.wantErr, err != nil)
+		})
+	}
+}
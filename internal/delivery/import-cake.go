@@ -0,0 +1,184 @@
+package delivery
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	importFormatCSV    = "csv"
+	importFormatNDJSON = "ndjson"
+	// maxImportLineSize: maximum size of single NDJSON line
+	maxImportLineSize = 1024 * 1024
+)
+
+// importContentTypes: content type of import body mapped to its format
+var importContentTypes = map[string]string{
+	"text/csv":             importFormatCSV,
+	"application/csv":      importFormatCSV,
+	"application/x-ndjson": importFormatNDJSON,
+	"application/ndjson":   importFormatNDJSON,
+	"application/jsonl":    importFormatNDJSON,
+}
+
+// cakeImportDecoder: decode and validate row of import file one by one, return io.EOF when no row left
+type cakeImportDecoder interface {
+	Next() (model.ImportCakeRow, error)
+}
+
+func newCakeImportDecoder(format string, r io.Reader) (cakeImportDecoder, error) {
+	switch format {
+	case importFormatCSV:
+		return newCsvCakeImportDecoder(r)
+	case importFormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+		return &ndjsonCakeImportDecoder{scanner: scanner}, nil
+	}
+	return nil, fmt.Errorf("unsupported import format '%s'", format)
+}
+
+type csvCakeImportDecoder struct {
+	reader *csv.Reader
+	// columns: index of column by its header name
+	columns map[string]int
+}
+
+func newCsvCakeImportDecoder(r io.Reader) (*csvCakeImportDecoder, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"title", "rating"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header must contain column '%s'", name)
+		}
+	}
+	return &csvCakeImportDecoder{
+		reader:  reader,
+		columns: columns,
+	}, nil
+}
+
+func (d *csvCakeImportDecoder) Next() (model.ImportCakeRow, error) {
+	record, err := d.reader.Read()
+	if err == io.EOF {
+		return model.ImportCakeRow{}, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return model.ImportCakeRow{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+	}
+	if err != nil {
+		return model.ImportCakeRow{}, err
+	}
+	line, _ := d.reader.FieldPos(0)
+	row := model.ImportCakeRow{Line: line}
+	payload := model.ApiMutationCakePayload{
+		Title:       d.field(record, "title"),
+		Description: d.optionalField(record, "description"),
+		Image:       d.optionalField(record, "image"),
+	}
+	rating := d.field(record, "rating")
+	if rating != "" {
+		value, err := strconv.ParseFloat(rating, 32)
+		if err != nil {
+			row.Err = model.ValidationErrors{{
+				Field:   "rating",
+				Code:    "invalid_type",
+				Message: "field 'rating' must be a number",
+				Params:  map[string]interface{}{"type": "number"},
+			}}
+			return row, nil
+		}
+		payload.Rating = float32(value)
+	}
+	row.Payload, row.Err = validateImportPayload(payload)
+	return row, nil
+}
+
+func (d *csvCakeImportDecoder) field(record []string, name string) string {
+	i, ok := d.columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// optionalField: empty cell is treated as null
+func (d *csvCakeImportDecoder) optionalField(record []string, name string) *string {
+	value := d.field(record, name)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+type ndjsonCakeImportDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (d *ndjsonCakeImportDecoder) Next() (model.ImportCakeRow, error) {
+	for d.scanner.Scan() {
+		d.line++
+		text := strings.TrimSpace(d.scanner.Text())
+		if text == "" {
+			continue
+		}
+		row := model.ImportCakeRow{Line: d.line}
+		var payload model.ApiMutationCakePayload
+		err := json.Unmarshal([]byte(text), &payload)
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			row.Err = model.ValidationErrors{unmarshalTypeFieldError(typeErr)}
+			return row, nil
+		}
+		if err != nil {
+			row.Err = fmt.Errorf("invalid json: %w", err)
+			return row, nil
+		}
+		row.Payload, row.Err = validateImportPayload(payload)
+		return row, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return model.ImportCakeRow{}, fmt.Errorf("line %d: %w", d.line+1, err)
+	}
+	return model.ImportCakeRow{}, io.EOF
+}
+
+// validateImportPayload: apply the same binding and validation rule of CreateCake payload
+func validateImportPayload(payload model.ApiMutationCakePayload) (model.CakePayloadQuery, error) {
+	err := validatePayload(binding.Validator.ValidateStruct(&payload), &payload)
+	if err != nil {
+		return model.CakePayloadQuery{}, err
+	}
+	return model.CakePayloadQuery{
+		Title:       payload.Title,
+		Description: payload.Description,
+		Rating:      payload.Rating,
+		Image:       payload.Image,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package delivery
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+)
+
+// importRows: decode every row until io.EOF
+func importRows(t *testing.T, decoder cakeImportDecoder) []model.ImportCakeRow {
+	rows := make([]model.ImportCakeRow, 0)
+	for {
+		row, err := decoder.Next()
+		if err == io.EOF {
+			return rows
+		}
+		if !assert.NoError(t, err) {
+			return rows
+		}
+		rows = append(rows, row)
+	}
+}
+
+func Test_csvCakeImportDecoder(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantErr   bool
+		wantRows  []model.ImportCakeRow
+		wantErrAt []int
+	}{
+		{
+			name:    "header on any order",
+			content: "\ufeffRating,title,image,description\n4.5,cake,,\n\"3\",\" cheese cake \",https://cdn.example.com/image.png,soft\n",
+			wantRows: []model.ImportCakeRow{
+				{Line: 2, Payload: model.CakePayloadQuery{Title: "cake", Rating: 4.5}},
+				{Line: 3, Payload: model.CakePayloadQuery{Title: "cheese cake", Rating: 3, Image: null.StringFrom("https://cdn.example.com/image.png").Ptr(), Description: null.StringFrom("soft").Ptr()}},
+			},
+		},
+		{
+			name:      "invalid rows are reported",
+			content:   "title,rating\ncake,abc\n,4\ncake,9\n" + strings.Repeat("a", model.MaxTitleLength+1) + ",4\ncake,\"4\n",
+			wantErrAt: []int{2, 3, 4, 5, 6},
+		},
+		{
+			name:    "missing required column",
+			content: "title,description\ncake,soft\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty file",
+			content: "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder, err := newCakeImportDecoder(importFormatCSV, strings.NewReader(tt.content))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			rows := importRows(t, decoder)
+			if tt.wantRows != nil {
+				assert.EqualValues(t, tt.wantRows, rows)
+			}
+			for i, line := range tt.wantErrAt {
+				if assert.Greater(t, len(rows), i) {
+					assert.EqualValues(t, line, rows[i].Line)
+					assert.Error(t, rows[i].Err)
+				}
+			}
+		})
+	}
+}
+
+func Test_ndjsonCakeImportDecoder(t *testing.T) {
+	content := `{"title":"cake","rating":4.5,"description":null}
+
+{"title":"cake","rating":7}
+{"title":
+{"title":"cheese cake","rating":3,"image":"https://cdn.example.com/image.png"}
+`
+	decoder, err := newCakeImportDecoder(importFormatNDJSON, strings.NewReader(content))
+	assert.NoError(t, err)
+	rows := importRows(t, decoder)
+	if !assert.Len(t, rows, 4) {
+		return
+	}
+	assert.EqualValues(t, model.ImportCakeRow{Line: 1, Payload: model.CakePayloadQuery{Title: "cake", Rating: 4.5}}, rows[0])
+	assert.EqualValues(t, 3, rows[1].Line)
+	assert.Error(t, rows[1].Err)
+	assert.EqualValues(t, 4, rows[2].Line)
+	assert.Error(t, rows[2].Err)
+	assert.EqualValues(t, model.ImportCakeRow{Line: 5, Payload: model.CakePayloadQuery{Title: "cheese cake", Rating: 3, Image: null.StringFrom("https://cdn.example.com/image.png").Ptr()}}, rows[3])
+}
@@ -0,0 +1,86 @@
+package delivery
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// timeZoneHeader: request header of response time zone, tz query parameter takes precedence
+	timeZoneHeader = "Time-Zone"
+	// timeZoneKey: key of response time zone on gin context
+	timeZoneKey = "time_zone"
+)
+
+// TimeZoneMiddleware: pick zone of response timestamps from tz query parameter or Time-Zone header
+// by IANA name e.g. Asia/Jakarta, timestamps stay in UTC when both are empty.
+// response vary by Time-Zone header so shared cache does not serve it to other zone
+func TimeZoneMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", timeZoneHeader)
+		name := c.Query("tz")
+		if name == "" {
+			name = c.GetHeader(timeZoneHeader)
+		}
+		if name == "" {
+			c.Next()
+			return
+		}
+		// Local is the zone of server, it is not meaningful for client
+		location, err := time.LoadLocation(name)
+		if err != nil || name == "Local" {
+			errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidTimeZone, map[string]interface{}{"tz": name}, nil)
+			c.Abort()
+			return
+		}
+		c.Set(timeZoneKey, location)
+		c.Next()
+	}
+}
+
+// timeZone: zone picked by TimeZoneMiddleware, UTC when the middleware is not used
+func timeZone(c *gin.Context) *time.Location {
+	if location, ok := c.Value(timeZoneKey).(*time.Location); ok {
+		return location
+	}
+	return time.UTC
+}
+
+// cakeETag: entity tag of cake version on zone of request, see util.FormatZonedETag
+func cakeETag(c *gin.Context, version int) string {
+	return util.FormatZonedETag(version, timeZone(c).String())
+}
+
+// cakesETag: entity tag of cakes listing on zone of request, see util.FormatCollectionETag
+func cakesETag(c *gin.Context, modified model.CakesModified) string {
+	return util.FormatCollectionETag(modified.Count, modified.VersionSum, modified.ModifiedAt, timeZone(c).String())
+}
+
+// zonedTime: RFC3339 timestamp of usecase response (always UTC) converted into location,
+// UTC is written with Z suffix. value which is not RFC3339 is kept as it is
+func zonedTime(value string, location *time.Location) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.In(location).Format(time.RFC3339)
+}
+
+// zonedCake: cake with timestamps converted into time zone of request
+func zonedCake(c *gin.Context, cake model.CakeResponse) model.CakeResponse {
+	location := timeZone(c)
+	if location == time.UTC {
+		return cake
+	}
+	cake.CreatedAt = zonedTime(cake.CreatedAt, location)
+	cake.UpdatedAt = zonedTime(cake.UpdatedAt, location)
+	if cake.DeletedAt != nil {
+		deletedAt := zonedTime(*cake.DeletedAt, location)
+		cake.DeletedAt = &deletedAt
+	}
+	return cake
+}
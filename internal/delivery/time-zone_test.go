@@ -0,0 +1,163 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeZoneMiddleware(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDeletedCakesFunc = func(ctx context.Context, param model.GetDeletedCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+		deleted := "2023-01-04T20:04:05Z"
+		return &model.GetCakesResponse{
+			Meta: model.MetaPagination{PageCount: 1, TotalData: 1},
+			Data: []model.CakeResponse{{
+				ID:        1,
+				CreatedAt: "2023-01-02T08:04:05Z",
+				UpdatedAt: "2023-01-02T08:04:05Z",
+				DeletedAt: &deleted,
+			}},
+		}, nil
+	}
+	d := NewCakeDelivery(mockCakeUsecase, 0, nil)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/cakes/trash", APIVersionMiddleware(APIVersion1), TimeZoneMiddleware(), d.GetDeletedCakes)
+	router.GET("/v2/cakes/trash", APIVersionMiddleware(APIVersion2), TimeZoneMiddleware(), d.GetDeletedCakes)
+	tests := []struct {
+		name          string
+		path          string
+		header        string
+		wantCode      int
+		wantCreatedAt string
+		wantDeletedAt string
+		wantErrCode   string
+	}{
+		{
+			name:          "utc by default",
+			path:          "/v1/cakes/trash?page_size=10",
+			wantCode:      http.StatusOK,
+			wantCreatedAt: "2023-01-02T08:04:05Z",
+			wantDeletedAt: "2023-01-04T20:04:05Z",
+		},
+		{
+			name:          "tz query parameter",
+			path:          "/v1/cakes/trash?page_size=10&tz=Asia/Jakarta",
+			wantCode:      http.StatusOK,
+			wantCreatedAt: "2023-01-02T15:04:05+07:00",
+			wantDeletedAt: "2023-01-05T03:04:05+07:00",
+		},
+		{
+			name:          "Time-Zone header",
+			path:          "/v2/cakes/trash?page_size=10",
+			header:        "America/New_York",
+			wantCode:      http.StatusOK,
+			wantCreatedAt: "2023-01-02T03:04:05-05:00",
+			wantDeletedAt: "2023-01-04T15:04:05-05:00",
+		},
+		{
+			name:          "query parameter takes precedence",
+			path:          "/v2/cakes/trash?page_size=10&tz=UTC",
+			header:        "Asia/Jakarta",
+			wantCode:      http.StatusOK,
+			wantCreatedAt: "2023-01-02T08:04:05Z",
+			wantDeletedAt: "2023-01-04T20:04:05Z",
+		},
+		{
+			name:        "unknown time zone",
+			path:        "/v1/cakes/trash?page_size=10&tz=Mars/Olympus",
+			wantCode:    http.StatusBadRequest,
+			wantErrCode: model.ErrCodeInvalidTimeZone,
+		},
+		{
+			name:        "server local time zone",
+			path:        "/v1/cakes/trash?page_size=10",
+			header:      "Local",
+			wantCode:    http.StatusBadRequest,
+			wantErrCode: model.ErrCodeInvalidTimeZone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Time-Zone", tt.header)
+			}
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			assert.EqualValues(t, "Time-Zone", w.Header().Get("Vary"))
+			if tt.wantErrCode != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tt.wantErrCode+`"`)
+				return
+			}
+			assert.Contains(t, w.Body.String(), `"created_at":"`+tt.wantCreatedAt+`"`)
+			assert.Contains(t, w.Body.String(), `"deleted_at":"`+tt.wantDeletedAt+`"`)
+		})
+	}
+}
+
+func TestTimeZoneMiddleware_etag(t *testing.T) {
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GetDetailCakeFunc = func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+		return &model.CakeResponse{ID: id, Version: 3, CreatedAt: "2023-01-02T08:04:05Z", UpdatedAt: "2023-01-02T08:04:05Z"}, nil
+	}
+	d := NewCakeDelivery(mockCakeUsecase, 0, nil)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/cakes/:id", APIVersionMiddleware(APIVersion1), TimeZoneMiddleware(), d.GetCake)
+	tests := []struct {
+		name        string
+		path        string
+		ifNoneMatch string
+		wantCode    int
+		wantETag    string
+	}{
+		{
+			name:     "utc keep version tag",
+			path:     "/v1/cakes/1",
+			wantCode: http.StatusOK,
+			wantETag: `"3"`,
+		},
+		{
+			name:     "zone is part of tag",
+			path:     "/v1/cakes/1?tz=Asia/Jakarta",
+			wantCode: http.StatusOK,
+			wantETag: `"3;Asia/Jakarta"`,
+		},
+		{
+			name:        "tag of other zone is not matched",
+			path:        "/v1/cakes/1?tz=Asia/Jakarta",
+			ifNoneMatch: `"3"`,
+			wantCode:    http.StatusOK,
+			wantETag:    `"3;Asia/Jakarta"`,
+		},
+		{
+			name:        "tag of the same zone is matched",
+			path:        "/v1/cakes/1?tz=Asia/Jakarta",
+			ifNoneMatch: `"3;Asia/Jakarta"`,
+			wantCode:    http.StatusNotModified,
+			wantETag:    `"3;Asia/Jakarta"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			router.ServeHTTP(w, req)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			assert.EqualValues(t, tt.wantETag, w.Header().Get("ETag"))
+			assert.EqualValues(t, "Time-Zone", w.Header().Get("Vary"))
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// validatable: payload with validation rule beyond its binding tag
+type validatable interface {
+	Validate() error
+}
+
+// validatePayload: collect every invalid field of binding rule and payload validation into model.ValidationErrors,
+// body which cannot be decoded at all (e.g. malformed json) is returned as it is
+func validatePayload(bindErr error, payload interface{}) error {
+	var errs model.ValidationErrors
+	if bindErr != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(bindErr, &typeErr) {
+			return model.ValidationErrors{unmarshalTypeFieldError(typeErr)}
+		}
+		var validationErrs validator.ValidationErrors
+		if !errors.As(bindErr, &validationErrs) {
+			return bindErr
+		}
+		errs = bindingFieldErrors(validationErrs, payload, "json")
+	}
+	if v, ok := payload.(validatable); ok {
+		var payloadErrs model.ValidationErrors
+		err := v.Validate()
+		if errors.As(err, &payloadErrs) {
+			errs = mergeFieldErrors(errs, payloadErrs)
+		} else if err != nil {
+			return err
+		}
+	}
+	return errs.Err()
+}
+
+// validateMutationPayload: apply binding rule, payload validation and image url policy of REST API on payload
+// which is not bound from request body e.g. gRPC message, nil imageURLValidator only apply model validation
+func validateMutationPayload(ctx context.Context, imageURLValidator *ImageURLValidator, payload model.ApiMutationCakePayload) (model.CakePayloadQuery, error) {
+	err := validatePayload(binding.Validator.ValidateStruct(&payload), &payload)
+	if err == nil && payload.Image != nil && imageURLValidator != nil {
+		err = imageURLValidator.Validate(ctx, *payload.Image, true)
+	}
+	if err != nil {
+		return model.CakePayloadQuery{}, err
+	}
+	return model.CakePayloadQuery{
+		Title:       payload.Title,
+		Description: payload.Description,
+		Rating:      payload.Rating,
+		Image:       payload.Image,
+	}, nil
+}
+
+// validateQuery: apply binding rule and validation of REST API query on query which is not bound from url,
+// invalid field is named by form tag
+func validateQuery(query validatable) error {
+	err := binding.Validator.ValidateStruct(query)
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return bindingFieldErrors(validationErrs, query, "form")
+	}
+	if err != nil {
+		return err
+	}
+	return query.Validate()
+}
+
+// invalidPayload: write every invalid field on error data as 422, body which cannot be decoded is 400
+func invalidPayload(c *gin.Context, err error) {
+	var errs model.ValidationErrors
+	var fieldErr *model.FieldError
+	if errors.As(err, &fieldErr) {
+		errs = model.ValidationErrors{*fieldErr}
+	} else if !errors.As(err, &errs) {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidPayload, map[string]interface{}{"detail": err.Error()}, nil)
+		return
+	}
+	errs = localizeFieldErrors(language(c), errs)
+	errorResponse(c, http.StatusUnprocessableEntity, model.ErrCodeValidationFailed, map[string]interface{}{"detail": errs.Error()}, errs)
+}
+
+// invalidQuery: write invalid query parameter as 400, binding rule failure is listed as invalid fields named by form tag
+func invalidQuery(c *gin.Context, err error, query interface{}) {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidQuery, map[string]interface{}{"detail": err.Error()}, nil)
+		return
+	}
+	errs := localizeFieldErrors(language(c), bindingFieldErrors(validationErrs, query, "form"))
+	errorResponse(c, http.StatusBadRequest, model.ErrCodeInvalidQuery, map[string]interface{}{"detail": errs.Error()}, errs)
+}
+
+// mergeFieldErrors: field which already invalid on first is not repeated from second
+func mergeFieldErrors(first model.ValidationErrors, second model.ValidationErrors) model.ValidationErrors {
+	fields := make(map[string]bool, len(first))
+	for _, fieldErr := range first {
+		fields[fieldErr.Field] = true
+	}
+	for _, fieldErr := range second {
+		if !fields[fieldErr.Field] {
+			first = append(first, fieldErr)
+		}
+	}
+	return first
+}
+
+// bindingFieldErrors: map binding tag failure into field error named by tag (json or form) of payload
+func bindingFieldErrors(validationErrs validator.ValidationErrors, payload interface{}, tag string) model.ValidationErrors {
+	errs := make(model.ValidationErrors, 0, len(validationErrs))
+	for _, validationErr := range validationErrs {
+		field := fieldPath(reflect.TypeOf(payload), tag, validationErr.StructNamespace())
+		errs = append(errs, bindingFieldError(field, validationErr))
+	}
+	return errs
+}
+
+func bindingFieldError(field string, validationErr validator.FieldError) model.FieldError {
+	param := validationErr.Param()
+	collection := validationErr.Kind() == reflect.Slice || validationErr.Kind() == reflect.Map
+	switch validationErr.Tag() {
+	case "required":
+		return model.FieldError{Field: field, Code: "required", Message: fmt.Sprintf("field '%s' is required", field)}
+	case "oneof":
+		choices := strings.Fields(param)
+		return model.FieldError{
+			Field:   field,
+			Code:    "invalid_choice",
+			Message: fmt.Sprintf("field '%s' must be one of %s", field, strings.Join(choices, ", ")),
+			Params:  map[string]interface{}{"choices": choices},
+		}
+	case "min", "gte":
+		fieldErr := model.FieldError{
+			Field:   field,
+			Code:    "out_of_range",
+			Message: fmt.Sprintf("field '%s' must be at least %s", field, param),
+			Params:  map[string]interface{}{"min": ruleParam(param)},
+		}
+		if collection {
+			fieldErr.Message = fmt.Sprintf("field '%s' must contain at least %s items", field, param)
+			fieldErr.Params["unit"] = "items"
+		}
+		return fieldErr
+	case "max", "lte":
+		fieldErr := model.FieldError{
+			Field:   field,
+			Code:    "out_of_range",
+			Message: fmt.Sprintf("field '%s' must be at most %s", field, param),
+			Params:  map[string]interface{}{"max": ruleParam(param)},
+		}
+		if collection {
+			fieldErr.Message = fmt.Sprintf("field '%s' must contain at most %s items", field, param)
+			fieldErr.Params["unit"] = "items"
+		}
+		return fieldErr
+	}
+	fieldErr := model.FieldError{Field: field, Code: validationErr.Tag(), Message: fmt.Sprintf("field '%s' is invalid", field)}
+	if param != "" {
+		fieldErr.Params = map[string]interface{}{"param": param}
+	}
+	return fieldErr
+}
+
+// ruleParam: numeric rule argument is returned as number
+func ruleParam(param string) interface{} {
+	number, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return param
+	}
+	return number
+}
+
+func unmarshalTypeFieldError(typeErr *json.UnmarshalTypeError) model.FieldError {
+	typeName := jsonTypeName(typeErr.Type)
+	return model.FieldError{
+		Field:   typeErr.Field,
+		Code:    "invalid_type",
+		Message: fmt.Sprintf("field '%s' must be %s", typeErr.Field, typeName),
+		Params:  map[string]interface{}{"type": typeName},
+	}
+}
+
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return "object"
+}
+
+// fieldPath: translate validator namespace e.g. ApiBatchCakesPayload.Operations[0].Data.Title into
+// path operations[0].data.title using tag (json or form) of payload type, embedded struct is flattened
+func fieldPath(t reflect.Type, tag string, namespace string) string {
+	parts := strings.Split(namespace, ".")
+	path := make([]string, 0, len(parts))
+	for _, part := range parts[1:] {
+		for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+			t = t.Elem()
+		}
+		name, index, indexed := strings.Cut(part, "[")
+		jsonName := name
+		if t != nil && t.Kind() == reflect.Struct {
+			if field, ok := t.FieldByName(name); ok {
+				tagName, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+				if field.Anonymous && tagName == "" && !indexed {
+					t = field.Type
+					continue
+				}
+				if tagName != "" && tagName != "-" {
+					jsonName = tagName
+				}
+				t = field.Type
+			} else {
+				t = nil
+			}
+		}
+		if indexed {
+			jsonName += "[" + index
+		}
+		path = append(path, jsonName)
+	}
+	return strings.Join(path, ".")
+}
@@ -0,0 +1,125 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		payload  interface{}
+		want     model.ValidationErrors
+		wantCode int
+	}{
+		{
+			name:    "binding and payload validation are collected",
+			body:    `{"description":" ","rating":7,"image":"lol"}`,
+			payload: &model.ApiMutationCakePayload{},
+			want: model.ValidationErrors{
+				{Field: "title", Code: "required", Message: "field 'title' is required"},
+				{Field: "description", Code: "required", Message: "field 'description' is required"},
+				{Field: "image", Code: "invalid_url", Message: "field 'image' must be an absolute url"},
+				{Field: "rating", Code: "out_of_range", Message: "field 'rating' must be on range 0-5", Params: map[string]interface{}{"min": float64(0), "max": float64(5)}},
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "wrong json type",
+			body:    `{"title":"cake","rating":"high"}`,
+			payload: &model.ApiMutationCakePayload{},
+			want: model.ValidationErrors{
+				{Field: "rating", Code: "invalid_type", Message: "field 'rating' must be number", Params: map[string]interface{}{"type": "number"}},
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "binding rule param",
+			body:    `{"mode":"partial","operations":[]}`,
+			payload: &model.ApiBatchCakesPayload{},
+			want: model.ValidationErrors{
+				{Field: "mode", Code: "invalid_choice", Message: "field 'mode' must be one of atomic, best_effort", Params: map[string]interface{}{"choices": []interface{}{"atomic", "best_effort"}}},
+				{Field: "operations", Code: "out_of_range", Message: "field 'operations' must contain at least 1 items", Params: map[string]interface{}{"min": float64(1), "unit": "items"}},
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "malformed json",
+			body:     `{"title":`,
+			payload:  &model.ApiMutationCakePayload{},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/cakes", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			err := validatePayload(ctx.ShouldBindJSON(tt.payload), tt.payload)
+			if !assert.Error(t, err) {
+				return
+			}
+			invalidPayload(ctx, err)
+			assert.EqualValues(t, tt.wantCode, w.Code)
+			var response struct {
+				Errors model.ValidationErrors `json:"errors"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.EqualValues(t, tt.want, response.Errors)
+		})
+	}
+}
+
+func TestFieldPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   interface{}
+		tag       string
+		namespace string
+		want      string
+	}{
+		{
+			name:      "basic test",
+			payload:   &model.ApiMutationCakePayload{},
+			tag:       "json",
+			namespace: "ApiMutationCakePayload.Title",
+			want:      "title",
+		},
+		{
+			name:      "embedded query struct is flattened",
+			payload:   &model.ApiGetCakesQuery{},
+			tag:       "form",
+			namespace: "ApiGetCakesQuery.ApiCakesFilterQuery.MinRating",
+			want:      "min_rating",
+		},
+		{
+			name:      "nested slice item",
+			payload:   &model.ApiBatchCakesPayload{},
+			tag:       "json",
+			namespace: "ApiBatchCakesPayload.Operations[2].Data.Rating",
+			want:      "operations[2].data.rating",
+		},
+		{
+			name:      "unknown field keep its name",
+			payload:   &model.ApiMutationCakePayload{},
+			tag:       "json",
+			namespace: "ApiMutationCakePayload.Unknown",
+			want:      "Unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualValues(t, tt.want, fieldPath(reflect.TypeOf(tt.payload), tt.tag, tt.namespace))
+		})
+	}
+}
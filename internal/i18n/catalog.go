@@ -0,0 +1,186 @@
+package i18n
+
+import "github.com/forderation/ralali-test/internal/model"
+
+// catalogue: message template of error code by language, every code must exist on DefaultLanguage.
+// message of invalid field is keyed by "field." + field error code
+var catalogue = map[string]map[string]string{
+	English: {
+		model.ErrCodeInternal: "internal server error",
+
+		model.ErrCodeInvalidQuery:          "invalid query parameter: {detail}",
+		model.ErrCodePageSizeTooLarge:      "maximum page_size is {max}",
+		model.ErrCodeInvalidParameterID:    "invalid parameter id",
+		model.ErrCodeInvalidPayload:        "invalid payload: {detail}",
+		model.ErrCodeValidationFailed:      "invalid payload: {detail}",
+		model.ErrCodeBatchInvalid:          "invalid payload, no operation is applied",
+		model.ErrCodeIfMatchRequired:       "header If-Match is required, use ETag from GetCake",
+		model.ErrCodeInvalidIfMatch:        "invalid header If-Match: {detail}",
+		model.ErrCodeVersionRequired:       "field 'version' is required, use version of cake from GetCake",
+		model.ErrCodeUnsupportedImportType: "content type must be text/csv or application/x-ndjson",
+		model.ErrCodeInvalidImportFile:     "invalid import file: {detail}",
+		model.ErrCodeUnsupportedPatchType:  "content type must be application/merge-patch+json",
+		model.ErrCodeImageRequired:         "form file 'image' is required",
+		model.ErrCodeImageTooLarge:         "maximum image size is {max_size} bytes",
+		model.ErrCodeImageUploadFailed:     "error read image: {detail}",
+		model.ErrCodeUnsupportedImageType:  "image must be jpeg, png, gif or webp",
+		model.ErrCodeInvalidGraphqlRequest: "invalid GraphQL request: {detail}",
+		model.ErrCodeQueryTooComplex:       "query complexity {complexity} exceeds the limit of {max}",
+		model.ErrCodeMutationNotAllowed:    "mutation must be sent using POST request",
+		model.ErrCodeInvalidTimeZone:       "unknown time zone '{tz}', use IANA time zone name e.g. Asia/Jakarta",
+		model.ErrCodeUnauthorized:          "authentication is required, send bearer token on Authorization header",
+		model.ErrCodeInvalidToken:          "invalid token: {detail}",
+		model.ErrCodeInvalidApiKey:         "invalid or revoked api key",
+		model.ErrCodeInsufficientScope:     "scope '{scope}' is required",
+
+		model.ErrCodeCakeNotFound:            "cake data with id {id} not found",
+		model.ErrCodeCakeModified:            "cake data with id {id} has been modified, current version is {version}",
+		model.ErrCodeCakeNotDeleted:          "cake data with id {id} is not deleted",
+		model.ErrCodeInvalidSort:             "invalid query parameter 'sort': {detail}",
+		model.ErrCodeInvalidCursor:           "invalid query parameter 'cursor'",
+		model.ErrCodeGetCakeFailed:           "error get cake data",
+		model.ErrCodeGetCakesFailed:          "error on get data cakes",
+		model.ErrCodeCountCakesFailed:        "error on count total cakes",
+		model.ErrCodeGetDeletedCakesFailed:   "error on get data deleted cakes",
+		model.ErrCodeCountDeletedCakesFailed: "error on count total deleted cakes",
+		model.ErrCodeCreateCakeFailed:        "error add cake data",
+		model.ErrCodeUpdateCakeFailed:        "error update cake data",
+		model.ErrCodeDeleteCakeFailed:        "error delete cake data",
+		model.ErrCodeRestoreCakeFailed:       "error restore cake data",
+		model.ErrCodeExportCakesFailed:       "error on export data cakes",
+		model.ErrCodeInvalidPurgeRetention:   "purge retention must be positive, got {retention}",
+		model.ErrCodeCountPurgeableFailed:    "error count purgeable cakes",
+		model.ErrCodePurgeFailed:             "error purge deleted cakes",
+		model.ErrCodePurgeInterrupted:        "purge deleted cakes interrupted",
+		model.ErrCodeBatchFailed:             "operation at index {index} failed, no operation is applied: {detail}",
+		model.ErrCodeBatchRunFailed:          "error run batch cakes",
+		model.ErrCodeUnknownOperation:        "unknown operation '{op}'",
+		model.ErrCodeImportReadFailed:        "error read import file: {detail}",
+		model.ErrCodeImportFailed:            "error import cakes, {imported_rows} rows already imported",
+		model.ErrCodeInvalidImportRow:        "invalid row: {detail}",
+
+		model.ErrCodeImageNotFound:            "image not found",
+		model.ErrCodeGetImageFailed:           "error get image",
+		model.ErrCodeStoreImageFailed:         "error store cake image",
+		model.ErrCodeImageDecodeFailed:        "cannot resize image '{key}'",
+		model.ErrCodeImageTooLargeToResize:    "image '{key}' is too large to resize",
+		model.ErrCodeStoreImageVariantFailed:  "error store image variant",
+		model.ErrCodeRegenerateImagesFailed:   "error get data cakes",
+		model.ErrCodeRegenerateImageCancelled: "image variant regeneration is cancelled",
+
+		model.ErrCodeApiKeyNotFound:           "api key with id {id} not found",
+		model.ErrCodeCreateApiKeyFailed:       "error create api key",
+		model.ErrCodeGetApiKeysFailed:         "error get api keys",
+		model.ErrCodeRevokeApiKeyFailed:       "error revoke api key",
+		model.ErrCodeAuthenticateApiKeyFailed: "error authenticate api key",
+
+		"field.invalid":                 "field '{field}' is invalid",
+		"field.required":                "field '{field}' is required",
+		"field.not_nullable":            "field '{field}' cannot be null",
+		"field.empty_payload":           "payload must contain at least one field",
+		"field.out_of_range":            "field '{field}' must be on range {min}-{max}",
+		"field.out_of_range.min":        "field '{field}' must be at least {min}",
+		"field.out_of_range.max":        "field '{field}' must be at most {max}",
+		"field.out_of_range.min_items":  "field '{field}' must contain at least {min} items",
+		"field.out_of_range.max_items":  "field '{field}' must contain at most {max} items",
+		"field.invalid_choice":          "field '{field}' must be one of {choices}",
+		"field.invalid_type":            "field '{field}' must be {type}",
+		"field.invalid_url":             "field '{field}' must be an absolute url",
+		"field.invalid_scheme":          "field '{field}' must be http or https url",
+		"field.credentials_not_allowed": "field '{field}' cannot contain credentials",
+		"field.too_long":                "field '{field}' cannot be longer than {max} characters",
+		"field.host_not_allowed":        "field '{field}' host '{host}' is not allowed",
+		"field.unreachable":             "field '{field}' url cannot be reached",
+		"field.not_found":               "field '{field}' url responded with status {status}",
+		"field.not_image":               "field '{field}' url is not an image but '{content_type}'",
+	},
+	Indonesian: {
+		model.ErrCodeInternal: "terjadi kesalahan pada server",
+
+		model.ErrCodeInvalidQuery:          "parameter query tidak valid: {detail}",
+		model.ErrCodePageSizeTooLarge:      "page_size maksimal adalah {max}",
+		model.ErrCodeInvalidParameterID:    "parameter id tidak valid",
+		model.ErrCodeInvalidPayload:        "payload tidak valid: {detail}",
+		model.ErrCodeValidationFailed:      "payload tidak valid: {detail}",
+		model.ErrCodeBatchInvalid:          "payload tidak valid, tidak ada operasi yang dijalankan",
+		model.ErrCodeIfMatchRequired:       "header If-Match wajib diisi, gunakan ETag dari GetCake",
+		model.ErrCodeInvalidIfMatch:        "header If-Match tidak valid: {detail}",
+		model.ErrCodeVersionRequired:       "kolom 'version' wajib diisi, gunakan version cake dari GetCake",
+		model.ErrCodeUnsupportedImportType: "content type harus text/csv atau application/x-ndjson",
+		model.ErrCodeInvalidImportFile:     "file impor tidak valid: {detail}",
+		model.ErrCodeUnsupportedPatchType:  "content type harus application/merge-patch+json",
+		model.ErrCodeImageRequired:         "form file 'image' wajib diisi",
+		model.ErrCodeImageTooLarge:         "ukuran gambar maksimal adalah {max_size} byte",
+		model.ErrCodeImageUploadFailed:     "gagal membaca gambar: {detail}",
+		model.ErrCodeUnsupportedImageType:  "gambar harus berformat jpeg, png, gif atau webp",
+		model.ErrCodeInvalidGraphqlRequest: "request GraphQL tidak valid: {detail}",
+		model.ErrCodeQueryTooComplex:       "kompleksitas query {complexity} melebihi batas {max}",
+		model.ErrCodeMutationNotAllowed:    "mutation harus dikirim menggunakan request POST",
+		model.ErrCodeInvalidTimeZone:       "zona waktu '{tz}' tidak dikenal, gunakan nama zona waktu IANA misalnya Asia/Jakarta",
+		model.ErrCodeUnauthorized:          "autentikasi diperlukan, kirim bearer token pada header Authorization",
+		model.ErrCodeInvalidToken:          "token tidak valid: {detail}",
+		model.ErrCodeInvalidApiKey:         "api key tidak valid atau sudah dicabut",
+		model.ErrCodeInsufficientScope:     "scope '{scope}' diperlukan",
+
+		model.ErrCodeCakeNotFound:            "data kue dengan id {id} tidak ditemukan",
+		model.ErrCodeCakeModified:            "data kue dengan id {id} telah diubah, versi saat ini adalah {version}",
+		model.ErrCodeCakeNotDeleted:          "data kue dengan id {id} tidak sedang dihapus",
+		model.ErrCodeInvalidSort:             "parameter query 'sort' tidak valid: {detail}",
+		model.ErrCodeInvalidCursor:           "parameter query 'cursor' tidak valid",
+		model.ErrCodeGetCakeFailed:           "gagal mengambil data kue",
+		model.ErrCodeGetCakesFailed:          "gagal mengambil daftar kue",
+		model.ErrCodeCountCakesFailed:        "gagal menghitung total kue",
+		model.ErrCodeGetDeletedCakesFailed:   "gagal mengambil daftar kue yang dihapus",
+		model.ErrCodeCountDeletedCakesFailed: "gagal menghitung total kue yang dihapus",
+		model.ErrCodeCreateCakeFailed:        "gagal menambah data kue",
+		model.ErrCodeUpdateCakeFailed:        "gagal mengubah data kue",
+		model.ErrCodeDeleteCakeFailed:        "gagal menghapus data kue",
+		model.ErrCodeRestoreCakeFailed:       "gagal memulihkan data kue",
+		model.ErrCodeExportCakesFailed:       "gagal mengekspor data kue",
+		model.ErrCodeInvalidPurgeRetention:   "retensi penghapusan permanen harus positif, didapat {retention}",
+		model.ErrCodeCountPurgeableFailed:    "gagal menghitung kue yang dapat dihapus permanen",
+		model.ErrCodePurgeFailed:             "gagal menghapus permanen kue yang dihapus",
+		model.ErrCodePurgeInterrupted:        "penghapusan permanen kue terhenti",
+		model.ErrCodeBatchFailed:             "operasi pada indeks {index} gagal, tidak ada operasi yang dijalankan: {detail}",
+		model.ErrCodeBatchRunFailed:          "gagal menjalankan batch kue",
+		model.ErrCodeUnknownOperation:        "operasi '{op}' tidak dikenal",
+		model.ErrCodeImportReadFailed:        "gagal membaca file impor: {detail}",
+		model.ErrCodeImportFailed:            "gagal mengimpor kue, {imported_rows} baris sudah terimpor",
+		model.ErrCodeInvalidImportRow:        "baris tidak valid: {detail}",
+
+		model.ErrCodeImageNotFound:            "gambar tidak ditemukan",
+		model.ErrCodeGetImageFailed:           "gagal mengambil gambar",
+		model.ErrCodeStoreImageFailed:         "gagal menyimpan gambar kue",
+		model.ErrCodeImageDecodeFailed:        "gambar '{key}' tidak dapat diubah ukurannya",
+		model.ErrCodeImageTooLargeToResize:    "gambar '{key}' terlalu besar untuk diubah ukurannya",
+		model.ErrCodeStoreImageVariantFailed:  "gagal menyimpan varian gambar",
+		model.ErrCodeRegenerateImagesFailed:   "gagal mengambil daftar kue",
+		model.ErrCodeRegenerateImageCancelled: "pembuatan ulang varian gambar dibatalkan",
+
+		model.ErrCodeApiKeyNotFound:           "api key dengan id {id} tidak ditemukan",
+		model.ErrCodeCreateApiKeyFailed:       "gagal membuat api key",
+		model.ErrCodeGetApiKeysFailed:         "gagal mengambil daftar api key",
+		model.ErrCodeRevokeApiKeyFailed:       "gagal mencabut api key",
+		model.ErrCodeAuthenticateApiKeyFailed: "gagal memverifikasi api key",
+
+		"field.invalid":                 "kolom '{field}' tidak valid",
+		"field.required":                "kolom '{field}' wajib diisi",
+		"field.not_nullable":            "kolom '{field}' tidak boleh null",
+		"field.empty_payload":           "payload harus berisi minimal satu kolom",
+		"field.out_of_range":            "kolom '{field}' harus di antara {min}-{max}",
+		"field.out_of_range.min":        "kolom '{field}' minimal {min}",
+		"field.out_of_range.max":        "kolom '{field}' maksimal {max}",
+		"field.out_of_range.min_items":  "kolom '{field}' harus berisi minimal {min} item",
+		"field.out_of_range.max_items":  "kolom '{field}' harus berisi maksimal {max} item",
+		"field.invalid_choice":          "kolom '{field}' harus salah satu dari {choices}",
+		"field.invalid_type":            "kolom '{field}' harus bertipe {type}",
+		"field.invalid_url":             "kolom '{field}' harus berupa url absolut",
+		"field.invalid_scheme":          "kolom '{field}' harus berupa url http atau https",
+		"field.credentials_not_allowed": "kolom '{field}' tidak boleh berisi kredensial",
+		"field.too_long":                "kolom '{field}' tidak boleh lebih dari {max} karakter",
+		"field.host_not_allowed":        "host '{host}' pada kolom '{field}' tidak diizinkan",
+		"field.unreachable":             "url pada kolom '{field}' tidak dapat dijangkau",
+		"field.not_found":               "url pada kolom '{field}' merespons dengan status {status}",
+		"field.not_image":               "url pada kolom '{field}' bukan gambar melainkan '{content_type}'",
+	},
+}
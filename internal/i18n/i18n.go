@@ -0,0 +1,142 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	English    = "en"
+	Indonesian = "id"
+	// DefaultLanguage: used when none of Accept-Language is supported, also the fallback of missing translation
+	DefaultLanguage = English
+)
+
+// languageAliases: legacy or alternative primary subtag mapped to supported language
+var languageAliases = map[string]string{
+	// "in" is deprecated code of Indonesian which is still sent by older Android / Java client
+	"in": Indonesian,
+}
+
+// IsSupported: language has message catalogue
+func IsSupported(language string) bool {
+	_, ok := catalogue[language]
+	return ok
+}
+
+// MatchLanguage: pick supported language of Accept-Language header with the highest quality value,
+// region is ignored (id-ID is id). fallback is returned when none of them is supported
+func MatchLanguage(acceptLanguage string, fallback string) string {
+	if !IsSupported(fallback) {
+		fallback = DefaultLanguage
+	}
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, parameter, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if name, value, ok := strings.Cut(parameter, "="); ok && strings.TrimSpace(name) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if alias, ok := languageAliases[language]; ok {
+			language = alias
+		}
+		if language == "*" {
+			language = fallback
+		}
+		if !IsSupported(language) || quality <= bestQuality {
+			continue
+		}
+		best, bestQuality = language, quality
+	}
+	if best == "" {
+		return fallback
+	}
+	return best
+}
+
+// Message: translate message of code into language, placeholder {name} is replaced by params[name].
+// missing translation falls back to DefaultLanguage then to the code itself
+func Message(language string, code string, params map[string]interface{}) string {
+	template, ok := catalogue[language][code]
+	if !ok {
+		template, ok = catalogue[DefaultLanguage][code]
+	}
+	if !ok {
+		return code
+	}
+	return format(template, params)
+}
+
+// FieldMessage: translate message of invalid field, variant of code is picked by its params
+// e.g. out_of_range with only min param use "field.out_of_range.min"
+func FieldMessage(language string, field string, code string, params map[string]interface{}) string {
+	key := fieldMessageKey(code, params)
+	if _, ok := catalogue[DefaultLanguage][key]; !ok {
+		key = "field.invalid"
+	}
+	values := make(map[string]interface{}, len(params)+1)
+	for name, value := range params {
+		values[name] = value
+	}
+	values["field"] = field
+	return Message(language, key, values)
+}
+
+func fieldMessageKey(code string, params map[string]interface{}) string {
+	key := "field." + code
+	if code != "out_of_range" {
+		return key
+	}
+	_, hasMin := params["min"]
+	_, hasMax := params["max"]
+	switch {
+	case hasMin && hasMax:
+		return key
+	case hasMin:
+		key += ".min"
+	case hasMax:
+		key += ".max"
+	}
+	if params["unit"] == "items" {
+		key += "_items"
+	}
+	return key
+}
+
+func format(template string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return template
+	}
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	// replace in stable order so the output never depend on map iteration
+	sort.Strings(names)
+	replacements := make([]string, 0, len(params)*2)
+	for _, name := range names {
+		replacements = append(replacements, "{"+name+"}", formatParam(params[name]))
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+func formatParam(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,187 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+)
+
+func TestMatchLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		fallback       string
+		want           string
+	}{
+		{
+			name:           "empty header use fallback",
+			acceptLanguage: "",
+			fallback:       Indonesian,
+			want:           Indonesian,
+		},
+		{
+			name:           "region is ignored",
+			acceptLanguage: "id-ID",
+			fallback:       English,
+			want:           Indonesian,
+		},
+		{
+			name:           "highest quality value win",
+			acceptLanguage: "en;q=0.5, id-ID;q=0.8, fr",
+			fallback:       English,
+			want:           Indonesian,
+		},
+		{
+			name:           "first language win on same quality",
+			acceptLanguage: "en-US, id",
+			fallback:       Indonesian,
+			want:           English,
+		},
+		{
+			name:           "unsupported language use fallback",
+			acceptLanguage: "fr-FR, de;q=0.9",
+			fallback:       Indonesian,
+			want:           Indonesian,
+		},
+		{
+			name:           "zero quality is not acceptable",
+			acceptLanguage: "id;q=0",
+			fallback:       English,
+			want:           English,
+		},
+		{
+			name:           "legacy indonesian code",
+			acceptLanguage: "in-ID",
+			fallback:       English,
+			want:           Indonesian,
+		},
+		{
+			name:           "wildcard use fallback",
+			acceptLanguage: "fr, *;q=0.5",
+			fallback:       Indonesian,
+			want:           Indonesian,
+		},
+		{
+			name:           "unsupported fallback use default language",
+			acceptLanguage: "fr",
+			fallback:       "fr",
+			want:           DefaultLanguage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchLanguage(tt.acceptLanguage, tt.fallback); got != tt.want {
+				t.Errorf("MatchLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		code     string
+		params   map[string]interface{}
+		want     string
+	}{
+		{
+			name:     "english with params",
+			language: English,
+			code:     model.ErrCodeCakeModified,
+			params:   map[string]interface{}{"id": 1, "version": 2},
+			want:     "cake data with id 1 has been modified, current version is 2",
+		},
+		{
+			name:     "indonesian with params",
+			language: Indonesian,
+			code:     model.ErrCodeCakeNotFound,
+			params:   map[string]interface{}{"id": 7},
+			want:     "data kue dengan id 7 tidak ditemukan",
+		},
+		{
+			name:     "unsupported language falls back to default language",
+			language: "fr",
+			code:     model.ErrCodeInvalidParameterID,
+			want:     "invalid parameter id",
+		},
+		{
+			name:     "unknown code is returned as it is",
+			language: Indonesian,
+			code:     "unknown_code",
+			want:     "unknown_code",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Message(tt.language, tt.code, tt.params); got != tt.want {
+				t.Errorf("Message() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		field    string
+		code     string
+		params   map[string]interface{}
+		want     string
+	}{
+		{
+			name:     "range",
+			language: Indonesian,
+			field:    "rating",
+			code:     "out_of_range",
+			params:   map[string]interface{}{"min": 0, "max": 5},
+			want:     "kolom 'rating' harus di antara 0-5",
+		},
+		{
+			name:     "minimum items",
+			language: English,
+			field:    "operations",
+			code:     "out_of_range",
+			params:   map[string]interface{}{"min": float64(1), "unit": "items"},
+			want:     "field 'operations' must contain at least 1 items",
+		},
+		{
+			name:     "choices",
+			language: Indonesian,
+			field:    "mode",
+			code:     "invalid_choice",
+			params:   map[string]interface{}{"choices": []interface{}{"atomic", "best_effort"}},
+			want:     "kolom 'mode' harus salah satu dari atomic, best_effort",
+		},
+		{
+			name:     "unknown code",
+			language: English,
+			field:    "title",
+			code:     "alphanum",
+			want:     "field 'title' is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FieldMessage(tt.language, tt.field, tt.code, tt.params); got != tt.want {
+				t.Errorf("FieldMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogueComplete(t *testing.T) {
+	for language, messages := range catalogue {
+		for code := range catalogue[DefaultLanguage] {
+			if _, ok := messages[code]; !ok {
+				t.Errorf("catalogue %s missing message of %s", language, code)
+			}
+		}
+		for code := range messages {
+			if _, ok := catalogue[DefaultLanguage][code]; !ok {
+				t.Errorf("catalogue %s has message of %s which is not on %s", language, code, DefaultLanguage)
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// ApiKey: represent model of api_keys table
+type ApiKey struct {
+	ID   int
+	Name string
+	// Prefix: public part of key used to look the key up, secret is only stored as SecretHash
+	Prefix     string
+	SecretHash string
+	// Scopes: space separated scopes e.g. "cakes:read cakes:write"
+	Scopes     string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
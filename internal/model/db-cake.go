@@ -14,4 +14,16 @@ type Cake struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
+	// Version: incremented on every mutation, used for optimistic locking
+	Version int
+}
+
+// CakesModified: aggregate of cakes table changed by every mutation of record matching a filter
+type CakesModified struct {
+	// Count: number of record not soft deleted
+	Count int64
+	// VersionSum: sum of version of record not soft deleted, catch update done on the same second of ModifiedAt
+	VersionSum int64
+	// ModifiedAt: latest updated_at or deleted_at, soft deleted record included
+	ModifiedAt time.Time
 }
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// domain error: kind of usecase error checked by errors.Is, mapped into status of each transport (HTTP, gRPC)
+var (
+	ErrNotFound = errors.New("not found")
+	// ErrConflict: request conflicts with current state of resource e.g. restore cake which is not deleted
+	ErrConflict = errors.New("conflict")
+	// ErrValidation: invalid argument e.g. unknown sort field, also matched by FieldError and ValidationErrors
+	ErrValidation = errors.New("validation failed")
+	// ErrUnsupported: content which cannot be processed e.g. unsupported image type
+	ErrUnsupported = errors.New("unsupported")
+	// ErrUnauthenticated: credential of request is invalid e.g. revoked api key
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrInternal: failure of dependency e.g. database or image store
+	ErrInternal = errors.New("internal error")
+
+	ErrCakeNotFound   = fmt.Errorf("cake %w", ErrNotFound)
+	ErrImageNotFound  = fmt.Errorf("image %w", ErrNotFound)
+	ErrApiKeyNotFound = fmt.Errorf("api key %w", ErrNotFound)
+	// ErrVersionMismatch: expected version of optimistic locking is not the current version
+	ErrVersionMismatch = fmt.Errorf("version mismatch %w", ErrConflict)
+)
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorResponse_Is(t *testing.T) {
+	driverErr := errors.New("connection refused")
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "match kind",
+			err:    &ErrorResponse{Err: errors.New("cake data with id 1 not found"), Kind: ErrCakeNotFound},
+			target: ErrCakeNotFound,
+			want:   true,
+		},
+		{
+			name:   "match generic kind",
+			err:    &ErrorResponse{Err: errors.New("cake data with id 1 not found"), Kind: ErrCakeNotFound},
+			target: ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "version mismatch is conflict",
+			err:    &ErrorResponse{Err: errors.New("modified"), Kind: ErrVersionMismatch},
+			target: ErrConflict,
+			want:   true,
+		},
+		{
+			name:   "other kind does not match",
+			err:    &ErrorResponse{Err: errors.New("modified"), Kind: ErrConflict},
+			target: ErrVersionMismatch,
+			want:   false,
+		},
+		{
+			name:   "match wrapped error",
+			err:    &ErrorResponse{Err: fmt.Errorf("error get cake data: %w", driverErr), Kind: ErrInternal},
+			target: driverErr,
+			want:   true,
+		},
+		{
+			name:   "validation errors",
+			err:    ValidationErrors{{Field: "title", Code: "required"}},
+			target: ErrValidation,
+			want:   true,
+		},
+		{
+			name:   "field error",
+			err:    &FieldError{Field: "title", Code: "required"},
+			target: ErrValidation,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package model
+
+// error code: stable identifier of error response, client should rely on it instead of the translated message.
+// message of every code is defined on i18n catalogue
+const (
+	ErrCodeInternal = "internal_error"
+
+	// request errors
+	ErrCodeInvalidQuery          = "invalid_query"
+	ErrCodePageSizeTooLarge      = "page_size_too_large"
+	ErrCodeInvalidParameterID    = "invalid_parameter_id"
+	ErrCodeInvalidPayload        = "invalid_payload"
+	ErrCodeValidationFailed      = "validation_failed"
+	ErrCodeBatchInvalid          = "batch_invalid"
+	ErrCodeIfMatchRequired       = "if_match_required"
+	ErrCodeInvalidIfMatch        = "invalid_if_match"
+	ErrCodeVersionRequired       = "version_required"
+	ErrCodeUnsupportedImportType = "unsupported_import_type"
+	ErrCodeInvalidImportFile     = "invalid_import_file"
+	ErrCodeUnsupportedPatchType  = "unsupported_patch_type"
+	ErrCodeImageRequired         = "image_required"
+	ErrCodeImageTooLarge         = "image_too_large"
+	ErrCodeImageUploadFailed     = "image_upload_failed"
+	ErrCodeUnsupportedImageType  = "unsupported_image_type"
+	ErrCodeInvalidGraphqlRequest = "invalid_graphql_request"
+	ErrCodeQueryTooComplex       = "query_too_complex"
+	ErrCodeMutationNotAllowed    = "mutation_not_allowed"
+	ErrCodeInvalidTimeZone       = "invalid_time_zone"
+	ErrCodeUnauthorized          = "unauthorized"
+	ErrCodeInvalidToken          = "invalid_token"
+	ErrCodeInvalidApiKey         = "invalid_api_key"
+	ErrCodeInsufficientScope     = "insufficient_scope"
+
+	// cake errors
+	ErrCodeCakeNotFound            = "cake_not_found"
+	ErrCodeCakeModified            = "cake_modified"
+	ErrCodeCakeNotDeleted          = "cake_not_deleted"
+	ErrCodeInvalidSort             = "invalid_sort"
+	ErrCodeInvalidCursor           = "invalid_cursor"
+	ErrCodeGetCakeFailed           = "get_cake_failed"
+	ErrCodeGetCakesFailed          = "get_cakes_failed"
+	ErrCodeCountCakesFailed        = "count_cakes_failed"
+	ErrCodeGetDeletedCakesFailed   = "get_deleted_cakes_failed"
+	ErrCodeCountDeletedCakesFailed = "count_deleted_cakes_failed"
+	ErrCodeCreateCakeFailed        = "create_cake_failed"
+	ErrCodeUpdateCakeFailed        = "update_cake_failed"
+	ErrCodeDeleteCakeFailed        = "delete_cake_failed"
+	ErrCodeRestoreCakeFailed       = "restore_cake_failed"
+	ErrCodeExportCakesFailed       = "export_cakes_failed"
+	ErrCodeInvalidPurgeRetention   = "invalid_purge_retention"
+	ErrCodeCountPurgeableFailed    = "count_purgeable_failed"
+	ErrCodePurgeFailed             = "purge_failed"
+	ErrCodePurgeInterrupted        = "purge_interrupted"
+	ErrCodeBatchFailed             = "batch_failed"
+	ErrCodeBatchRunFailed          = "batch_run_failed"
+	ErrCodeUnknownOperation        = "unknown_operation"
+	ErrCodeImportReadFailed        = "import_read_failed"
+	ErrCodeImportFailed            = "import_failed"
+	ErrCodeInvalidImportRow        = "invalid_import_row"
+
+	// image errors
+	ErrCodeImageNotFound            = "image_not_found"
+	ErrCodeGetImageFailed           = "get_image_failed"
+	ErrCodeStoreImageFailed         = "store_image_failed"
+	ErrCodeImageDecodeFailed        = "image_decode_failed"
+	ErrCodeImageTooLargeToResize    = "image_too_large_to_resize"
+	ErrCodeStoreImageVariantFailed  = "store_image_variant_failed"
+	ErrCodeRegenerateImagesFailed   = "regenerate_images_failed"
+	ErrCodeRegenerateImageCancelled = "regenerate_images_cancelled"
+
+	// api key errors
+	ErrCodeApiKeyNotFound           = "api_key_not_found"
+	ErrCodeCreateApiKeyFailed       = "create_api_key_failed"
+	ErrCodeGetApiKeysFailed         = "get_api_keys_failed"
+	ErrCodeRevokeApiKeyFailed       = "revoke_api_key_failed"
+	ErrCodeAuthenticateApiKeyFailed = "authenticate_api_key_failed"
+)
@@ -1,16 +1,146 @@
 package model
 
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ErrorResponse: error of usecase, Err is kept in english for log while Code and Params are used to
+// translate message on response. Kind is domain error e.g. ErrCakeNotFound, checked by errors.Is on ErrorResponse
 type ErrorResponse struct {
-	Err            error
-	HttpStatusCode int
-	ErrData        interface{}
+	Err     error
+	Kind    error
+	ErrData interface{}
+	Code    string
+	Params  map[string]interface{}
+}
+
+func (e *ErrorResponse) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ErrorResponse) Unwrap() []error {
+	return []error{e.Kind, e.Err}
 }
 
 type ErrorDetailResponse struct {
 	Detail string `json:"detail"`
 }
 
+// FieldError: validation error of single payload field, code is stable to be handled by client
+// and params hold the rule argument e.g. {"min": 0, "max": 5}
+type FieldError struct {
+	Field   string                 `json:"field"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+func (e *FieldError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ValidationErrors: every invalid field of payload
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Append: add field error, nil is skipped
+func (e ValidationErrors) Append(fieldErr *FieldError) ValidationErrors {
+	if fieldErr == nil {
+		return e
+	}
+	return append(e, *fieldErr)
+}
+
+// Prefix: nest field errors under parent field e.g. title become data.title
+func (e ValidationErrors) Prefix(parent string) ValidationErrors {
+	nested := make(ValidationErrors, 0, len(e))
+	for _, fieldErr := range e {
+		if fieldErr.Field == "" {
+			fieldErr.Field = parent
+		} else {
+			fieldErr.Field = parent + "." + fieldErr.Field
+		}
+		nested = append(nested, fieldErr)
+	}
+	return nested
+}
+
+// Err: return nil when there is no invalid field, so it is never returned as non nil error holding empty list
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// JsonErrorResp: error response body, message is translated by Accept-Language
 type JsonErrorResp struct {
+	ErrorCode    string      `json:"error_code"`
 	ErrorMessage string      `json:"error_message"`
 	ErrData      interface{} `json:"error_data,omitempty"`
 }
+
+// ProblemDetails: error response body of RFC 7807 (application/problem+json), code, errors and data are
+// extension members
+type ProblemDetails struct {
+	// Type: uri of problem type, about:blank when problem type base url is not configured
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code: stable error code, same as error_code of JsonErrorResp
+	Code string `json:"code"`
+	// Errors: every invalid field of request
+	Errors ValidationErrors `json:"errors,omitempty"`
+	// Data: other error data e.g. result of batch operations
+	Data interface{} `json:"data,omitempty"`
+}
+
+// NullableField: json field which distinguish between missing field, explicit null and value,
+// used on partial update (JSON Merge Patch) payload
+type NullableField[T any] struct {
+	// Set: field is present on payload
+	Set bool
+	// Valid: field value is not null
+	Valid bool
+	Value T
+}
+
+func NullableFieldFrom[T any](value T) NullableField[T] {
+	return NullableField[T]{Set: true, Valid: true, Value: value}
+}
+
+// Ptr: return nil when field is null or missing
+func (f NullableField[T]) Ptr() *T {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Value
+}
+
+func (f *NullableField[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if string(data) == "null" {
+		f.Valid = false
+		return nil
+	}
+	f.Valid = true
+	return json.Unmarshal(data, &f.Value)
+}
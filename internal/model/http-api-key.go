@@ -0,0 +1,74 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// scope of api key, checked per route
+const (
+	ScopeCakesRead  = "cakes:read"
+	ScopeCakesWrite = "cakes:write"
+)
+
+// ScopeApiKeysAdmin: scope of bearer token managing api keys, it cannot be granted to api key
+const ScopeApiKeysAdmin = "api_keys:admin"
+
+// ApiKeyScopes: every scope which can be granted to api key
+var ApiKeyScopes = []string{ScopeCakesRead, ScopeCakesWrite}
+
+// MaxApiKeyNameLength: length of name column
+const MaxApiKeyNameLength = 255
+
+// ApiCreateApiKeyPayload: request validation model of api key creation
+type ApiCreateApiKeyPayload struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+}
+
+func (p *ApiCreateApiKeyPayload) Validate() error {
+	var errs ValidationErrors
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		errs = append(errs, FieldError{Field: "name", Code: "required", Message: "field 'name' cannot be empty"})
+	} else if len(p.Name) > MaxApiKeyNameLength {
+		errs = append(errs, FieldError{
+			Field:   "name",
+			Code:    "too_long",
+			Message: fmt.Sprintf("field 'name' cannot be longer than %d characters", MaxApiKeyNameLength),
+			Params:  map[string]interface{}{"max": MaxApiKeyNameLength},
+		})
+	}
+	scopes := make([]string, 0, len(p.Scopes))
+	for i, scope := range p.Scopes {
+		scope = strings.TrimSpace(scope)
+		if !isApiKeyScope(scope) {
+			field := fmt.Sprintf("scopes[%d]", i)
+			errs = append(errs, FieldError{
+				Field:   field,
+				Code:    "invalid_choice",
+				Message: fmt.Sprintf("field '%s' must be one of %s", field, strings.Join(ApiKeyScopes, ", ")),
+				Params:  map[string]interface{}{"choices": ApiKeyScopes},
+			})
+			continue
+		}
+		if !containsString(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	p.Scopes = scopes
+	return errs.Err()
+}
+
+func isApiKeyScope(scope string) bool {
+	return containsString(ApiKeyScopes, scope)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApiCreateApiKeyPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    ApiCreateApiKeyPayload
+		wantName   string
+		wantScopes []string
+		wantFields []string
+	}{
+		{
+			name:       "basic test",
+			payload:    ApiCreateApiKeyPayload{Name: " partner ", Scopes: []string{ScopeCakesRead, ScopeCakesWrite}},
+			wantName:   "partner",
+			wantScopes: []string{ScopeCakesRead, ScopeCakesWrite},
+		},
+		{
+			name:       "duplicate scope",
+			payload:    ApiCreateApiKeyPayload{Name: "partner", Scopes: []string{ScopeCakesRead, " cakes:read"}},
+			wantName:   "partner",
+			wantScopes: []string{ScopeCakesRead},
+		},
+		{
+			name:       "blank name",
+			payload:    ApiCreateApiKeyPayload{Name: "  ", Scopes: []string{ScopeCakesRead}},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "name too long",
+			payload:    ApiCreateApiKeyPayload{Name: strings.Repeat("a", MaxApiKeyNameLength+1), Scopes: []string{ScopeCakesRead}},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "unknown scope",
+			payload:    ApiCreateApiKeyPayload{Name: "partner", Scopes: []string{ScopeCakesRead, ScopeApiKeysAdmin, "cakes:*"}},
+			wantFields: []string{"scopes[1]", "scopes[2]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.Validate()
+			if tt.wantFields != nil {
+				var errs ValidationErrors
+				if !errors.As(err, &errs) {
+					t.Fatalf("ApiCreateApiKeyPayload.Validate() error = %v, want ValidationErrors", err)
+				}
+				var fields []string
+				for _, fieldErr := range errs {
+					fields = append(fields, fieldErr.Field)
+				}
+				if !reflect.DeepEqual(fields, tt.wantFields) {
+					t.Errorf("ApiCreateApiKeyPayload.Validate() fields = %v, want %v", fields, tt.wantFields)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApiCreateApiKeyPayload.Validate() error = %v", err)
+			}
+			if tt.payload.Name != tt.wantName || !reflect.DeepEqual(tt.payload.Scopes, tt.wantScopes) {
+				t.Errorf("ApiCreateApiKeyPayload.Validate() = %v, %v, want %v, %v", tt.payload.Name, tt.payload.Scopes, tt.wantName, tt.wantScopes)
+			}
+		})
+	}
+}
+
+func TestAuthenticatedApiKey_HasScope(t *testing.T) {
+	apiKey := AuthenticatedApiKey{Scopes: []string{ScopeCakesRead}}
+	if !apiKey.HasScope(ScopeCakesRead) {
+		t.Errorf("AuthenticatedApiKey.HasScope(%v) = false, want true", ScopeCakesRead)
+	}
+	if apiKey.HasScope(ScopeCakesWrite) {
+		t.Errorf("AuthenticatedApiKey.HasScope(%v) = true, want false", ScopeCakesWrite)
+	}
+}
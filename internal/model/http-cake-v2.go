@@ -0,0 +1,67 @@
+package model
+
+// response model of /v2, every success response is wrapped on data and listing put its pagination on meta
+
+// CakeV2Response: cake on /v2, only carry fields of cake resource
+type CakeV2Response struct {
+	ID          int                 `json:"id"`
+	Title       string              `json:"title"`
+	Description *string             `json:"description"`
+	Rating      float32             `json:"rating"`
+	Image       *string             `json:"image"`
+	Images      *CakeImagesResponse `json:"images"`
+	CreatedAt   string              `json:"created_at"`
+	UpdatedAt   string              `json:"updated_at"`
+	// DeletedAt: only filled on soft deleted cake e.g. trash listing
+	DeletedAt *string `json:"deleted_at,omitempty"`
+	Version   int     `json:"version"`
+}
+
+type CakeV2DataResponse struct {
+	Data CakeV2Response `json:"data"`
+}
+
+type CakesV2Response struct {
+	Data []CakeV2Response `json:"data"`
+	Meta MetaV2Pagination `json:"meta"`
+}
+
+// MetaV2Pagination: page_count and total_count are 0 on cursor pagination
+type MetaV2Pagination struct {
+	PageCount  int     `json:"page_count"`
+	TotalCount int64   `json:"total_count"`
+	NextCursor *string `json:"next_cursor"`
+	PrevCursor *string `json:"prev_cursor"`
+}
+
+// CakeDeleteV2Response: deleted cake, version is not returned since deleted cake cannot be modified
+type CakeDeleteV2Response struct {
+	Data CakeDeleteResponse `json:"data"`
+}
+
+// BatchCakeV2Result: result of batch operation on /v2, cake of succeeded operation is CakeV2Response
+type BatchCakeV2Result struct {
+	Index        int              `json:"index"`
+	Op           string           `json:"op"`
+	Status       int              `json:"status"`
+	ID           int              `json:"id,omitempty"`
+	Cake         *CakeV2Response  `json:"cake,omitempty"`
+	ErrorCode    string           `json:"error_code,omitempty"`
+	ErrorMessage string           `json:"error_message,omitempty"`
+	Errors       ValidationErrors `json:"errors,omitempty"`
+}
+
+// BatchCakesV2Response: results of batch operations on data, count of succeeded and failed operation on meta
+type BatchCakesV2Response struct {
+	Data []BatchCakeV2Result `json:"data"`
+	Meta MetaV2Batch         `json:"meta"`
+}
+
+type MetaV2Batch struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+type ImportCakesV2Response struct {
+	Data ImportCakesResponse `json:"data"`
+}
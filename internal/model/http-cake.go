@@ -2,15 +2,100 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// MaxImageURLLength: length of image column
+const MaxImageURLLength = 255
+
+// MaxTitleLength, MaxDescriptionLength: length (characters) of title and description column
+const (
+	MaxTitleLength       = 255
+	MaxDescriptionLength = 255
+)
+
+// ApiCakesFilterQuery: request validation model of cakes filter, shared by listing and export
+type ApiCakesFilterQuery struct {
+	Keyword       string     `form:"q"`
+	MinRating     *float32   `form:"min_rating" binding:"omitempty,gte=0,lte=5"`
+	MaxRating     *float32   `form:"max_rating" binding:"omitempty,gte=0,lte=5"`
+	HasImage      *bool      `form:"has_image"`
+	CreatedAfter  *time.Time `form:"created_after"`
+	CreatedBefore *time.Time `form:"created_before"`
+}
+
+func (q *ApiCakesFilterQuery) Validate() error {
+	q.Keyword = strings.TrimSpace(q.Keyword)
+	if q.MinRating != nil && q.MaxRating != nil && *q.MinRating > *q.MaxRating {
+		return errors.New("query 'min_rating' cannot be greater than 'max_rating'")
+	}
+	if q.CreatedAfter != nil && q.CreatedBefore != nil && q.CreatedAfter.After(*q.CreatedBefore) {
+		return errors.New("query 'created_after' cannot be later than 'created_before'")
+	}
+	return nil
+}
+
+// Filter: map validated query into filter for usecase and repository
+func (q *ApiCakesFilterQuery) Filter() CakesFilterQuery {
+	return CakesFilterQuery{
+		Keyword:       q.Keyword,
+		MinRating:     q.MinRating,
+		MaxRating:     q.MaxRating,
+		HasImage:      q.HasImage,
+		CreatedAfter:  q.CreatedAfter,
+		CreatedBefore: q.CreatedBefore,
+	}
+}
+
 // ApiGetCakesQuery: request validation model
 type ApiGetCakesQuery struct {
+	ApiCakesFilterQuery
+	Page       int    `form:"page"`
+	PageSize   int    `form:"page_size" binding:"required"`
+	Sort       string `form:"sort"`
+	Pagination string `form:"pagination" binding:"omitempty,oneof=page cursor"`
+	Cursor     string `form:"cursor"`
+}
+
+func (q *ApiGetCakesQuery) Validate() error {
+	err := q.ApiCakesFilterQuery.Validate()
+	if err != nil {
+		return err
+	}
+	if q.IsCursorMode() && strings.TrimSpace(q.Sort) != "" {
+		return errors.New("query 'sort' is not supported on cursor pagination")
+	}
+	return nil
+}
+
+// IsCursorMode: cursor (keyset) pagination is used when requested explicitly or cursor is given
+func (q *ApiGetCakesQuery) IsCursorMode() bool {
+	return q.Pagination == "cursor" || q.Cursor != ""
+}
+
+// ApiExportCakesQuery: request validation model of export
+type ApiExportCakesQuery struct {
+	ApiCakesFilterQuery
+	Format string `form:"format" binding:"required,oneof=csv ndjson xlsx"`
+	Sort   string `form:"sort"`
+}
+
+// ApiGetDeletedCakesQuery: request validation model of trash listing
+type ApiGetDeletedCakesQuery struct {
 	Page     int `form:"page"`
 	PageSize int `form:"page_size" binding:"required"`
 }
 
+// ApiImportCakesQuery: request validation model of import, format is taken from content type when empty
+type ApiImportCakesQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+	DryRun bool   `form:"dry_run"`
+}
+
 // ApiMutationCakePayload: request validation model
 type ApiMutationCakePayload struct {
 	Title       string  `json:"title" binding:"required"`
@@ -20,26 +105,202 @@ type ApiMutationCakePayload struct {
 }
 
 func (p *ApiMutationCakePayload) Validate() error {
-	p.Title = strings.TrimSpace(p.Title)
-	if len(p.Title) <= 0 {
-		return errors.New("field 'title' cannot be empty")
-	}
+	var errs ValidationErrors
+	title, fieldErr := validateTitle(p.Title)
+	errs = errs.Append(fieldErr)
+	p.Title = title
 	if p.Description != nil {
-		description := strings.TrimSpace(*p.Description)
-		if len(description) <= 0 {
-			return errors.New("field 'description' cannot be empty")
-		}
+		description, fieldErr := validateOptionalText("description", *p.Description, MaxDescriptionLength)
+		errs = errs.Append(fieldErr)
 		p.Description = &description
 	}
 	if p.Image != nil {
-		image := strings.TrimSpace(*p.Image)
-		if len(image) <= 0 {
-			return errors.New("field 'image' cannot be empty")
-		}
+		image, fieldErr := NormalizeImageURL(*p.Image)
+		errs = errs.Append(fieldErr)
 		p.Image = &image
 	}
-	if p.Rating < 0 || p.Rating > 5 {
-		return errors.New("field 'rating' must be on range 0-5")
+	errs = errs.Append(validateRating(p.Rating))
+	return errs.Err()
+}
+
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+// ApiBatchCakesPayload: request validation model of batch operations, default mode is atomic
+type ApiBatchCakesPayload struct {
+	Mode       string                  `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Operations []ApiBatchCakeOperation `json:"operations" binding:"required,min=1,max=500"`
+}
+
+// ApiBatchCakeOperation: single operation of batch, data is required on create / update,
+// id and version (ETag value, positive) are required on update / delete, batch cannot skip version check
+type ApiBatchCakeOperation struct {
+	Op      string                  `json:"op" enums:"create,update,delete"`
+	ID      int                     `json:"id"`
+	Version *int                    `json:"version"`
+	Data    *ApiMutationCakePayload `json:"data"`
+}
+
+func (o *ApiBatchCakeOperation) Validate() error {
+	var errs ValidationErrors
+	switch o.Op {
+	case BatchOpCreate:
+	case BatchOpUpdate, BatchOpDelete:
+		if o.ID <= 0 {
+			errs = errs.Append(&FieldError{Field: "id", Code: "required", Message: "field 'id' is required"})
+		}
+		if o.Version == nil || *o.Version <= 0 {
+			errs = errs.Append(&FieldError{Field: "version", Code: "required", Message: "field 'version' is required"})
+		}
+	default:
+		errs = errs.Append(&FieldError{
+			Field:   "op",
+			Code:    "invalid_choice",
+			Message: fmt.Sprintf("field 'op' must be one of %s, %s, %s", BatchOpCreate, BatchOpUpdate, BatchOpDelete),
+			Params:  map[string]interface{}{"choices": []string{BatchOpCreate, BatchOpUpdate, BatchOpDelete}},
+		})
+		return errs.Err()
+	}
+	if o.Op == BatchOpDelete {
+		return errs.Err()
+	}
+	if o.Data == nil {
+		errs = errs.Append(&FieldError{Field: "data", Code: "required", Message: "field 'data' is required"})
+		return errs.Err()
+	}
+	var dataErrs ValidationErrors
+	if errors.As(o.Data.Validate(), &dataErrs) {
+		errs = append(errs, dataErrs.Prefix("data")...)
+	}
+	return errs.Err()
+}
+
+// ApiPatchCakePayload: request validation model of partial update (JSON Merge Patch),
+// missing field is left unchanged and null field is cleared
+type ApiPatchCakePayload struct {
+	Title       NullableField[string]  `json:"title" swaggertype:"string"`
+	Description NullableField[string]  `json:"description" swaggertype:"string"`
+	Rating      NullableField[float32] `json:"rating" swaggertype:"number"`
+	Image       NullableField[string]  `json:"image" swaggertype:"string"`
+}
+
+func (p *ApiPatchCakePayload) Validate() error {
+	var errs ValidationErrors
+	if !p.Title.Set && !p.Description.Set && !p.Rating.Set && !p.Image.Set {
+		errs = errs.Append(&FieldError{Code: "empty_payload", Message: "payload must contain at least one field"})
+		return errs.Err()
+	}
+	if p.Title.Set {
+		if !p.Title.Valid {
+			errs = errs.Append(&FieldError{Field: "title", Code: "not_nullable", Message: "field 'title' cannot be null"})
+		} else {
+			title, fieldErr := validateTitle(p.Title.Value)
+			errs = errs.Append(fieldErr)
+			p.Title.Value = title
+		}
+	}
+	if p.Description.Valid {
+		description, fieldErr := validateOptionalText("description", p.Description.Value, MaxDescriptionLength)
+		errs = errs.Append(fieldErr)
+		p.Description.Value = description
+	}
+	if p.Image.Valid {
+		image, fieldErr := NormalizeImageURL(p.Image.Value)
+		errs = errs.Append(fieldErr)
+		p.Image.Value = image
+	}
+	if p.Rating.Set {
+		if !p.Rating.Valid {
+			errs = errs.Append(&FieldError{Field: "rating", Code: "not_nullable", Message: "field 'rating' cannot be null"})
+		} else {
+			errs = errs.Append(validateRating(p.Rating.Value))
+		}
+	}
+	return errs.Err()
+}
+
+func validateTitle(title string) (string, *FieldError) {
+	title = strings.TrimSpace(title)
+	if len(title) <= 0 {
+		return "", &FieldError{Field: "title", Code: "required", Message: "field 'title' cannot be empty"}
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return "", tooLongFieldError("title", MaxTitleLength)
+	}
+	return title, nil
+}
+
+// validateOptionalText: validate nullable text field, when value is given it cannot be blank nor longer than max
+func validateOptionalText(field string, value string, max int) (string, *FieldError) {
+	value = strings.TrimSpace(value)
+	if len(value) <= 0 {
+		return "", &FieldError{Field: field, Code: "required", Message: fmt.Sprintf("field '%s' cannot be empty", field)}
+	}
+	if utf8.RuneCountInString(value) > max {
+		return "", tooLongFieldError(field, max)
+	}
+	return value, nil
+}
+
+func tooLongFieldError(field string, max int) *FieldError {
+	return &FieldError{
+		Field:   field,
+		Code:    "too_long",
+		Message: fmt.Sprintf("field '%s' cannot be longer than %d characters", field, max),
+		Params:  map[string]interface{}{"max": max},
+	}
+}
+
+func validateRating(rating float32) *FieldError {
+	if rating < 0 || rating > 5 {
+		return &FieldError{
+			Field:   "rating",
+			Code:    "out_of_range",
+			Message: "field 'rating' must be on range 0-5",
+			Params:  map[string]interface{}{"min": 0, "max": 5},
+		}
 	}
 	return nil
 }
+
+// NormalizeImageURL: validate image url is absolute http / https url fit on image column, then normalise it
+// by lower casing scheme and host, dropping default port and fragment
+func NormalizeImageURL(value string) (string, *FieldError) {
+	value = strings.TrimSpace(value)
+	if len(value) <= 0 {
+		return "", &FieldError{Field: "image", Code: "required", Message: "field 'image' cannot be empty"}
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || u.Hostname() == "" {
+		return "", &FieldError{Field: "image", Code: "invalid_url", Message: "field 'image' must be an absolute url"}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", &FieldError{Field: "image", Code: "invalid_scheme", Message: "field 'image' must be http or https url"}
+	}
+	if u.User != nil {
+		return "", &FieldError{Field: "image", Code: "credentials_not_allowed", Message: "field 'image' cannot contain credentials"}
+	}
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	port := u.Port()
+	if port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Fragment = ""
+	u.RawFragment = ""
+	normalized := u.String()
+	if len(normalized) > MaxImageURLLength {
+		return "", &FieldError{
+			Field:   "image",
+			Code:    "too_long",
+			Message: fmt.Sprintf("field 'image' cannot be longer than %d characters", MaxImageURLLength),
+			Params:  map[string]interface{}{"max": MaxImageURLLength},
+		}
+	}
+	return normalized, nil
+}
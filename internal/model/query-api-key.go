@@ -0,0 +1,9 @@
+package model
+
+// ApiKeyPayloadQuery: new api key record, secret is already hashed
+type ApiKeyPayloadQuery struct {
+	Name       string
+	Prefix     string
+	SecretHash string
+	Scopes     string
+}
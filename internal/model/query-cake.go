@@ -1,8 +1,53 @@
 package model
 
+import "time"
+
+// AnyVersion: expected version which skip optimistic locking check, used on If-Match: *
+const AnyVersion = 0
+
+// CakesFilterQuery: optional filter applied on cakes listing, nil / empty value means filter not applied
+type CakesFilterQuery struct {
+	Keyword       string
+	MinRating     *float32
+	MaxRating     *float32
+	HasImage      *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
 type GetCakesQuery struct {
 	Limit  int
 	Offset int
+	Filter CakesFilterQuery
+	// Sort: comma separated column, prefix with '-' for descending order e.g. "-created_at,title"
+	Sort string
+}
+
+type StreamCakesQuery struct {
+	Filter CakesFilterQuery
+	// Sort: same format as GetCakesQuery.Sort
+	Sort string
+}
+
+type GetDeletedCakesQuery struct {
+	Limit  int
+	Offset int
+}
+
+// CakeSortKey: keyset position of cake record on default order (rating DESC, title ASC, id ASC)
+type CakeSortKey struct {
+	Rating float32
+	Title  string
+	ID     int
+}
+
+type GetCakesSeekQuery struct {
+	Limit  int
+	Filter CakesFilterQuery
+	// After: seek position, nil means from the first record
+	After *CakeSortKey
+	// Backward: seek record before After position instead of after it
+	Backward bool
 }
 
 type CakePayloadQuery struct {
@@ -11,3 +56,11 @@ type CakePayloadQuery struct {
 	Rating      float32
 	Image       *string
 }
+
+// CakePatchQuery: partial update of cake record, only field with Set true are updated
+type CakePatchQuery struct {
+	Title       NullableField[string]
+	Description NullableField[string]
+	Rating      NullableField[float32]
+	Image       NullableField[string]
+}
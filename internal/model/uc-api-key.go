@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+type CreateApiKeyUsecaseParam struct {
+	Name   string
+	Scopes []string
+}
+
+// ApiKeyResponse: api key without its secret, timestamps are RFC3339 in UTC
+type ApiKeyResponse struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
+	// LastUsedAt: null when key is never used
+	LastUsedAt *string `json:"last_used_at"`
+	CreatedAt  string  `json:"created_at"`
+	// RevokedAt: null when key is still active
+	RevokedAt *string `json:"revoked_at"`
+}
+
+// CreateApiKeyResponse: created api key, Key is the only time the secret is returned
+type CreateApiKeyResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
+
+type ApiKeysResponse struct {
+	Data []ApiKeyResponse `json:"api_keys"`
+}
+
+// AuthenticatedApiKey: active api key of X-API-Key header
+type AuthenticatedApiKey struct {
+	ID     int
+	Name   string
+	Scopes []string
+	// LastUsedAt: last used time recorded by this request
+	LastUsedAt time.Time
+}
+
+// HasScope: scope is granted to the key
+func (k AuthenticatedApiKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,77 @@
 package model
 
+import (
+	"io"
+	"time"
+)
+
 type GetCakesUsecaseParam struct {
 	Page     int
 	PageSize int
+	Filter   CakesFilterQuery
+	Sort     string
+	// CursorMode: use keyset pagination on default order, Page is ignored
+	CursorMode bool
+	// Cursor: opaque cursor from previous response next_cursor / prev_cursor, empty means first page
+	Cursor string
+	// Backward: seek page before Cursor (last page when Cursor is empty) regardless direction of cursor,
+	// used by cursor of single cake e.g. GraphQL before argument
+	Backward bool
+}
+
+type GetDeletedCakesUsecaseParam struct {
+	Page     int
+	PageSize int
+}
+
+type PurgeCakesUsecaseParam struct {
+	// Retention: soft deleted cake older than retention are purged
+	Retention time.Duration
+	// BatchSize: maximum record deleted on single statement to avoid long table lock
+	BatchSize int
+	// DryRun: only count purgeable cake without deleting it
+	DryRun bool
+}
+
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+// BatchCakeOperation: single operation of batch, Index is position of operation on request payload
+type BatchCakeOperation struct {
+	Index   int
+	Op      string
+	ID      int
+	Version int
+	Payload CakePayloadQuery
+}
+
+type BatchCakesUsecaseParam struct {
+	// Atomic: all operation is rolled back when one of them failed, otherwise failed operation is only reported
+	Atomic     bool
+	Operations []BatchCakeOperation
+}
+
+// ImportCakeRow: decoded row of import file, Err is filled when row is invalid
+type ImportCakeRow struct {
+	// Line: line number of row on import file
+	Line    int
+	Payload CakePayloadQuery
+	Err     error
+}
+
+type ImportCakesUsecaseParam struct {
+	// Next: return next decoded row, io.EOF when no row left, other error stop the import
+	Next func() (ImportCakeRow, error)
+	// DryRun: only validate rows without inserting it
+	DryRun bool
+}
+
+type ExportCakesUsecaseParam struct {
+	Filter CakesFilterQuery
+	Sort   string
 }
 
 type GetCakesResponse struct {
@@ -11,27 +80,118 @@ type GetCakesResponse struct {
 }
 
 type MetaPagination struct {
-	PageCount int   `json:"page_count"`
-	TotalData int64 `json:"total_data"`
+	PageCount  int     `json:"page_count"`
+	TotalData  int64   `json:"total_data"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+	PrevCursor *string `json:"prev_cursor,omitempty"`
 }
 
+// CakeResponse: timestamps are RFC3339 in UTC, REST transport convert them into time zone of request
 type CakeResponse struct {
 	ID          int     `json:"id"`
 	Title       string  `json:"title"`
 	Description *string `json:"description"`
 	Rating      float32 `json:"rating"`
 	Image       *string `json:"image"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	// Images: resized variants of image, null when cake has no image
+	Images    *CakeImagesResponse `json:"images"`
+	CreatedAt string              `json:"created_at"`
+	UpdatedAt string              `json:"updated_at"`
+	// DeletedAt: only filled on soft deleted cake e.g. trash listing
+	DeletedAt *string `json:"deleted_at,omitempty"`
+	Version   int     `json:"version"`
 }
 
-type CakeMutationResponse struct {
-	Title       string  `json:"title"`
-	Description *string `json:"description"`
-	Rating      float32 `json:"rating"`
-	Image       *string `json:"image"`
+// CakeImagesResponse: responsive variants of cake image, url of variant which is not generated yet
+// (or image hosted outside image store) falls back to the original image
+type CakeImagesResponse struct {
+	Original string                     `json:"original"`
+	Variants []CakeImageVariantResponse `json:"variants"`
+}
+
+type CakeImageVariantResponse struct {
+	Width  int    `json:"width"`
+	Format string `json:"format"`
+	URL    string `json:"url"`
 }
 
 type CakeDeleteResponse struct {
-	ID int `json:"id"`
+	ID        int    `json:"id"`
+	DeletedAt string `json:"deleted_at"`
+}
+
+type PurgeCakesResponse struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Purged        int64     `json:"purged"`
+	DryRun        bool      `json:"dry_run"`
+}
+
+// BatchCakeResult: result of single batch operation, Status is set by transport from Op and Err
+type BatchCakeResult struct {
+	Index        int           `json:"index"`
+	Op           string        `json:"op"`
+	Status       int           `json:"status"`
+	ID           int           `json:"id,omitempty"`
+	Cake         *CakeResponse `json:"cake,omitempty"`
+	ErrorCode    string        `json:"error_code,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+	// ErrorParams: argument of error code to translate error message
+	ErrorParams map[string]interface{} `json:"-"`
+	// Err: domain error of failed operation
+	Err error `json:"-"`
+	// Errors: invalid fields of operation, nested field of data is prefixed e.g. data.rating
+	Errors ValidationErrors `json:"errors,omitempty"`
+}
+
+type BatchCakesResponse struct {
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchCakeResult `json:"results"`
+}
+
+type ImportCakeError struct {
+	Line         int    `json:"line"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+	// ErrorParams: argument of error code to translate error message
+	ErrorParams map[string]interface{} `json:"-"`
+	Errors      ValidationErrors       `json:"errors,omitempty"`
+}
+
+type ImportCakesResponse struct {
+	DryRun       bool `json:"dry_run"`
+	TotalRows    int  `json:"total_rows"`
+	ValidRows    int  `json:"valid_rows"`
+	ImportedRows int  `json:"imported_rows"`
+	RejectedRows int  `json:"rejected_rows"`
+	// Errors: rejected rows, limited to first 1000 rows, see ErrorsTruncated
+	Errors          []ImportCakeError `json:"errors"`
+	ErrorsTruncated bool              `json:"errors_truncated"`
+}
+
+type UploadCakeImageParam struct {
+	Content []byte
+	// ContentType: detected from content, not from client header
+	ContentType string
+}
+
+// ImageObject: stored image opened from image store
+type ImageObject struct {
+	// Key: key of served image, it differs from requested key when missing variant falls back to original image
+	Key         string
+	Content     io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+type RegenerateImageVariantsParam struct {
+	// Force: regenerate variants even when all of them already exist
+	Force bool
+}
+
+type RegenerateImageVariantsResponse struct {
+	Images    int `json:"images"`
+	Generated int `json:"generated"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
 }
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	INSERT_API_KEY_STMT int = iota
+	GET_API_KEYS_STMT
+	GET_API_KEY_STMT
+	GET_API_KEY_BY_PREFIX_STMT
+	REVOKE_API_KEY_STMT
+	TOUCH_API_KEY_STMT
+)
+
+const apiKeyColumns = "id, name, prefix, secret_hash, scopes, created_at, last_used_at, revoked_at"
+
+type ApiKeyDBRepository struct {
+	tableName     string
+	queryPrepared map[int]*sql.Stmt
+}
+
+func NewApiKeyDBRepository(db *sql.DB, tableName string) ApiKeyDBInterface {
+	if db == nil {
+		logrus.Panic("db param for NewApiKeyDBRepository is nil")
+	}
+	queryPrepared := make(map[int]*sql.Stmt, 0)
+	sqlStmtInsertApiKey, err := db.Prepare(fmt.Sprintf("INSERT INTO %s (name, prefix, secret_hash, scopes, created_at) VALUES (?, ?, ?, ?, ?)", tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtInsertApiKey : ", err)
+	}
+	sqlStmtGetApiKeys, err := db.Prepare(fmt.Sprintf("SELECT %s FROM %s ORDER BY id", apiKeyColumns, tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtGetApiKeys : ", err)
+	}
+	sqlStmtGetApiKey, err := db.Prepare(fmt.Sprintf("SELECT %s FROM %s WHERE id = ? LIMIT 1", apiKeyColumns, tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtGetApiKey : ", err)
+	}
+	sqlStmtGetApiKeyByPrefix, err := db.Prepare(fmt.Sprintf("SELECT %s FROM %s WHERE prefix = ? LIMIT 1", apiKeyColumns, tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtGetApiKeyByPrefix : ", err)
+	}
+	sqlStmtRevokeApiKey, err := db.Prepare(fmt.Sprintf("UPDATE %s SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtRevokeApiKey : ", err)
+	}
+	sqlStmtTouchApiKey, err := db.Prepare(fmt.Sprintf("UPDATE %s SET last_used_at = ? WHERE id = ?", tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtTouchApiKey : ", err)
+	}
+	queryPrepared[INSERT_API_KEY_STMT] = sqlStmtInsertApiKey
+	queryPrepared[GET_API_KEYS_STMT] = sqlStmtGetApiKeys
+	queryPrepared[GET_API_KEY_STMT] = sqlStmtGetApiKey
+	queryPrepared[GET_API_KEY_BY_PREFIX_STMT] = sqlStmtGetApiKeyByPrefix
+	queryPrepared[REVOKE_API_KEY_STMT] = sqlStmtRevokeApiKey
+	queryPrepared[TOUCH_API_KEY_STMT] = sqlStmtTouchApiKey
+	return &ApiKeyDBRepository{
+		tableName:     tableName,
+		queryPrepared: queryPrepared,
+	}
+}
+
+func scanApiKeyRows(rows *sql.Rows) ([]model.ApiKey, error) {
+	result := make([]model.ApiKey, 0)
+	for rows.Next() {
+		var apiKey model.ApiKey
+		err := rows.Scan(&apiKey.ID, &apiKey.Name, &apiKey.Prefix, &apiKey.SecretHash, &apiKey.Scopes, &apiKey.CreatedAt, &apiKey.LastUsedAt, &apiKey.RevokedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, apiKey)
+	}
+	return result, rows.Err()
+}
+
+// getApiKey: single api key record of prepared statement, nil when record not found
+func (repo *ApiKeyDBRepository) getApiKey(ctx context.Context, key int, args ...interface{}) (*model.ApiKey, error) {
+	rows, err := repo.queryPrepared[key].QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result, err := scanApiKeyRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) > 0 {
+		return &result[0], nil
+	}
+	return nil, nil
+}
+
+func (repo *ApiKeyDBRepository) InsertApiKey(ctx context.Context, param model.ApiKeyPayloadQuery) (int64, error) {
+	stmt := repo.queryPrepared[INSERT_API_KEY_STMT]
+	result, err := stmt.ExecContext(ctx, param.Name, param.Prefix, param.SecretHash, param.Scopes, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *ApiKeyDBRepository) GetApiKeys(ctx context.Context) ([]model.ApiKey, error) {
+	rows, err := repo.queryPrepared[GET_API_KEYS_STMT].QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanApiKeyRows(rows)
+}
+
+func (repo *ApiKeyDBRepository) GetApiKey(ctx context.Context, id int) (*model.ApiKey, error) {
+	return repo.getApiKey(ctx, GET_API_KEY_STMT, id)
+}
+
+func (repo *ApiKeyDBRepository) GetApiKeyByPrefix(ctx context.Context, prefix string) (*model.ApiKey, error) {
+	return repo.getApiKey(ctx, GET_API_KEY_BY_PREFIX_STMT, prefix)
+}
+
+func (repo *ApiKeyDBRepository) RevokeApiKey(ctx context.Context, id int, revokedAt time.Time) (bool, error) {
+	stmt := repo.queryPrepared[REVOKE_API_KEY_STMT]
+	result, err := stmt.ExecContext(ctx, revokedAt.UTC(), id)
+	return isRowAffected(result, err)
+}
+
+func (repo *ApiKeyDBRepository) TouchApiKey(ctx context.Context, id int, usedAt time.Time) error {
+	stmt := repo.queryPrepared[TOUCH_API_KEY_STMT]
+	_, err := stmt.ExecContext(ctx, usedAt.UTC(), id)
+	return err
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/forderation/ralali-test/internal/model"
+)
+
+func InitApiKeyTestDB(tableName string) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("INSERT INTO %s (name, prefix, secret_hash, scopes, created_at) VALUES (?, ?, ?, ?, ?)", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT id, name, prefix, secret_hash, scopes, created_at, last_used_at, revoked_at FROM %s ORDER BY id", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT id, name, prefix, secret_hash, scopes, created_at, last_used_at, revoked_at FROM %s WHERE id = ? LIMIT 1", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT id, name, prefix, secret_hash, scopes, created_at, last_used_at, revoked_at FROM %s WHERE prefix = ? LIMIT 1", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("UPDATE %s SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("UPDATE %s SET last_used_at = ? WHERE id = ?", tableName)))
+	return db, mock
+}
+
+func TestNewApiKeyDBRepository(t *testing.T) {
+	tableName := "api_keys"
+	db, mock := InitApiKeyTestDB(tableName)
+	defer db.Close()
+	NewApiKeyDBRepository(db, tableName)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("NewApiKeyDBRepository() prepared query = %v", err)
+	}
+}
+
+func TestApiKeyDBRepository_InsertApiKey(t *testing.T) {
+	tableName := "api_keys"
+	db, mock := InitApiKeyTestDB(tableName)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_keys (name, prefix, secret_hash, scopes, created_at) VALUES (?, ?, ?, ?, ?)")).WithArgs(
+		"partner",
+		"abcd1234",
+		"hash",
+		"cakes:read",
+		sqlmock.AnyArg(),
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+	repo := NewApiKeyDBRepository(db, tableName)
+	got, err := repo.InsertApiKey(context.TODO(), model.ApiKeyPayloadQuery{
+		Name:       "partner",
+		Prefix:     "abcd1234",
+		SecretHash: "hash",
+		Scopes:     "cakes:read",
+	})
+	if err != nil {
+		t.Errorf("ApiKeyDBRepository.InsertApiKey() error = %v", err)
+		return
+	}
+	if got != 1 {
+		t.Errorf("ApiKeyDBRepository.InsertApiKey() = %v, want %v", got, 1)
+	}
+}
+
+func TestApiKeyDBRepository_GetApiKeys(t *testing.T) {
+	tableName := "api_keys"
+	db, mock := InitApiKeyTestDB(tableName)
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "name", "prefix", "secret_hash", "scopes", "created_at", "last_used_at", "revoked_at"})
+	rows.AddRow(1, "partner", "abcd1234", "hash", "cakes:read", timeMock, nil, nil)
+	rows.AddRow(2, "old partner", "efgh5678", "hash", "cakes:read cakes:write", timeMock, timeMock, timeMock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, prefix, secret_hash, scopes, created_at, last_used_at, revoked_at FROM api_keys ORDER BY id")).WillReturnRows(rows)
+	repo := NewApiKeyDBRepository(db, tableName)
+	want := []model.ApiKey{
+		{ID: 1, Name: "partner", Prefix: "abcd1234", SecretHash: "hash", Scopes: "cakes:read", CreatedAt: timeMock},
+		{ID: 2, Name: "old partner", Prefix: "efgh5678", SecretHash: "hash", Scopes: "cakes:read cakes:write", CreatedAt: timeMock, LastUsedAt: &timeMock, RevokedAt: &timeMock},
+	}
+	got, err := repo.GetApiKeys(context.TODO())
+	if err != nil {
+		t.Errorf("ApiKeyDBRepository.GetApiKeys() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ApiKeyDBRepository.GetApiKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestApiKeyDBRepository_GetApiKeyByPrefix(t *testing.T) {
+	tableName := "api_keys"
+	db, mock := InitApiKeyTestDB(tableName)
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "name", "prefix", "secret_hash", "scopes", "created_at", "last_used_at", "revoked_at"})
+	rows.AddRow(1, "partner", "abcd1234", "hash", "cakes:read", timeMock, nil, nil)
+	query := regexp.QuoteMeta("SELECT id, name, prefix, secret_hash, scopes, created_at, last_used_at, revoked_at FROM api_keys WHERE prefix = ? LIMIT 1")
+	mock.ExpectQuery(query).WithArgs("abcd1234").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("unknown").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "secret_hash", "scopes", "created_at", "last_used_at", "revoked_at"}))
+	repo := NewApiKeyDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		prefix  string
+		want    *model.ApiKey
+		wantErr bool
+	}{
+		{
+			name:   "basic test",
+			prefix: "abcd1234",
+			want:   &model.ApiKey{ID: 1, Name: "partner", Prefix: "abcd1234", SecretHash: "hash", Scopes: "cakes:read", CreatedAt: timeMock},
+		},
+		{
+			name:   "not found",
+			prefix: "unknown",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetApiKeyByPrefix(context.TODO(), tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ApiKeyDBRepository.GetApiKeyByPrefix() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApiKeyDBRepository.GetApiKeyByPrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApiKeyDBRepository_RevokeApiKey(t *testing.T) {
+	tableName := "api_keys"
+	db, mock := InitApiKeyTestDB(tableName)
+	revokedAt := time.Date(2023, 1, 2, 15, 4, 5, 0, time.FixedZone("WIB", 7*60*60))
+	query := regexp.QuoteMeta("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL")
+	mock.ExpectExec(query).WithArgs(revokedAt.UTC(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(revokedAt.UTC(), 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	repo := NewApiKeyDBRepository(db, tableName)
+	tests := []struct {
+		name string
+		id   int
+		want bool
+	}{
+		{
+			name: "basic test",
+			id:   1,
+			want: true,
+		},
+		{
+			name: "already revoked",
+			id:   2,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.RevokeApiKey(context.TODO(), tt.id, revokedAt)
+			if err != nil {
+				t.Errorf("ApiKeyDBRepository.RevokeApiKey() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ApiKeyDBRepository.RevokeApiKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApiKeyDBRepository_TouchApiKey(t *testing.T) {
+	tableName := "api_keys"
+	db, mock := InitApiKeyTestDB(tableName)
+	usedAt := time.Date(2023, 1, 2, 8, 4, 5, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE api_keys SET last_used_at = ? WHERE id = ?")).WithArgs(usedAt, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	repo := NewApiKeyDBRepository(db, tableName)
+	if err := repo.TouchApiKey(context.TODO(), 1, usedAt); err != nil {
+		t.Errorf("ApiKeyDBRepository.TouchApiKey() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("ApiKeyDBRepository.TouchApiKey() query = %v", err)
+	}
+}
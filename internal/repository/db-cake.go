@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/forderation/ralali-test/internal/model"
@@ -11,16 +13,46 @@ import (
 )
 
 const (
-	COUNT_CAKES_STMT int = iota
-	GET_CAKES_STMT
-	GET_CAKE_STMT
+	GET_CAKE_STMT int = iota
 	INSERT_CAKE_STMT
 	UPDATE_CAKE_STMT
 	SOFT_DELETE_CAKE_STMT
+	GET_DELETED_CAKES_STMT
+	COUNT_DELETED_CAKES_STMT
+	GET_CAKE_WITH_DELETED_STMT
+	RESTORE_CAKE_STMT
+	PURGE_DELETED_CAKES_STMT
+	COUNT_PURGEABLE_CAKES_STMT
 )
 
+const (
+	cakeColumns      = "id, title, description, rating, image, created_at, updated_at, deleted_at, version"
+	defaultCakesSort = "rating DESC, title ASC"
+)
+
+// ErrInvalidSortField: returned when requested sort field is not on cakeSortableColumns
+var ErrInvalidSortField = errors.New("invalid sort field")
+
+// cakeSortableColumns: allow-list of sort field that can be requested by client mapped to its column
+var cakeSortableColumns = map[string]string{
+	"id":         "id",
+	"title":      "title",
+	"rating":     "rating",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// sqlExecutor: query method shared by *sql.DB and *sql.Tx
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
 type CakeDBRepository struct {
-	db            *sql.DB
+	db *sql.DB
+	// tx: filled when repository is bound to transaction by RunInTx
+	tx            *sql.Tx
+	tableName     string
 	queryPrepared map[int]*sql.Stmt
 }
 
@@ -29,64 +61,279 @@ func NewCakeDBRepository(db *sql.DB, tableName string) CakeDBInterface {
 		logrus.Panic("db param for NewCakeDBRepository is nil")
 	}
 	queryPrepared := make(map[int]*sql.Stmt, 0)
-	sqlStmtGetCakes, err := db.Prepare(fmt.Sprintf("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at FROM %s WHERE deleted_at IS NULL ORDER BY rating DESC, title ASC LIMIT ? OFFSET ?", tableName))
-	if err != nil {
-		logrus.Panic("error execute prepared statement sqlStmtGetCakes : ", err)
-	}
 	sqlStmtInsertCake, err := db.Prepare(fmt.Sprintf("INSERT INTO %s (title, description, rating, image, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)", tableName))
 	if err != nil {
 		logrus.Panic("error execute prepared statement sqlStmtInsertCake : ", err)
 	}
-	sqlStmtUpdateCake, err := db.Prepare(fmt.Sprintf("UPDATE %s SET title = ?, description = ?, rating = ?, image = ?, updated_at = ? WHERE id = ?", tableName))
+	sqlStmtUpdateCake, err := db.Prepare(fmt.Sprintf("UPDATE %s SET title = ?, description = ?, rating = ?, image = ?, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)", tableName))
 	if err != nil {
 		logrus.Panic("error execute prepared statement sqlStmtUpdateCake : ", err)
 	}
-	sqlStmtSoftDeleteCake, err := db.Prepare(fmt.Sprintf("UPDATE %s SET deleted_at = ? WHERE id = ?", tableName))
+	sqlStmtSoftDeleteCake, err := db.Prepare(fmt.Sprintf("UPDATE %s SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)", tableName))
 	if err != nil {
 		logrus.Panic("error execute prepared statement sqlStmtSoftDeleteCake : ", err)
 	}
-	sqlStmtCountCakes, err := db.Prepare(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE deleted_at IS NULL", tableName))
+	sqlStmtGetCake, err := db.Prepare(fmt.Sprintf("SELECT %s FROM %s WHERE deleted_at IS NULL AND id = ? LIMIT 1", cakeColumns, tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtGetCake : ", err)
+	}
+	sqlStmtGetDeletedCakes, err := db.Prepare(fmt.Sprintf("SELECT %s FROM %s WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC LIMIT ? OFFSET ?", cakeColumns, tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtGetDeletedCakes : ", err)
+	}
+	sqlStmtCountDeletedCakes, err := db.Prepare(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE deleted_at IS NOT NULL", tableName))
 	if err != nil {
-		logrus.Panic("error execute prepared statement sqlStmtCountCakes : ", err)
+		logrus.Panic("error execute prepared statement sqlStmtCountDeletedCakes : ", err)
 	}
-	sqlStmtGetCake, err := db.Prepare(fmt.Sprintf("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at FROM %s WHERE deleted_at IS NULL AND id = ? LIMIT 1", tableName))
+	sqlStmtGetCakeWithDeleted, err := db.Prepare(fmt.Sprintf("SELECT %s FROM %s WHERE id = ? LIMIT 1", cakeColumns, tableName))
 	if err != nil {
-		logrus.Panic("error execute prepared statement sqlStmtGetCakes : ", err)
+		logrus.Panic("error execute prepared statement sqlStmtGetCakeWithDeleted : ", err)
+	}
+	sqlStmtRestoreCake, err := db.Prepare(fmt.Sprintf("UPDATE %s SET deleted_at = NULL, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL", tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtRestoreCake : ", err)
+	}
+	sqlStmtPurgeDeletedCakes, err := db.Prepare(fmt.Sprintf("DELETE FROM %s WHERE deleted_at IS NOT NULL AND deleted_at < ? LIMIT ?", tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtPurgeDeletedCakes : ", err)
+	}
+	sqlStmtCountPurgeableCakes, err := db.Prepare(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE deleted_at IS NOT NULL AND deleted_at < ?", tableName))
+	if err != nil {
+		logrus.Panic("error execute prepared statement sqlStmtCountPurgeableCakes : ", err)
 	}
 	queryPrepared[GET_CAKE_STMT] = sqlStmtGetCake
-	queryPrepared[GET_CAKES_STMT] = sqlStmtGetCakes
 	queryPrepared[INSERT_CAKE_STMT] = sqlStmtInsertCake
 	queryPrepared[UPDATE_CAKE_STMT] = sqlStmtUpdateCake
-	queryPrepared[COUNT_CAKES_STMT] = sqlStmtCountCakes
 	queryPrepared[SOFT_DELETE_CAKE_STMT] = sqlStmtSoftDeleteCake
+	queryPrepared[GET_DELETED_CAKES_STMT] = sqlStmtGetDeletedCakes
+	queryPrepared[COUNT_DELETED_CAKES_STMT] = sqlStmtCountDeletedCakes
+	queryPrepared[GET_CAKE_WITH_DELETED_STMT] = sqlStmtGetCakeWithDeleted
+	queryPrepared[RESTORE_CAKE_STMT] = sqlStmtRestoreCake
+	queryPrepared[PURGE_DELETED_CAKES_STMT] = sqlStmtPurgeDeletedCakes
+	queryPrepared[COUNT_PURGEABLE_CAKES_STMT] = sqlStmtCountPurgeableCakes
 	return &CakeDBRepository{
 		db:            db,
+		tableName:     tableName,
 		queryPrepared: queryPrepared,
 	}
 }
 
+func (repo *CakeDBRepository) RunInTx(ctx context.Context, fn func(txRepo CakeDBInterface) error) (err error) {
+	if repo.tx != nil {
+		// already on transaction, join it
+		return fn(repo)
+	}
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+	err = fn(&CakeDBRepository{
+		db:            repo.db,
+		tx:            tx,
+		tableName:     repo.tableName,
+		queryPrepared: repo.queryPrepared,
+	})
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			logrus.Error("error rollback transaction: ", errRollback)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// executor: transaction when repository is bound to one, otherwise db
+func (repo *CakeDBRepository) executor() sqlExecutor {
+	if repo.tx != nil {
+		return repo.tx
+	}
+	return repo.db
+}
+
+// stmt: prepared statement by key, bound to transaction when repository is bound to one
+func (repo *CakeDBRepository) stmt(ctx context.Context, key int) *sql.Stmt {
+	stmt := repo.queryPrepared[key]
+	if repo.tx != nil {
+		return repo.tx.StmtContext(ctx, stmt)
+	}
+	return stmt
+}
+
+// likeEscaper: escape wildcard character of LIKE pattern from user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// buildCakesFilter: build where clause and its arguments from filter, soft deleted record always excluded
+func buildCakesFilter(filter model.CakesFilterQuery) (string, []interface{}) {
+	conditions, args := buildCakesFilterConditions(filter)
+	return strings.Join(append([]string{"deleted_at IS NULL"}, conditions...), " AND "), args
+}
+
+// buildCakesFilterConditions: conditions of filter and their arguments, soft deleted record is not excluded
+func buildCakesFilterConditions(filter model.CakesFilterQuery) ([]string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.Keyword != "" {
+		keyword := "%" + likeEscaper.Replace(filter.Keyword) + "%"
+		conditions = append(conditions, "(title LIKE ? OR description LIKE ?)")
+		args = append(args, keyword, keyword)
+	}
+	if filter.MinRating != nil {
+		conditions = append(conditions, "rating >= ?")
+		args = append(args, *filter.MinRating)
+	}
+	if filter.MaxRating != nil {
+		conditions = append(conditions, "rating <= ?")
+		args = append(args, *filter.MaxRating)
+	}
+	if filter.HasImage != nil {
+		if *filter.HasImage {
+			conditions = append(conditions, "(image IS NOT NULL AND image <> '')")
+		} else {
+			conditions = append(conditions, "(image IS NULL OR image = '')")
+		}
+	}
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.CreatedAfter.UTC())
+	}
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, filter.CreatedBefore.UTC())
+	}
+	return conditions, args
+}
+
+// buildCakesOrderBy: build order by clause from sort param, every field must be listed on cakeSortableColumns
+func buildCakesOrderBy(sort string) (string, error) {
+	sort = strings.TrimSpace(sort)
+	if sort == "" {
+		return defaultCakesSort, nil
+	}
+	fields := strings.Split(sort, ",")
+	orders := make([]string, 0, len(fields))
+	used := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		direction := "ASC"
+		if strings.HasPrefix(field, "-") {
+			direction = "DESC"
+			field = strings.TrimPrefix(field, "-")
+		} else {
+			field = strings.TrimPrefix(field, "+")
+		}
+		column, ok := cakeSortableColumns[field]
+		if !ok || used[column] {
+			return "", fmt.Errorf("%w '%s'", ErrInvalidSortField, field)
+		}
+		used[column] = true
+		orders = append(orders, column+" "+direction)
+	}
+	return strings.Join(orders, ", "), nil
+}
+
 func (repo *CakeDBRepository) GetCakes(ctx context.Context, param model.GetCakesQuery) ([]model.Cake, error) {
-	stmt := repo.queryPrepared[GET_CAKES_STMT]
-	rows, err := stmt.QueryContext(ctx, param.Limit, param.Offset)
+	orderBy, err := buildCakesOrderBy(param.Sort)
+	if err != nil {
+		return nil, err
+	}
+	where, args := buildCakesFilter(param.Filter)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT ? OFFSET ?", cakeColumns, repo.tableName, where, orderBy)
+	args = append(args, param.Limit, param.Offset)
+	rows, err := repo.executor().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCakeRows(rows)
+}
+
+func (repo *CakeDBRepository) GetCakesSeek(ctx context.Context, param model.GetCakesSeekQuery) ([]model.Cake, error) {
+	where, args := buildCakesFilter(param.Filter)
+	orderBy := "rating DESC, title ASC, id ASC"
+	if param.Backward {
+		orderBy = "rating ASC, title DESC, id DESC"
+	}
+	if param.After != nil {
+		if param.Backward {
+			where += " AND (rating > ? OR (rating = ? AND (title < ? OR (title = ? AND id < ?))))"
+		} else {
+			where += " AND (rating < ? OR (rating = ? AND (title > ? OR (title = ? AND id > ?))))"
+		}
+		args = append(args, param.After.Rating, param.After.Rating, param.After.Title, param.After.Title, param.After.ID)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT ?", cakeColumns, repo.tableName, where, orderBy)
+	args = append(args, param.Limit)
+	rows, err := repo.executor().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	result, err := scanCakeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if param.Backward {
+		// keep result on default order regardless seek direction
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+	return result, nil
+}
+
+func (repo *CakeDBRepository) StreamCakes(ctx context.Context, param model.StreamCakesQuery, fn func(cake model.Cake) error) error {
+	orderBy, err := buildCakesOrderBy(param.Sort)
+	if err != nil {
+		return err
+	}
+	where, args := buildCakesFilter(param.Filter)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s", cakeColumns, repo.tableName, where, orderBy)
+	rows, err := repo.executor().QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var cake model.Cake
+		err := scanCake(rows, &cake)
+		if err != nil {
+			return err
+		}
+		err = fn(cake)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func scanCakeRows(rows *sql.Rows) ([]model.Cake, error) {
 	result := []model.Cake{}
 	for rows.Next() {
 		var cake model.Cake
-		err := rows.Scan(&cake.ID, &cake.Title, &cake.Description, &cake.Rating, &cake.Image, &cake.CreatedAt, &cake.UpdatedAt, &cake.DeletedAt)
+		err := scanCake(rows, &cake)
 		if err != nil {
 			return nil, err
 		}
 		result = append(result, cake)
 	}
-	return result, nil
+	return result, rows.Err()
+}
+
+func scanCake(rows *sql.Rows, cake *model.Cake) error {
+	return rows.Scan(&cake.ID, &cake.Title, &cake.Description, &cake.Rating, &cake.Image, &cake.CreatedAt, &cake.UpdatedAt, &cake.DeletedAt, &cake.Version)
 }
 
-func (repo *CakeDBRepository) CountCakes(ctx context.Context) (int64, error) {
-	stmt := repo.queryPrepared[COUNT_CAKES_STMT]
-	rows, err := stmt.QueryContext(ctx)
+func (repo *CakeDBRepository) CountCakes(ctx context.Context, filter model.CakesFilterQuery) (int64, error) {
+	where, args := buildCakesFilter(filter)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", repo.tableName, where)
+	rows, err := repo.executor().QueryContext(ctx, query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -101,21 +348,42 @@ func (repo *CakeDBRepository) CountCakes(ctx context.Context) (int64, error) {
 	return result, nil
 }
 
-func (repo *CakeDBRepository) GetCake(ctx context.Context, id int) (*model.Cake, error) {
-	stmt := repo.queryPrepared[GET_CAKE_STMT]
-	rows, err := stmt.QueryContext(ctx, id)
+func (repo *CakeDBRepository) GetCakesModified(ctx context.Context, filter model.CakesFilterQuery) (model.CakesModified, error) {
+	conditions, args := buildCakesFilterConditions(filter)
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	// soft deleted record is scanned too so deleting a cake moves the modified time by its deleted_at
+	query := fmt.Sprintf("SELECT COUNT(CASE WHEN deleted_at IS NULL THEN 1 END), COALESCE(SUM(CASE WHEN deleted_at IS NULL THEN version END), 0), "+
+		"MAX(COALESCE(deleted_at, updated_at)) FROM %s%s", repo.tableName, where)
+	rows, err := repo.executor().QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return model.CakesModified{}, err
 	}
 	defer rows.Close()
-	result := []model.Cake{}
+	var result model.CakesModified
+	var modifiedAt sql.NullTime
 	for rows.Next() {
-		var cake model.Cake
-		err := rows.Scan(&cake.ID, &cake.Title, &cake.Description, &cake.Rating, &cake.Image, &cake.CreatedAt, &cake.UpdatedAt, &cake.DeletedAt)
+		err := rows.Scan(&result.Count, &result.VersionSum, &modifiedAt)
 		if err != nil {
-			return nil, err
+			return model.CakesModified{}, err
 		}
-		result = append(result, cake)
+	}
+	result.ModifiedAt = modifiedAt.Time
+	return result, rows.Err()
+}
+
+func (repo *CakeDBRepository) GetCake(ctx context.Context, id int) (*model.Cake, error) {
+	stmt := repo.stmt(ctx, GET_CAKE_STMT)
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result, err := scanCakeRows(rows)
+	if err != nil {
+		return nil, err
 	}
 	if len(result) > 0 {
 		return &result[0], nil
@@ -123,23 +391,145 @@ func (repo *CakeDBRepository) GetCake(ctx context.Context, id int) (*model.Cake,
 	return nil, nil
 }
 
-func (repo *CakeDBRepository) InsertCake(ctx context.Context, param model.CakePayloadQuery) error {
-	stmt := repo.queryPrepared[INSERT_CAKE_STMT]
+func (repo *CakeDBRepository) GetDeletedCakes(ctx context.Context, param model.GetDeletedCakesQuery) ([]model.Cake, error) {
+	stmt := repo.stmt(ctx, GET_DELETED_CAKES_STMT)
+	rows, err := stmt.QueryContext(ctx, param.Limit, param.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCakeRows(rows)
+}
+
+func (repo *CakeDBRepository) CountDeletedCakes(ctx context.Context) (int64, error) {
+	stmt := repo.stmt(ctx, COUNT_DELETED_CAKES_STMT)
+	var result int64
+	err := stmt.QueryRowContext(ctx).Scan(&result)
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+func (repo *CakeDBRepository) GetCakeWithDeleted(ctx context.Context, id int) (*model.Cake, error) {
+	stmt := repo.stmt(ctx, GET_CAKE_WITH_DELETED_STMT)
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result, err := scanCakeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) > 0 {
+		return &result[0], nil
+	}
+	return nil, nil
+}
+
+func (repo *CakeDBRepository) RestoreCake(ctx context.Context, id int) (bool, error) {
+	stmt := repo.stmt(ctx, RESTORE_CAKE_STMT)
+	timeRestored := time.Now().UTC()
+	result, err := stmt.ExecContext(ctx, timeRestored, id)
+	return isRowAffected(result, err)
+}
+
+func (repo *CakeDBRepository) InsertCake(ctx context.Context, param model.CakePayloadQuery) (int64, error) {
+	stmt := repo.stmt(ctx, INSERT_CAKE_STMT)
+	timeCreated := time.Now().UTC()
+	result, err := stmt.ExecContext(ctx, param.Title, param.Description, param.Rating, param.Image, timeCreated, timeCreated)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *CakeDBRepository) InsertCakes(ctx context.Context, params []model.CakePayloadQuery) (int64, error) {
+	if len(params) == 0 {
+		return 0, nil
+	}
 	timeCreated := time.Now().UTC()
-	_, err := stmt.ExecContext(ctx, param.Title, param.Description, param.Rating, param.Image, timeCreated, timeCreated)
-	return err
+	values := make([]string, 0, len(params))
+	args := make([]interface{}, 0, len(params)*6)
+	for _, param := range params {
+		values = append(values, "(?, ?, ?, ?, ?, ?)")
+		args = append(args, param.Title, param.Description, param.Rating, param.Image, timeCreated, timeCreated)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (title, description, rating, image, created_at, updated_at) VALUES %s", repo.tableName, strings.Join(values, ", "))
+	result, err := repo.executor().ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-func (repo *CakeDBRepository) UpdateCake(ctx context.Context, id int, param model.CakePayloadQuery) error {
-	stmt := repo.queryPrepared[UPDATE_CAKE_STMT]
+func (repo *CakeDBRepository) UpdateCake(ctx context.Context, id int, version int, param model.CakePayloadQuery) (bool, error) {
+	stmt := repo.stmt(ctx, UPDATE_CAKE_STMT)
 	timeUpdated := time.Now().UTC()
-	_, err := stmt.ExecContext(ctx, param.Title, param.Description, param.Rating, param.Image, timeUpdated, id)
-	return err
+	result, err := stmt.ExecContext(ctx, param.Title, param.Description, param.Rating, param.Image, timeUpdated, id, version, version)
+	return isRowAffected(result, err)
 }
 
-func (repo *CakeDBRepository) SoftDeleteCake(ctx context.Context, id int) error {
-	stmt := repo.queryPrepared[SOFT_DELETE_CAKE_STMT]
-	timeDeleted := time.Now().UTC()
-	_, err := stmt.ExecContext(ctx, timeDeleted, id)
-	return err
+func (repo *CakeDBRepository) PatchCake(ctx context.Context, id int, version int, param model.CakePatchQuery) (bool, error) {
+	sets := make([]string, 0)
+	args := make([]interface{}, 0)
+	if param.Title.Set {
+		sets = append(sets, "title = ?")
+		args = append(args, param.Title.Value)
+	}
+	if param.Description.Set {
+		sets = append(sets, "description = ?")
+		args = append(args, param.Description.Ptr())
+	}
+	if param.Rating.Set {
+		sets = append(sets, "rating = ?")
+		args = append(args, param.Rating.Value)
+	}
+	if param.Image.Set {
+		sets = append(sets, "image = ?")
+		args = append(args, param.Image.Ptr())
+	}
+	sets = append(sets, "updated_at = ?", "version = version + 1")
+	args = append(args, time.Now().UTC(), id, version, version)
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)", repo.tableName, strings.Join(sets, ", "))
+	result, err := repo.executor().ExecContext(ctx, query, args...)
+	return isRowAffected(result, err)
+}
+
+func (repo *CakeDBRepository) SoftDeleteCake(ctx context.Context, id int, version int, deletedAt time.Time) (bool, error) {
+	stmt := repo.stmt(ctx, SOFT_DELETE_CAKE_STMT)
+	result, err := stmt.ExecContext(ctx, deletedAt.UTC(), id, version, version)
+	return isRowAffected(result, err)
+}
+
+func (repo *CakeDBRepository) PurgeDeletedCakes(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	stmt := repo.stmt(ctx, PURGE_DELETED_CAKES_STMT)
+	result, err := stmt.ExecContext(ctx, deletedBefore.UTC(), limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (repo *CakeDBRepository) CountPurgeableCakes(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	stmt := repo.stmt(ctx, COUNT_PURGEABLE_CAKES_STMT)
+	var result int64
+	err := stmt.QueryRowContext(ctx, deletedBefore.UTC()).Scan(&result)
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// isRowAffected: conditional update result, false when no record match the condition
+func isRowAffected(result sql.Result, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
@@ -47,12 +48,16 @@ func InitTestDB(tableName string) (*sql.DB, sqlmock.Sqlmock) {
 	if err != nil {
 		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
-	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at FROM %s WHERE deleted_at IS NULL ORDER BY rating DESC, title ASC LIMIT ? OFFSET ?", tableName)))
 	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("INSERT INTO %s (title, description, rating, image, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)", tableName)))
-	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("UPDATE %s SET title = ?, description = ?, rating = ?, image = ?, updated_at = ? WHERE id = ?", tableName)))
-	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("UPDATE %s SET deleted_at = ? WHERE id = ?", tableName)))
-	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE deleted_at IS NULL", tableName)))
-	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at FROM %s WHERE deleted_at IS NULL AND id = ? LIMIT 1", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("UPDATE %s SET title = ?, description = ?, rating = ?, image = ?, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("UPDATE %s SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM %s WHERE deleted_at IS NULL AND id = ? LIMIT 1", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM %s WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC LIMIT ? OFFSET ?", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE deleted_at IS NOT NULL", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM %s WHERE id = ? LIMIT 1", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("UPDATE %s SET deleted_at = NULL, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("DELETE FROM %s WHERE deleted_at IS NOT NULL AND deleted_at < ? LIMIT ?", tableName)))
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE deleted_at IS NOT NULL AND deleted_at < ?", tableName)))
 	return db, mock
 }
 
@@ -63,9 +68,20 @@ func TestCakeDBRepository_GetCakes(t *testing.T) {
 	if err != nil {
 		log.Fatalf("an error '%s' was not expected", err)
 	}
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"})
-	rows.AddRow(1, "title", "desc", float32(4.5), "image", timeMock, timeMock, nil)
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at FROM cakes WHERE deleted_at IS NULL ORDER BY rating DESC, title ASC LIMIT ? OFFSET ?")).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(rows)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	rows.AddRow(1, "title", "desc", float32(4.5), "image", timeMock, timeMock, nil, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE deleted_at IS NULL ORDER BY rating DESC, title ASC LIMIT ? OFFSET ?")).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(rows)
+	minRating := float32(4)
+	filteredRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	filteredRows.AddRow(1, "title", "desc", float32(4.5), "image", timeMock, timeMock, nil, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE deleted_at IS NULL AND (title LIKE ? OR description LIKE ?) AND rating >= ? AND (image IS NOT NULL AND image <> '') AND created_at >= ? ORDER BY rating DESC, title ASC LIMIT ? OFFSET ?")).WithArgs(
+		"%50\\%%",
+		"%50\\%%",
+		float32(4),
+		timeMock,
+		10,
+		0,
+	).WillReturnRows(filteredRows)
 	repo := NewCakeDBRepository(db, tableName)
 	type args struct {
 		ctx   context.Context
@@ -96,6 +112,37 @@ func TestCakeDBRepository_GetCakes(t *testing.T) {
 					CreatedAt:   timeMock,
 					UpdatedAt:   timeMock,
 					DeletedAt:   nil,
+					Version:     1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "get cakes with filter",
+			args: args{
+				ctx: context.TODO(),
+				param: model.GetCakesQuery{
+					Limit:  10,
+					Offset: 0,
+					Filter: model.CakesFilterQuery{
+						Keyword:      "50%",
+						MinRating:    &minRating,
+						HasImage:     null.BoolFrom(true).Ptr(),
+						CreatedAfter: &timeMock,
+					},
+				},
+			},
+			want: []model.Cake{
+				{
+					ID:          1,
+					Title:       "title",
+					Description: null.StringFrom("desc").Ptr(),
+					Rating:      4.5,
+					Image:       null.StringFrom("image").Ptr(),
+					CreatedAt:   timeMock,
+					UpdatedAt:   timeMock,
+					DeletedAt:   nil,
+					Version:     1,
 				},
 			},
 			wantErr: false,
@@ -121,9 +168,14 @@ func TestCakeDBRepository_CountCakes(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"count"})
 	rows.AddRow(1)
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM cakes WHERE deleted_at IS NULL")).WillReturnRows(rows)
+	maxRating := float32(3)
+	filteredRows := sqlmock.NewRows([]string{"count"})
+	filteredRows.AddRow(2)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM cakes WHERE deleted_at IS NULL AND rating <= ? AND (image IS NULL OR image = '')")).WithArgs(maxRating).WillReturnRows(filteredRows)
 	repo := NewCakeDBRepository(db, tableName)
 	type args struct {
-		ctx context.Context
+		ctx    context.Context
+		filter model.CakesFilterQuery
 	}
 	tests := []struct {
 		name    string
@@ -139,10 +191,22 @@ func TestCakeDBRepository_CountCakes(t *testing.T) {
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "count with filter",
+			args: args{
+				ctx: context.TODO(),
+				filter: model.CakesFilterQuery{
+					MaxRating: &maxRating,
+					HasImage:  null.BoolFrom(false).Ptr(),
+				},
+			},
+			want:    2,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repo.CountCakes(tt.args.ctx)
+			got, err := repo.CountCakes(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CakeDBRepository.CountCakes() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -154,6 +218,54 @@ func TestCakeDBRepository_CountCakes(t *testing.T) {
 	}
 }
 
+func TestCakeDBRepository_GetCakesModified(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	timeMock := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	selectColumns := "SELECT COUNT(CASE WHEN deleted_at IS NULL THEN 1 END), COALESCE(SUM(CASE WHEN deleted_at IS NULL THEN version END), 0), MAX(COALESCE(deleted_at, updated_at)) FROM cakes"
+	rows := sqlmock.NewRows([]string{"count", "version_sum", "modified_at"})
+	rows.AddRow(2, 5, timeMock)
+	mock.ExpectQuery(regexp.QuoteMeta(selectColumns)).WillReturnRows(rows)
+	maxRating := float32(3)
+	filteredRows := sqlmock.NewRows([]string{"count", "version_sum", "modified_at"})
+	filteredRows.AddRow(0, 0, nil)
+	mock.ExpectQuery(regexp.QuoteMeta(selectColumns + " WHERE rating <= ?")).WithArgs(maxRating).WillReturnRows(filteredRows)
+	mock.ExpectQuery(regexp.QuoteMeta(selectColumns)).WillReturnError(errors.New("error mock"))
+	repo := NewCakeDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		filter  model.CakesFilterQuery
+		want    model.CakesModified
+		wantErr bool
+	}{
+		{
+			name: "basic test",
+			want: model.CakesModified{Count: 2, VersionSum: 5, ModifiedAt: timeMock},
+		},
+		{
+			name:   "no record match filter",
+			filter: model.CakesFilterQuery{MaxRating: &maxRating},
+			want:   model.CakesModified{},
+		},
+		{
+			name:    "error test",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetCakesModified(context.TODO(), tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.GetCakesModified() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeDBRepository.GetCakesModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCakeDBRepository_GetCake(t *testing.T) {
 	tableName := "cakes"
 	db, mock := InitTestDB(tableName)
@@ -161,9 +273,9 @@ func TestCakeDBRepository_GetCake(t *testing.T) {
 	if err != nil {
 		log.Fatalf("an error '%s' was not expected", err)
 	}
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"})
-	rows.AddRow(1, "title", "desc", float32(4.5), "image", timeMock, timeMock, nil)
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at FROM cakes WHERE deleted_at IS NULL AND id = ? LIMIT 1")).WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	rows.AddRow(1, "title", "desc", float32(4.5), "image", timeMock, timeMock, nil, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE deleted_at IS NULL AND id = ? LIMIT 1")).WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
 	repo := NewCakeDBRepository(db, tableName)
 	type args struct {
 		ctx context.Context
@@ -190,6 +302,7 @@ func TestCakeDBRepository_GetCake(t *testing.T) {
 				CreatedAt:   timeMock,
 				UpdatedAt:   timeMock,
 				DeletedAt:   nil,
+				Version:     1,
 			},
 			wantErr: false,
 		},
@@ -227,6 +340,7 @@ func TestCakeDBRepository_InsertCake(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
+		want    int64
 		wantErr bool
 	}{
 		{
@@ -235,13 +349,19 @@ func TestCakeDBRepository_InsertCake(t *testing.T) {
 				ctx:   context.TODO(),
 				param: model.CakePayloadQuery{},
 			},
+			want:    1,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := repo.InsertCake(tt.args.ctx, tt.args.param); (err != nil) != tt.wantErr {
+			got, err := repo.InsertCake(tt.args.ctx, tt.args.param)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("CakeDBRepository.InsertCake() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CakeDBRepository.InsertCake() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -250,39 +370,71 @@ func TestCakeDBRepository_InsertCake(t *testing.T) {
 func TestCakeDBRepository_UpdateCake(t *testing.T) {
 	tableName := "cakes"
 	db, mock := InitTestDB(tableName)
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET title = ?, description = ?, rating = ?, image = ?, updated_at = ? WHERE id = ?")).WithArgs(
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET title = ?, description = ?, rating = ?, image = ?, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)")).WithArgs(
 		sqlmock.AnyArg(),
 		sqlmock.AnyArg(),
 		sqlmock.AnyArg(),
 		sqlmock.AnyArg(),
 		sqlmock.AnyArg(),
+		1,
+		2,
+		2,
+	).WillReturnResult(sqlmock.NewResult(0, 1)).WillReturnError(nil)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET title = ?, description = ?, rating = ?, image = ?, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)")).WithArgs(
 		sqlmock.AnyArg(),
-	).WillReturnResult(sqlmock.NewResult(1, 1)).WillReturnError(nil)
+		sqlmock.AnyArg(),
+		sqlmock.AnyArg(),
+		sqlmock.AnyArg(),
+		sqlmock.AnyArg(),
+		1,
+		1,
+		1,
+	).WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(nil)
 	repo := NewCakeDBRepository(db, tableName)
 	type args struct {
-		ctx   context.Context
-		id    int
-		param model.CakePayloadQuery
+		ctx     context.Context
+		id      int
+		version int
+		param   model.CakePayloadQuery
 	}
 	tests := []struct {
 		name    string
 		args    args
+		want    bool
 		wantErr bool
 	}{
 		{
 			name: "basic test",
 			args: args{
-				ctx:   context.TODO(),
-				id:    1,
-				param: model.CakePayloadQuery{},
+				ctx:     context.TODO(),
+				id:      1,
+				version: 2,
+				param:   model.CakePayloadQuery{},
 			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "version not match",
+			args: args{
+				ctx:     context.TODO(),
+				id:      1,
+				version: 1,
+				param:   model.CakePayloadQuery{},
+			},
+			want:    false,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := repo.UpdateCake(tt.args.ctx, tt.args.id, tt.args.param); (err != nil) != tt.wantErr {
+			got, err := repo.UpdateCake(tt.args.ctx, tt.args.id, tt.args.version, tt.args.param)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("CakeDBRepository.UpdateCake() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CakeDBRepository.UpdateCake() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -291,33 +443,690 @@ func TestCakeDBRepository_UpdateCake(t *testing.T) {
 func TestCakeDBRepository_SoftDeleteCake(t *testing.T) {
 	tableName := "cakes"
 	db, mock := InitTestDB(tableName)
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET deleted_at = ? WHERE id = ?")).WithArgs(
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)")).WithArgs(
 		sqlmock.AnyArg(),
+		1,
+		model.AnyVersion,
+		model.AnyVersion,
+	).WillReturnResult(sqlmock.NewResult(0, 1)).WillReturnError(nil)
+	repo := NewCakeDBRepository(db, tableName)
+	type args struct {
+		ctx     context.Context
+		id      int
+		version int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "basic test",
+			args: args{
+				ctx:     context.TODO(),
+				id:      1,
+				version: model.AnyVersion,
+			},
+			want:    true,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.SoftDeleteCake(tt.args.ctx, tt.args.id, tt.args.version, time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.SoftDeleteCake() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CakeDBRepository.SoftDeleteCake() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildCakesOrderBy(t *testing.T) {
+	type args struct {
+		sort string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default sort",
+			args: args{
+				sort: "",
+			},
+			want:    "rating DESC, title ASC",
+			wantErr: false,
+		},
+		{
+			name: "multiple field with direction",
+			args: args{
+				sort: "-created_at, title,+id",
+			},
+			want:    "created_at DESC, title ASC, id ASC",
+			wantErr: false,
+		},
+		{
+			name: "unknown field",
+			args: args{
+				sort: "title;DROP TABLE cakes",
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate field",
+			args: args{
+				sort: "title,-title",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty field",
+			args: args{
+				sort: "title,",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildCakesOrderBy(tt.args.sort)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("buildCakesOrderBy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidSortField) {
+				t.Errorf("buildCakesOrderBy() error = %v, want ErrInvalidSortField", err)
+			}
+			if got != tt.want {
+				t.Errorf("buildCakesOrderBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeDBRepository_GetCakesSeek(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	firstPageRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	firstPageRows.AddRow(1, "a", nil, float32(5), nil, timeMock, timeMock, nil, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE deleted_at IS NULL ORDER BY rating DESC, title ASC, id ASC LIMIT ?")).WithArgs(2).WillReturnRows(firstPageRows)
+	backwardRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	backwardRows.AddRow(3, "c", nil, float32(4), nil, timeMock, timeMock, nil, 1)
+	backwardRows.AddRow(2, "b", nil, float32(4), nil, timeMock, timeMock, nil, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE deleted_at IS NULL AND (rating > ? OR (rating = ? AND (title < ? OR (title = ? AND id < ?)))) ORDER BY rating ASC, title DESC, id DESC LIMIT ?")).WithArgs(
+		float32(3),
+		float32(3),
+		"d",
+		"d",
+		4,
+		2,
+	).WillReturnRows(backwardRows)
+	repo := NewCakeDBRepository(db, tableName)
+	type args struct {
+		ctx   context.Context
+		param model.GetCakesSeekQuery
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []model.Cake
+		wantErr bool
+	}{
+		{
+			name: "first page",
+			args: args{
+				ctx: context.TODO(),
+				param: model.GetCakesSeekQuery{
+					Limit: 2,
+				},
+			},
+			want: []model.Cake{
+				{ID: 1, Title: "a", Rating: 5, CreatedAt: timeMock, UpdatedAt: timeMock, Version: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "seek backward keep default order",
+			args: args{
+				ctx: context.TODO(),
+				param: model.GetCakesSeekQuery{
+					Limit: 2,
+					After: &model.CakeSortKey{
+						Rating: 3,
+						Title:  "d",
+						ID:     4,
+					},
+					Backward: true,
+				},
+			},
+			want: []model.Cake{
+				{ID: 2, Title: "b", Rating: 4, CreatedAt: timeMock, UpdatedAt: timeMock, Version: 1},
+				{ID: 3, Title: "c", Rating: 4, CreatedAt: timeMock, UpdatedAt: timeMock, Version: 1},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetCakesSeek(tt.args.ctx, tt.args.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.GetCakesSeek() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeDBRepository.GetCakesSeek() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeDBRepository_PatchCake(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET rating = ?, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)")).WithArgs(
+		float32(3),
 		sqlmock.AnyArg(),
-	).WillReturnResult(sqlmock.NewResult(1, 1)).WillReturnError(nil)
+		1,
+		1,
+		1,
+	).WillReturnResult(sqlmock.NewResult(0, 1)).WillReturnError(nil)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET title = ?, description = ?, image = ?, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)")).WithArgs(
+		"title",
+		nil,
+		"image",
+		sqlmock.AnyArg(),
+		2,
+		3,
+		3,
+	).WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(nil)
 	repo := NewCakeDBRepository(db, tableName)
 	type args struct {
-		ctx context.Context
-		id  int
+		ctx     context.Context
+		id      int
+		version int
+		param   model.CakePatchQuery
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "update single field",
+			args: args{
+				ctx:     context.TODO(),
+				id:      1,
+				version: 1,
+				param: model.CakePatchQuery{
+					Rating: model.NullableFieldFrom(float32(3)),
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "update and clear field with version not match",
+			args: args{
+				ctx:     context.TODO(),
+				id:      2,
+				version: 3,
+				param: model.CakePatchQuery{
+					Title:       model.NullableFieldFrom("title"),
+					Description: model.NullableField[string]{Set: true},
+					Image:       model.NullableFieldFrom("image"),
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.PatchCake(tt.args.ctx, tt.args.id, tt.args.version, tt.args.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.PatchCake() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CakeDBRepository.PatchCake() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCakeDBRepository_GetDeletedCakes(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	rows.AddRow(1, "title", nil, float32(4.5), nil, timeMock, timeMock, timeMock, 2)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC LIMIT ? OFFSET ?")).WithArgs(10, 0).WillReturnRows(rows)
+	repo := NewCakeDBRepository(db, tableName)
+	type args struct {
+		ctx   context.Context
+		param model.GetDeletedCakesQuery
 	}
 	tests := []struct {
 		name    string
 		args    args
+		want    []model.Cake
 		wantErr bool
 	}{
 		{
 			name: "basic test",
 			args: args{
 				ctx: context.TODO(),
-				id:  1,
+				param: model.GetDeletedCakesQuery{
+					Limit:  10,
+					Offset: 0,
+				},
+			},
+			want: []model.Cake{
+				{
+					ID:        1,
+					Title:     "title",
+					Rating:    4.5,
+					CreatedAt: timeMock,
+					UpdatedAt: timeMock,
+					DeletedAt: &timeMock,
+					Version:   2,
+				},
 			},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := repo.SoftDeleteCake(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
-				t.Errorf("CakeDBRepository.SoftDeleteCake() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := repo.GetDeletedCakes(tt.args.ctx, tt.args.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.GetDeletedCakes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeDBRepository.GetDeletedCakes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeDBRepository_CountDeletedCakes(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	rows := sqlmock.NewRows([]string{"count"})
+	rows.AddRow(3)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM cakes WHERE deleted_at IS NOT NULL")).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM cakes WHERE deleted_at IS NOT NULL")).WillReturnError(errors.New("error mock"))
+	repo := NewCakeDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "basic test",
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name:    "error test",
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.CountDeletedCakes(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.CountDeletedCakes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CakeDBRepository.CountDeletedCakes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeDBRepository_GetCakeWithDeleted(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	rows.AddRow(1, "title", nil, float32(4.5), nil, timeMock, timeMock, timeMock, 2)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE id = ? LIMIT 1")).WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE id = ? LIMIT 1")).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"}))
+	repo := NewCakeDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		id      int
+		want    *model.Cake
+		wantErr bool
+	}{
+		{
+			name: "soft deleted record",
+			id:   1,
+			want: &model.Cake{
+				ID:        1,
+				Title:     "title",
+				Rating:    4.5,
+				CreatedAt: timeMock,
+				UpdatedAt: timeMock,
+				DeletedAt: &timeMock,
+				Version:   2,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "record not found",
+			id:      2,
+			want:    nil,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetCakeWithDeleted(context.TODO(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.GetCakeWithDeleted() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeDBRepository.GetCakeWithDeleted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeDBRepository_RestoreCake(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET deleted_at = NULL, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL")).WithArgs(
+		sqlmock.AnyArg(),
+		1,
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET deleted_at = NULL, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL")).WithArgs(
+		sqlmock.AnyArg(),
+		2,
+	).WillReturnResult(sqlmock.NewResult(0, 0))
+	repo := NewCakeDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		id      int
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "basic test",
+			id:      1,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "record not deleted",
+			id:      2,
+			want:    false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.RestoreCake(context.TODO(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.RestoreCake() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CakeDBRepository.RestoreCake() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeDBRepository_PurgeDeletedCakes(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	deletedBefore := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cakes WHERE deleted_at IS NOT NULL AND deleted_at < ? LIMIT ?")).WithArgs(deletedBefore, 100).WillReturnResult(sqlmock.NewResult(0, 100))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cakes WHERE deleted_at IS NOT NULL AND deleted_at < ? LIMIT ?")).WithArgs(deletedBefore, 100).WillReturnError(errors.New("error mock"))
+	repo := NewCakeDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "basic test",
+			want:    100,
+			wantErr: false,
+		},
+		{
+			name:    "error test",
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.PurgeDeletedCakes(context.TODO(), deletedBefore, 100)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.PurgeDeletedCakes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CakeDBRepository.PurgeDeletedCakes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeDBRepository_CountPurgeableCakes(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	deletedBefore := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"count"})
+	rows.AddRow(7)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM cakes WHERE deleted_at IS NOT NULL AND deleted_at < ?")).WithArgs(deletedBefore).WillReturnRows(rows)
+	repo := NewCakeDBRepository(db, tableName)
+	got, err := repo.CountPurgeableCakes(context.TODO(), deletedBefore)
+	if err != nil {
+		t.Errorf("CakeDBRepository.CountPurgeableCakes() error = %v", err)
+		return
+	}
+	if got != 7 {
+		t.Errorf("CakeDBRepository.CountPurgeableCakes() = %v, want %v", got, 7)
+	}
+}
+
+func TestCakeDBRepository_RunInTx(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)")).WithArgs(
+		sqlmock.AnyArg(),
+		1,
+		model.AnyVersion,
+		model.AnyVersion,
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)")).WithArgs(
+		sqlmock.AnyArg(),
+		2,
+		model.AnyVersion,
+		model.AnyVersion,
+	).WillReturnError(errors.New("error mock"))
+	mock.ExpectRollback()
+	repo := NewCakeDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		id      int
+		wantErr bool
+	}{
+		{
+			name:    "commit when fn succeed",
+			id:      1,
+			wantErr: false,
+		},
+		{
+			name:    "rollback when fn failed",
+			id:      2,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.RunInTx(context.TODO(), func(txRepo CakeDBInterface) error {
+				_, err := txRepo.SoftDeleteCake(context.TODO(), tt.id, model.AnyVersion, time.Now())
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.RunInTx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCakeDBRepository_InsertCakes(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO cakes (title, description, rating, image, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)")).WithArgs(
+		"title",
+		nil,
+		float32(4.5),
+		nil,
+		sqlmock.AnyArg(),
+		sqlmock.AnyArg(),
+		"title 2",
+		null.StringFrom("description").Ptr(),
+		float32(3),
+		null.StringFrom("image").Ptr(),
+		sqlmock.AnyArg(),
+		sqlmock.AnyArg(),
+	).WillReturnResult(sqlmock.NewResult(1, 2))
+	repo := NewCakeDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		params  []model.CakePayloadQuery
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "basic test",
+			params: []model.CakePayloadQuery{
+				{Title: "title", Rating: 4.5},
+				{Title: "title 2", Description: null.StringFrom("description").Ptr(), Rating: 3, Image: null.StringFrom("image").Ptr()},
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "empty params",
+			params:  []model.CakePayloadQuery{},
+			want:    0,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.InsertCakes(context.TODO(), tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.InsertCakes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CakeDBRepository.InsertCakes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeDBRepository_StreamCakes(t *testing.T) {
+	tableName := "cakes"
+	db, mock := InitTestDB(tableName)
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	rows.AddRow(1, "title", nil, float32(4.5), nil, timeMock, timeMock, nil, 1)
+	rows.AddRow(2, "title 2", nil, float32(4), nil, timeMock, timeMock, nil, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE deleted_at IS NULL AND (title LIKE ? OR description LIKE ?) ORDER BY id DESC")).WithArgs("%cake%", "%cake%").WillReturnRows(rows)
+	stoppedRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "version"})
+	stoppedRows.AddRow(1, "title", nil, float32(4.5), nil, timeMock, timeMock, nil, 1)
+	stoppedRows.AddRow(2, "title 2", nil, float32(4), nil, timeMock, timeMock, nil, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, image, created_at, updated_at, deleted_at, version FROM cakes WHERE deleted_at IS NULL ORDER BY rating DESC, title ASC")).WillReturnRows(stoppedRows)
+	repo := NewCakeDBRepository(db, tableName)
+	tests := []struct {
+		name    string
+		param   model.StreamCakesQuery
+		stopAt  int
+		wantIDs []int
+		wantErr bool
+	}{
+		{
+			name: "stream every record",
+			param: model.StreamCakesQuery{
+				Filter: model.CakesFilterQuery{Keyword: "cake"},
+				Sort:   "-id",
+			},
+			wantIDs: []int{1, 2},
+		},
+		{
+			name:    "stop when fn return error",
+			param:   model.StreamCakesQuery{},
+			stopAt:  1,
+			wantIDs: []int{1},
+			wantErr: true,
+		},
+		{
+			name: "invalid sort field",
+			param: model.StreamCakesQuery{
+				Sort: "deleted_at",
+			},
+			wantIDs: []int{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []int{}
+			err := repo.StreamCakes(context.TODO(), tt.param, func(cake model.Cake) error {
+				ids = append(ids, cake.ID)
+				if len(ids) == tt.stopAt {
+					return errors.New("error mock")
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeDBRepository.StreamCakes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("CakeDBRepository.StreamCakes() ids = %v, want %v", ids, tt.wantIDs)
 			}
 		})
 	}
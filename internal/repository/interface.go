@@ -2,22 +2,72 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/forderation/ralali-test/internal/model"
 )
 
-//go:generate moq -out mock_interface.go . CakeDBInterface
+//go:generate moq -out mock_interface.go . CakeDBInterface ApiKeyDBInterface
 type CakeDBInterface interface {
-	// GetCakes: get all cake record with not soft delete, parameter with pagination
+	// RunInTx: run fn on single transaction, every method of txRepo is executed on that transaction.
+	// transaction is committed when fn return nil, otherwise rolled back and the error is returned
+	RunInTx(ctx context.Context, fn func(txRepo CakeDBInterface) error) error
+	// GetCakes: get all cake record with not soft delete, parameter with pagination, filter and sort.
+	// will return ErrInvalidSortField if sort field is not allowed
 	GetCakes(ctx context.Context, param model.GetCakesQuery) ([]model.Cake, error)
-	// CountCakes: get count all cake record with not soft delete, will return 0 and error exist if query error
-	CountCakes(ctx context.Context) (int64, error)
+	// GetCakesSeek: get cake record with not soft delete using keyset pagination on (rating DESC, title ASC, id ASC) order,
+	// result always returned on that order even when seeking backward
+	GetCakesSeek(ctx context.Context, param model.GetCakesSeekQuery) ([]model.Cake, error)
+	// StreamCakes: iterate all cake record with not soft delete matching filter, fn is called for each record while cursor is open
+	// so memory usage does not depend on number of record. iteration stop when fn return error, the error is returned.
+	// will return ErrInvalidSortField if sort field is not allowed
+	StreamCakes(ctx context.Context, param model.StreamCakesQuery, fn func(cake model.Cake) error) error
+	// CountCakes: get count all cake record with not soft delete matching filter, will return 0 and error exist if query error
+	CountCakes(ctx context.Context, filter model.CakesFilterQuery) (int64, error)
+	// GetCakesModified: get count, sum of version and latest updated_at / deleted_at of cake record matching filter,
+	// used as validator of cakes listing without querying the listing itself. zero ModifiedAt if no record match
+	GetCakesModified(ctx context.Context, filter model.CakesFilterQuery) (model.CakesModified, error)
 	// GetCake: get single cake record, required id record, will return nil if record not found at *model.Cake
 	GetCake(ctx context.Context, id int) (*model.Cake, error)
-	// InsertCake: insert new cake record, required parameter refer to model.CakePayloadQuery
-	InsertCake(ctx context.Context, param model.CakePayloadQuery) error
-	// UpdateCake: update cake record data, required id record and parameter refer to model.CakePayloadQuery
-	UpdateCake(ctx context.Context, id int, param model.CakePayloadQuery) error
-	// SoftDeleteCake: updating cake record data with filled deleted_at, required id record
-	SoftDeleteCake(ctx context.Context, id int) error
+	// GetDeletedCakes: get soft deleted cake record ordered by latest deleted_at, parameter with pagination
+	GetDeletedCakes(ctx context.Context, param model.GetDeletedCakesQuery) ([]model.Cake, error)
+	// CountDeletedCakes: get count all soft deleted cake record, will return 0 and error exist if query error
+	CountDeletedCakes(ctx context.Context) (int64, error)
+	// GetCakeWithDeleted: get single cake record including soft deleted one, will return nil if record not found at *model.Cake
+	GetCakeWithDeleted(ctx context.Context, id int) (*model.Cake, error)
+	// InsertCake: insert new cake record, required parameter refer to model.CakePayloadQuery, will return id of inserted record
+	InsertCake(ctx context.Context, param model.CakePayloadQuery) (int64, error)
+	// InsertCakes: insert multiple cake record on single statement, will return number of inserted record
+	InsertCakes(ctx context.Context, params []model.CakePayloadQuery) (int64, error)
+	// UpdateCake: update cake record data, required id record, expected version and parameter refer to model.CakePayloadQuery.
+	// will return false if record not found or version not match, use model.AnyVersion to skip version check
+	UpdateCake(ctx context.Context, id int, version int, param model.CakePayloadQuery) (bool, error)
+	// PatchCake: partial update cake record data, only field set on model.CakePatchQuery are updated, null value clear the field.
+	// will return false if record not found or version not match, use model.AnyVersion to skip version check
+	PatchCake(ctx context.Context, id int, version int, param model.CakePatchQuery) (bool, error)
+	// SoftDeleteCake: updating cake record data with deleted_at filled by deletedAt, required id record and expected version.
+	// will return false if record not found or version not match, use model.AnyVersion to skip version check
+	SoftDeleteCake(ctx context.Context, id int, version int, deletedAt time.Time) (bool, error)
+	// RestoreCake: clear deleted_at of soft deleted cake record, required id record.
+	// will return false if record not found or not soft deleted
+	RestoreCake(ctx context.Context, id int) (bool, error)
+	// PurgeDeletedCakes: permanently delete at most limit cake record soft deleted before deletedBefore, will return number of deleted record
+	PurgeDeletedCakes(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+	// CountPurgeableCakes: get count of cake record soft deleted before deletedBefore
+	CountPurgeableCakes(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type ApiKeyDBInterface interface {
+	// InsertApiKey: insert new api key record, required parameter refer to model.ApiKeyPayloadQuery, will return id of inserted record
+	InsertApiKey(ctx context.Context, param model.ApiKeyPayloadQuery) (int64, error)
+	// GetApiKeys: get all api key record including revoked one ordered by id
+	GetApiKeys(ctx context.Context) ([]model.ApiKey, error)
+	// GetApiKey: get single api key record, will return nil if record not found at *model.ApiKey
+	GetApiKey(ctx context.Context, id int) (*model.ApiKey, error)
+	// GetApiKeyByPrefix: get single api key record by its prefix, will return nil if record not found at *model.ApiKey
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (*model.ApiKey, error)
+	// RevokeApiKey: fill revoked_at of api key record by revokedAt, will return false if record not found or already revoked
+	RevokeApiKey(ctx context.Context, id int, revokedAt time.Time) (bool, error)
+	// TouchApiKey: fill last_used_at of api key record by usedAt
+	TouchApiKey(ctx context.Context, id int, usedAt time.Time) error
 }
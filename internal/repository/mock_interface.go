@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/forderation/ralali-test/internal/model"
 	"sync"
+	"time"
 )
 
 // Ensure, that CakeDBInterfaceMock does implement CakeDBInterface.
@@ -19,22 +20,58 @@ var _ CakeDBInterface = &CakeDBInterfaceMock{}
 //
 //		// make and configure a mocked CakeDBInterface
 //		mockedCakeDBInterface := &CakeDBInterfaceMock{
-//			CountCakesFunc: func(ctx context.Context) (int64, error) {
+//			CountCakesFunc: func(ctx context.Context, filter model.CakesFilterQuery) (int64, error) {
 //				panic("mock out the CountCakes method")
 //			},
+//			CountDeletedCakesFunc: func(ctx context.Context) (int64, error) {
+//				panic("mock out the CountDeletedCakes method")
+//			},
+//			CountPurgeableCakesFunc: func(ctx context.Context, deletedBefore time.Time) (int64, error) {
+//				panic("mock out the CountPurgeableCakes method")
+//			},
 //			GetCakeFunc: func(ctx context.Context, id int) (*model.Cake, error) {
 //				panic("mock out the GetCake method")
 //			},
+//			GetCakeWithDeletedFunc: func(ctx context.Context, id int) (*model.Cake, error) {
+//				panic("mock out the GetCakeWithDeleted method")
+//			},
 //			GetCakesFunc: func(ctx context.Context, param model.GetCakesQuery) ([]model.Cake, error) {
 //				panic("mock out the GetCakes method")
 //			},
-//			InsertCakeFunc: func(ctx context.Context, param model.CakePayloadQuery) error {
+//			GetCakesModifiedFunc: func(ctx context.Context, filter model.CakesFilterQuery) (model.CakesModified, error) {
+//				panic("mock out the GetCakesModified method")
+//			},
+//			GetCakesSeekFunc: func(ctx context.Context, param model.GetCakesSeekQuery) ([]model.Cake, error) {
+//				panic("mock out the GetCakesSeek method")
+//			},
+//			GetDeletedCakesFunc: func(ctx context.Context, param model.GetDeletedCakesQuery) ([]model.Cake, error) {
+//				panic("mock out the GetDeletedCakes method")
+//			},
+//			InsertCakeFunc: func(ctx context.Context, param model.CakePayloadQuery) (int64, error) {
 //				panic("mock out the InsertCake method")
 //			},
-//			SoftDeleteCakeFunc: func(ctx context.Context, id int) error {
+//			InsertCakesFunc: func(ctx context.Context, params []model.CakePayloadQuery) (int64, error) {
+//				panic("mock out the InsertCakes method")
+//			},
+//			PatchCakeFunc: func(ctx context.Context, id int, version int, param model.CakePatchQuery) (bool, error) {
+//				panic("mock out the PatchCake method")
+//			},
+//			PurgeDeletedCakesFunc: func(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+//				panic("mock out the PurgeDeletedCakes method")
+//			},
+//			RestoreCakeFunc: func(ctx context.Context, id int) (bool, error) {
+//				panic("mock out the RestoreCake method")
+//			},
+//			RunInTxFunc: func(ctx context.Context, fn func(txRepo CakeDBInterface) error) error {
+//				panic("mock out the RunInTx method")
+//			},
+//			SoftDeleteCakeFunc: func(ctx context.Context, id int, version int, deletedAt time.Time) (bool, error) {
 //				panic("mock out the SoftDeleteCake method")
 //			},
-//			UpdateCakeFunc: func(ctx context.Context, id int, param model.CakePayloadQuery) error {
+//			StreamCakesFunc: func(ctx context.Context, param model.StreamCakesQuery, fn func(cake model.Cake) error) error {
+//				panic("mock out the StreamCakes method")
+//			},
+//			UpdateCakeFunc: func(ctx context.Context, id int, version int, param model.CakePayloadQuery) (bool, error) {
 //				panic("mock out the UpdateCake method")
 //			},
 //		}
@@ -45,22 +82,58 @@ var _ CakeDBInterface = &CakeDBInterfaceMock{}
 //	}
 type CakeDBInterfaceMock struct {
 	// CountCakesFunc mocks the CountCakes method.
-	CountCakesFunc func(ctx context.Context) (int64, error)
+	CountCakesFunc func(ctx context.Context, filter model.CakesFilterQuery) (int64, error)
+
+	// CountDeletedCakesFunc mocks the CountDeletedCakes method.
+	CountDeletedCakesFunc func(ctx context.Context) (int64, error)
+
+	// CountPurgeableCakesFunc mocks the CountPurgeableCakes method.
+	CountPurgeableCakesFunc func(ctx context.Context, deletedBefore time.Time) (int64, error)
 
 	// GetCakeFunc mocks the GetCake method.
 	GetCakeFunc func(ctx context.Context, id int) (*model.Cake, error)
 
+	// GetCakeWithDeletedFunc mocks the GetCakeWithDeleted method.
+	GetCakeWithDeletedFunc func(ctx context.Context, id int) (*model.Cake, error)
+
 	// GetCakesFunc mocks the GetCakes method.
 	GetCakesFunc func(ctx context.Context, param model.GetCakesQuery) ([]model.Cake, error)
 
+	// GetCakesModifiedFunc mocks the GetCakesModified method.
+	GetCakesModifiedFunc func(ctx context.Context, filter model.CakesFilterQuery) (model.CakesModified, error)
+
+	// GetCakesSeekFunc mocks the GetCakesSeek method.
+	GetCakesSeekFunc func(ctx context.Context, param model.GetCakesSeekQuery) ([]model.Cake, error)
+
+	// GetDeletedCakesFunc mocks the GetDeletedCakes method.
+	GetDeletedCakesFunc func(ctx context.Context, param model.GetDeletedCakesQuery) ([]model.Cake, error)
+
 	// InsertCakeFunc mocks the InsertCake method.
-	InsertCakeFunc func(ctx context.Context, param model.CakePayloadQuery) error
+	InsertCakeFunc func(ctx context.Context, param model.CakePayloadQuery) (int64, error)
+
+	// InsertCakesFunc mocks the InsertCakes method.
+	InsertCakesFunc func(ctx context.Context, params []model.CakePayloadQuery) (int64, error)
+
+	// PatchCakeFunc mocks the PatchCake method.
+	PatchCakeFunc func(ctx context.Context, id int, version int, param model.CakePatchQuery) (bool, error)
+
+	// PurgeDeletedCakesFunc mocks the PurgeDeletedCakes method.
+	PurgeDeletedCakesFunc func(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+
+	// RestoreCakeFunc mocks the RestoreCake method.
+	RestoreCakeFunc func(ctx context.Context, id int) (bool, error)
+
+	// RunInTxFunc mocks the RunInTx method.
+	RunInTxFunc func(ctx context.Context, fn func(txRepo CakeDBInterface) error) error
 
 	// SoftDeleteCakeFunc mocks the SoftDeleteCake method.
-	SoftDeleteCakeFunc func(ctx context.Context, id int) error
+	SoftDeleteCakeFunc func(ctx context.Context, id int, version int, deletedAt time.Time) (bool, error)
+
+	// StreamCakesFunc mocks the StreamCakes method.
+	StreamCakesFunc func(ctx context.Context, param model.StreamCakesQuery, fn func(cake model.Cake) error) error
 
 	// UpdateCakeFunc mocks the UpdateCake method.
-	UpdateCakeFunc func(ctx context.Context, id int, param model.CakePayloadQuery) error
+	UpdateCakeFunc func(ctx context.Context, id int, version int, param model.CakePayloadQuery) (bool, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -68,6 +141,20 @@ type CakeDBInterfaceMock struct {
 		CountCakes []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter model.CakesFilterQuery
+		}
+		// CountDeletedCakes holds details about calls to the CountDeletedCakes method.
+		CountDeletedCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// CountPurgeableCakes holds details about calls to the CountPurgeableCakes method.
+		CountPurgeableCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DeletedBefore is the deletedBefore argument value.
+			DeletedBefore time.Time
 		}
 		// GetCake holds details about calls to the GetCake method.
 		GetCake []struct {
@@ -76,6 +163,13 @@ type CakeDBInterfaceMock struct {
 			// ID is the id argument value.
 			ID int
 		}
+		// GetCakeWithDeleted holds details about calls to the GetCakeWithDeleted method.
+		GetCakeWithDeleted []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+		}
 		// GetCakes holds details about calls to the GetCakes method.
 		GetCakes []struct {
 			// Ctx is the ctx argument value.
@@ -83,6 +177,27 @@ type CakeDBInterfaceMock struct {
 			// Param is the param argument value.
 			Param model.GetCakesQuery
 		}
+		// GetCakesModified holds details about calls to the GetCakesModified method.
+		GetCakesModified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter model.CakesFilterQuery
+		}
+		// GetCakesSeek holds details about calls to the GetCakesSeek method.
+		GetCakesSeek []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.GetCakesSeekQuery
+		}
+		// GetDeletedCakes holds details about calls to the GetDeletedCakes method.
+		GetDeletedCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.GetDeletedCakesQuery
+		}
 		// InsertCake holds details about calls to the InsertCake method.
 		InsertCake []struct {
 			// Ctx is the ctx argument value.
@@ -90,12 +205,66 @@ type CakeDBInterfaceMock struct {
 			// Param is the param argument value.
 			Param model.CakePayloadQuery
 		}
+		// InsertCakes holds details about calls to the InsertCakes method.
+		InsertCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params []model.CakePayloadQuery
+		}
+		// PatchCake holds details about calls to the PatchCake method.
+		PatchCake []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+			// Version is the version argument value.
+			Version int
+			// Param is the param argument value.
+			Param model.CakePatchQuery
+		}
+		// PurgeDeletedCakes holds details about calls to the PurgeDeletedCakes method.
+		PurgeDeletedCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DeletedBefore is the deletedBefore argument value.
+			DeletedBefore time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// RestoreCake holds details about calls to the RestoreCake method.
+		RestoreCake []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+		}
+		// RunInTx holds details about calls to the RunInTx method.
+		RunInTx []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Fn is the fn argument value.
+			Fn func(txRepo CakeDBInterface) error
+		}
 		// SoftDeleteCake holds details about calls to the SoftDeleteCake method.
 		SoftDeleteCake []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int
+			// Version is the version argument value.
+			Version int
+			// DeletedAt is the deletedAt argument value.
+			DeletedAt time.Time
+		}
+		// StreamCakes holds details about calls to the StreamCakes method.
+		StreamCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.StreamCakesQuery
+			// Fn is the fn argument value.
+			Fn func(cake model.Cake) error
 		}
 		// UpdateCake holds details about calls to the UpdateCake method.
 		UpdateCake []struct {
@@ -103,32 +272,48 @@ type CakeDBInterfaceMock struct {
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int
+			// Version is the version argument value.
+			Version int
 			// Param is the param argument value.
 			Param model.CakePayloadQuery
 		}
 	}
-	lockCountCakes     sync.RWMutex
-	lockGetCake        sync.RWMutex
-	lockGetCakes       sync.RWMutex
-	lockInsertCake     sync.RWMutex
-	lockSoftDeleteCake sync.RWMutex
-	lockUpdateCake     sync.RWMutex
+	lockCountCakes          sync.RWMutex
+	lockCountDeletedCakes   sync.RWMutex
+	lockCountPurgeableCakes sync.RWMutex
+	lockGetCake             sync.RWMutex
+	lockGetCakeWithDeleted  sync.RWMutex
+	lockGetCakes            sync.RWMutex
+	lockGetCakesModified    sync.RWMutex
+	lockGetCakesSeek        sync.RWMutex
+	lockGetDeletedCakes     sync.RWMutex
+	lockInsertCake          sync.RWMutex
+	lockInsertCakes         sync.RWMutex
+	lockPatchCake           sync.RWMutex
+	lockPurgeDeletedCakes   sync.RWMutex
+	lockRestoreCake         sync.RWMutex
+	lockRunInTx             sync.RWMutex
+	lockSoftDeleteCake      sync.RWMutex
+	lockStreamCakes         sync.RWMutex
+	lockUpdateCake          sync.RWMutex
 }
 
 // CountCakes calls CountCakesFunc.
-func (mock *CakeDBInterfaceMock) CountCakes(ctx context.Context) (int64, error) {
+func (mock *CakeDBInterfaceMock) CountCakes(ctx context.Context, filter model.CakesFilterQuery) (int64, error) {
 	if mock.CountCakesFunc == nil {
 		panic("CakeDBInterfaceMock.CountCakesFunc: method is nil but CakeDBInterface.CountCakes was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx    context.Context
+		Filter model.CakesFilterQuery
 	}{
-		Ctx: ctx,
+		Ctx:    ctx,
+		Filter: filter,
 	}
 	mock.lockCountCakes.Lock()
 	mock.calls.CountCakes = append(mock.calls.CountCakes, callInfo)
 	mock.lockCountCakes.Unlock()
-	return mock.CountCakesFunc(ctx)
+	return mock.CountCakesFunc(ctx, filter)
 }
 
 // CountCakesCalls gets all the calls that were made to CountCakes.
@@ -136,10 +321,12 @@ func (mock *CakeDBInterfaceMock) CountCakes(ctx context.Context) (int64, error)
 //
 //	len(mockedCakeDBInterface.CountCakesCalls())
 func (mock *CakeDBInterfaceMock) CountCakesCalls() []struct {
-	Ctx context.Context
+	Ctx    context.Context
+	Filter model.CakesFilterQuery
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx    context.Context
+		Filter model.CakesFilterQuery
 	}
 	mock.lockCountCakes.RLock()
 	calls = mock.calls.CountCakes
@@ -147,6 +334,74 @@ func (mock *CakeDBInterfaceMock) CountCakesCalls() []struct {
 	return calls
 }
 
+// CountDeletedCakes calls CountDeletedCakesFunc.
+func (mock *CakeDBInterfaceMock) CountDeletedCakes(ctx context.Context) (int64, error) {
+	if mock.CountDeletedCakesFunc == nil {
+		panic("CakeDBInterfaceMock.CountDeletedCakesFunc: method is nil but CakeDBInterface.CountDeletedCakes was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCountDeletedCakes.Lock()
+	mock.calls.CountDeletedCakes = append(mock.calls.CountDeletedCakes, callInfo)
+	mock.lockCountDeletedCakes.Unlock()
+	return mock.CountDeletedCakesFunc(ctx)
+}
+
+// CountDeletedCakesCalls gets all the calls that were made to CountDeletedCakes.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.CountDeletedCakesCalls())
+func (mock *CakeDBInterfaceMock) CountDeletedCakesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockCountDeletedCakes.RLock()
+	calls = mock.calls.CountDeletedCakes
+	mock.lockCountDeletedCakes.RUnlock()
+	return calls
+}
+
+// CountPurgeableCakes calls CountPurgeableCakesFunc.
+func (mock *CakeDBInterfaceMock) CountPurgeableCakes(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	if mock.CountPurgeableCakesFunc == nil {
+		panic("CakeDBInterfaceMock.CountPurgeableCakesFunc: method is nil but CakeDBInterface.CountPurgeableCakes was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+	}{
+		Ctx:           ctx,
+		DeletedBefore: deletedBefore,
+	}
+	mock.lockCountPurgeableCakes.Lock()
+	mock.calls.CountPurgeableCakes = append(mock.calls.CountPurgeableCakes, callInfo)
+	mock.lockCountPurgeableCakes.Unlock()
+	return mock.CountPurgeableCakesFunc(ctx, deletedBefore)
+}
+
+// CountPurgeableCakesCalls gets all the calls that were made to CountPurgeableCakes.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.CountPurgeableCakesCalls())
+func (mock *CakeDBInterfaceMock) CountPurgeableCakesCalls() []struct {
+	Ctx           context.Context
+	DeletedBefore time.Time
+} {
+	var calls []struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+	}
+	mock.lockCountPurgeableCakes.RLock()
+	calls = mock.calls.CountPurgeableCakes
+	mock.lockCountPurgeableCakes.RUnlock()
+	return calls
+}
+
 // GetCake calls GetCakeFunc.
 func (mock *CakeDBInterfaceMock) GetCake(ctx context.Context, id int) (*model.Cake, error) {
 	if mock.GetCakeFunc == nil {
@@ -183,6 +438,42 @@ func (mock *CakeDBInterfaceMock) GetCakeCalls() []struct {
 	return calls
 }
 
+// GetCakeWithDeleted calls GetCakeWithDeletedFunc.
+func (mock *CakeDBInterfaceMock) GetCakeWithDeleted(ctx context.Context, id int) (*model.Cake, error) {
+	if mock.GetCakeWithDeletedFunc == nil {
+		panic("CakeDBInterfaceMock.GetCakeWithDeletedFunc: method is nil but CakeDBInterface.GetCakeWithDeleted was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetCakeWithDeleted.Lock()
+	mock.calls.GetCakeWithDeleted = append(mock.calls.GetCakeWithDeleted, callInfo)
+	mock.lockGetCakeWithDeleted.Unlock()
+	return mock.GetCakeWithDeletedFunc(ctx, id)
+}
+
+// GetCakeWithDeletedCalls gets all the calls that were made to GetCakeWithDeleted.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.GetCakeWithDeletedCalls())
+func (mock *CakeDBInterfaceMock) GetCakeWithDeletedCalls() []struct {
+	Ctx context.Context
+	ID  int
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int
+	}
+	mock.lockGetCakeWithDeleted.RLock()
+	calls = mock.calls.GetCakeWithDeleted
+	mock.lockGetCakeWithDeleted.RUnlock()
+	return calls
+}
+
 // GetCakes calls GetCakesFunc.
 func (mock *CakeDBInterfaceMock) GetCakes(ctx context.Context, param model.GetCakesQuery) ([]model.Cake, error) {
 	if mock.GetCakesFunc == nil {
@@ -219,8 +510,116 @@ func (mock *CakeDBInterfaceMock) GetCakesCalls() []struct {
 	return calls
 }
 
+// GetCakesModified calls GetCakesModifiedFunc.
+func (mock *CakeDBInterfaceMock) GetCakesModified(ctx context.Context, filter model.CakesFilterQuery) (model.CakesModified, error) {
+	if mock.GetCakesModifiedFunc == nil {
+		panic("CakeDBInterfaceMock.GetCakesModifiedFunc: method is nil but CakeDBInterface.GetCakesModified was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter model.CakesFilterQuery
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockGetCakesModified.Lock()
+	mock.calls.GetCakesModified = append(mock.calls.GetCakesModified, callInfo)
+	mock.lockGetCakesModified.Unlock()
+	return mock.GetCakesModifiedFunc(ctx, filter)
+}
+
+// GetCakesModifiedCalls gets all the calls that were made to GetCakesModified.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.GetCakesModifiedCalls())
+func (mock *CakeDBInterfaceMock) GetCakesModifiedCalls() []struct {
+	Ctx    context.Context
+	Filter model.CakesFilterQuery
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter model.CakesFilterQuery
+	}
+	mock.lockGetCakesModified.RLock()
+	calls = mock.calls.GetCakesModified
+	mock.lockGetCakesModified.RUnlock()
+	return calls
+}
+
+// GetCakesSeek calls GetCakesSeekFunc.
+func (mock *CakeDBInterfaceMock) GetCakesSeek(ctx context.Context, param model.GetCakesSeekQuery) ([]model.Cake, error) {
+	if mock.GetCakesSeekFunc == nil {
+		panic("CakeDBInterfaceMock.GetCakesSeekFunc: method is nil but CakeDBInterface.GetCakesSeek was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.GetCakesSeekQuery
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockGetCakesSeek.Lock()
+	mock.calls.GetCakesSeek = append(mock.calls.GetCakesSeek, callInfo)
+	mock.lockGetCakesSeek.Unlock()
+	return mock.GetCakesSeekFunc(ctx, param)
+}
+
+// GetCakesSeekCalls gets all the calls that were made to GetCakesSeek.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.GetCakesSeekCalls())
+func (mock *CakeDBInterfaceMock) GetCakesSeekCalls() []struct {
+	Ctx   context.Context
+	Param model.GetCakesSeekQuery
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.GetCakesSeekQuery
+	}
+	mock.lockGetCakesSeek.RLock()
+	calls = mock.calls.GetCakesSeek
+	mock.lockGetCakesSeek.RUnlock()
+	return calls
+}
+
+// GetDeletedCakes calls GetDeletedCakesFunc.
+func (mock *CakeDBInterfaceMock) GetDeletedCakes(ctx context.Context, param model.GetDeletedCakesQuery) ([]model.Cake, error) {
+	if mock.GetDeletedCakesFunc == nil {
+		panic("CakeDBInterfaceMock.GetDeletedCakesFunc: method is nil but CakeDBInterface.GetDeletedCakes was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.GetDeletedCakesQuery
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockGetDeletedCakes.Lock()
+	mock.calls.GetDeletedCakes = append(mock.calls.GetDeletedCakes, callInfo)
+	mock.lockGetDeletedCakes.Unlock()
+	return mock.GetDeletedCakesFunc(ctx, param)
+}
+
+// GetDeletedCakesCalls gets all the calls that were made to GetDeletedCakes.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.GetDeletedCakesCalls())
+func (mock *CakeDBInterfaceMock) GetDeletedCakesCalls() []struct {
+	Ctx   context.Context
+	Param model.GetDeletedCakesQuery
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.GetDeletedCakesQuery
+	}
+	mock.lockGetDeletedCakes.RLock()
+	calls = mock.calls.GetDeletedCakes
+	mock.lockGetDeletedCakes.RUnlock()
+	return calls
+}
+
 // InsertCake calls InsertCakeFunc.
-func (mock *CakeDBInterfaceMock) InsertCake(ctx context.Context, param model.CakePayloadQuery) error {
+func (mock *CakeDBInterfaceMock) InsertCake(ctx context.Context, param model.CakePayloadQuery) (int64, error) {
 	if mock.InsertCakeFunc == nil {
 		panic("CakeDBInterfaceMock.InsertCakeFunc: method is nil but CakeDBInterface.InsertCake was just called")
 	}
@@ -255,10 +654,130 @@ func (mock *CakeDBInterfaceMock) InsertCakeCalls() []struct {
 	return calls
 }
 
-// SoftDeleteCake calls SoftDeleteCakeFunc.
-func (mock *CakeDBInterfaceMock) SoftDeleteCake(ctx context.Context, id int) error {
-	if mock.SoftDeleteCakeFunc == nil {
-		panic("CakeDBInterfaceMock.SoftDeleteCakeFunc: method is nil but CakeDBInterface.SoftDeleteCake was just called")
+// InsertCakes calls InsertCakesFunc.
+func (mock *CakeDBInterfaceMock) InsertCakes(ctx context.Context, params []model.CakePayloadQuery) (int64, error) {
+	if mock.InsertCakesFunc == nil {
+		panic("CakeDBInterfaceMock.InsertCakesFunc: method is nil but CakeDBInterface.InsertCakes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params []model.CakePayloadQuery
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockInsertCakes.Lock()
+	mock.calls.InsertCakes = append(mock.calls.InsertCakes, callInfo)
+	mock.lockInsertCakes.Unlock()
+	return mock.InsertCakesFunc(ctx, params)
+}
+
+// InsertCakesCalls gets all the calls that were made to InsertCakes.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.InsertCakesCalls())
+func (mock *CakeDBInterfaceMock) InsertCakesCalls() []struct {
+	Ctx    context.Context
+	Params []model.CakePayloadQuery
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params []model.CakePayloadQuery
+	}
+	mock.lockInsertCakes.RLock()
+	calls = mock.calls.InsertCakes
+	mock.lockInsertCakes.RUnlock()
+	return calls
+}
+
+// PatchCake calls PatchCakeFunc.
+func (mock *CakeDBInterfaceMock) PatchCake(ctx context.Context, id int, version int, param model.CakePatchQuery) (bool, error) {
+	if mock.PatchCakeFunc == nil {
+		panic("CakeDBInterfaceMock.PatchCakeFunc: method is nil but CakeDBInterface.PatchCake was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int
+		Version int
+		Param   model.CakePatchQuery
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Param:   param,
+	}
+	mock.lockPatchCake.Lock()
+	mock.calls.PatchCake = append(mock.calls.PatchCake, callInfo)
+	mock.lockPatchCake.Unlock()
+	return mock.PatchCakeFunc(ctx, id, version, param)
+}
+
+// PatchCakeCalls gets all the calls that were made to PatchCake.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.PatchCakeCalls())
+func (mock *CakeDBInterfaceMock) PatchCakeCalls() []struct {
+	Ctx     context.Context
+	ID      int
+	Version int
+	Param   model.CakePatchQuery
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int
+		Version int
+		Param   model.CakePatchQuery
+	}
+	mock.lockPatchCake.RLock()
+	calls = mock.calls.PatchCake
+	mock.lockPatchCake.RUnlock()
+	return calls
+}
+
+// PurgeDeletedCakes calls PurgeDeletedCakesFunc.
+func (mock *CakeDBInterfaceMock) PurgeDeletedCakes(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	if mock.PurgeDeletedCakesFunc == nil {
+		panic("CakeDBInterfaceMock.PurgeDeletedCakesFunc: method is nil but CakeDBInterface.PurgeDeletedCakes was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+		Limit         int
+	}{
+		Ctx:           ctx,
+		DeletedBefore: deletedBefore,
+		Limit:         limit,
+	}
+	mock.lockPurgeDeletedCakes.Lock()
+	mock.calls.PurgeDeletedCakes = append(mock.calls.PurgeDeletedCakes, callInfo)
+	mock.lockPurgeDeletedCakes.Unlock()
+	return mock.PurgeDeletedCakesFunc(ctx, deletedBefore, limit)
+}
+
+// PurgeDeletedCakesCalls gets all the calls that were made to PurgeDeletedCakes.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.PurgeDeletedCakesCalls())
+func (mock *CakeDBInterfaceMock) PurgeDeletedCakesCalls() []struct {
+	Ctx           context.Context
+	DeletedBefore time.Time
+	Limit         int
+} {
+	var calls []struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+		Limit         int
+	}
+	mock.lockPurgeDeletedCakes.RLock()
+	calls = mock.calls.PurgeDeletedCakes
+	mock.lockPurgeDeletedCakes.RUnlock()
+	return calls
+}
+
+// RestoreCake calls RestoreCakeFunc.
+func (mock *CakeDBInterfaceMock) RestoreCake(ctx context.Context, id int) (bool, error) {
+	if mock.RestoreCakeFunc == nil {
+		panic("CakeDBInterfaceMock.RestoreCakeFunc: method is nil but CakeDBInterface.RestoreCake was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
@@ -267,10 +786,86 @@ func (mock *CakeDBInterfaceMock) SoftDeleteCake(ctx context.Context, id int) err
 		Ctx: ctx,
 		ID:  id,
 	}
+	mock.lockRestoreCake.Lock()
+	mock.calls.RestoreCake = append(mock.calls.RestoreCake, callInfo)
+	mock.lockRestoreCake.Unlock()
+	return mock.RestoreCakeFunc(ctx, id)
+}
+
+// RestoreCakeCalls gets all the calls that were made to RestoreCake.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.RestoreCakeCalls())
+func (mock *CakeDBInterfaceMock) RestoreCakeCalls() []struct {
+	Ctx context.Context
+	ID  int
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int
+	}
+	mock.lockRestoreCake.RLock()
+	calls = mock.calls.RestoreCake
+	mock.lockRestoreCake.RUnlock()
+	return calls
+}
+
+// RunInTx calls RunInTxFunc.
+func (mock *CakeDBInterfaceMock) RunInTx(ctx context.Context, fn func(txRepo CakeDBInterface) error) error {
+	if mock.RunInTxFunc == nil {
+		panic("CakeDBInterfaceMock.RunInTxFunc: method is nil but CakeDBInterface.RunInTx was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Fn  func(txRepo CakeDBInterface) error
+	}{
+		Ctx: ctx,
+		Fn:  fn,
+	}
+	mock.lockRunInTx.Lock()
+	mock.calls.RunInTx = append(mock.calls.RunInTx, callInfo)
+	mock.lockRunInTx.Unlock()
+	return mock.RunInTxFunc(ctx, fn)
+}
+
+// RunInTxCalls gets all the calls that were made to RunInTx.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.RunInTxCalls())
+func (mock *CakeDBInterfaceMock) RunInTxCalls() []struct {
+	Ctx context.Context
+	Fn  func(txRepo CakeDBInterface) error
+} {
+	var calls []struct {
+		Ctx context.Context
+		Fn  func(txRepo CakeDBInterface) error
+	}
+	mock.lockRunInTx.RLock()
+	calls = mock.calls.RunInTx
+	mock.lockRunInTx.RUnlock()
+	return calls
+}
+
+// SoftDeleteCake calls SoftDeleteCakeFunc.
+func (mock *CakeDBInterfaceMock) SoftDeleteCake(ctx context.Context, id int, version int, deletedAt time.Time) (bool, error) {
+	if mock.SoftDeleteCakeFunc == nil {
+		panic("CakeDBInterfaceMock.SoftDeleteCakeFunc: method is nil but CakeDBInterface.SoftDeleteCake was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        int
+		Version   int
+		DeletedAt time.Time
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		Version:   version,
+		DeletedAt: deletedAt,
+	}
 	mock.lockSoftDeleteCake.Lock()
 	mock.calls.SoftDeleteCake = append(mock.calls.SoftDeleteCake, callInfo)
 	mock.lockSoftDeleteCake.Unlock()
-	return mock.SoftDeleteCakeFunc(ctx, id)
+	return mock.SoftDeleteCakeFunc(ctx, id, version, deletedAt)
 }
 
 // SoftDeleteCakeCalls gets all the calls that were made to SoftDeleteCake.
@@ -278,12 +873,16 @@ func (mock *CakeDBInterfaceMock) SoftDeleteCake(ctx context.Context, id int) err
 //
 //	len(mockedCakeDBInterface.SoftDeleteCakeCalls())
 func (mock *CakeDBInterfaceMock) SoftDeleteCakeCalls() []struct {
-	Ctx context.Context
-	ID  int
+	Ctx       context.Context
+	ID        int
+	Version   int
+	DeletedAt time.Time
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int
+		Ctx       context.Context
+		ID        int
+		Version   int
+		DeletedAt time.Time
 	}
 	mock.lockSoftDeleteCake.RLock()
 	calls = mock.calls.SoftDeleteCake
@@ -291,24 +890,66 @@ func (mock *CakeDBInterfaceMock) SoftDeleteCakeCalls() []struct {
 	return calls
 }
 
-// UpdateCake calls UpdateCakeFunc.
-func (mock *CakeDBInterfaceMock) UpdateCake(ctx context.Context, id int, param model.CakePayloadQuery) error {
-	if mock.UpdateCakeFunc == nil {
-		panic("CakeDBInterfaceMock.UpdateCakeFunc: method is nil but CakeDBInterface.UpdateCake was just called")
+// StreamCakes calls StreamCakesFunc.
+func (mock *CakeDBInterfaceMock) StreamCakes(ctx context.Context, param model.StreamCakesQuery, fn func(cake model.Cake) error) error {
+	if mock.StreamCakesFunc == nil {
+		panic("CakeDBInterfaceMock.StreamCakesFunc: method is nil but CakeDBInterface.StreamCakes was just called")
 	}
 	callInfo := struct {
 		Ctx   context.Context
-		ID    int
-		Param model.CakePayloadQuery
+		Param model.StreamCakesQuery
+		Fn    func(cake model.Cake) error
 	}{
 		Ctx:   ctx,
-		ID:    id,
 		Param: param,
+		Fn:    fn,
+	}
+	mock.lockStreamCakes.Lock()
+	mock.calls.StreamCakes = append(mock.calls.StreamCakes, callInfo)
+	mock.lockStreamCakes.Unlock()
+	return mock.StreamCakesFunc(ctx, param, fn)
+}
+
+// StreamCakesCalls gets all the calls that were made to StreamCakes.
+// Check the length with:
+//
+//	len(mockedCakeDBInterface.StreamCakesCalls())
+func (mock *CakeDBInterfaceMock) StreamCakesCalls() []struct {
+	Ctx   context.Context
+	Param model.StreamCakesQuery
+	Fn    func(cake model.Cake) error
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.StreamCakesQuery
+		Fn    func(cake model.Cake) error
+	}
+	mock.lockStreamCakes.RLock()
+	calls = mock.calls.StreamCakes
+	mock.lockStreamCakes.RUnlock()
+	return calls
+}
+
+// UpdateCake calls UpdateCakeFunc.
+func (mock *CakeDBInterfaceMock) UpdateCake(ctx context.Context, id int, version int, param model.CakePayloadQuery) (bool, error) {
+	if mock.UpdateCakeFunc == nil {
+		panic("CakeDBInterfaceMock.UpdateCakeFunc: method is nil but CakeDBInterface.UpdateCake was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int
+		Version int
+		Param   model.CakePayloadQuery
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Param:   param,
 	}
 	mock.lockUpdateCake.Lock()
 	mock.calls.UpdateCake = append(mock.calls.UpdateCake, callInfo)
 	mock.lockUpdateCake.Unlock()
-	return mock.UpdateCakeFunc(ctx, id, param)
+	return mock.UpdateCakeFunc(ctx, id, version, param)
 }
 
 // UpdateCakeCalls gets all the calls that were made to UpdateCake.
@@ -316,17 +957,347 @@ func (mock *CakeDBInterfaceMock) UpdateCake(ctx context.Context, id int, param m
 //
 //	len(mockedCakeDBInterface.UpdateCakeCalls())
 func (mock *CakeDBInterfaceMock) UpdateCakeCalls() []struct {
-	Ctx   context.Context
-	ID    int
-	Param model.CakePayloadQuery
+	Ctx     context.Context
+	ID      int
+	Version int
+	Param   model.CakePayloadQuery
 } {
 	var calls []struct {
-		Ctx   context.Context
-		ID    int
-		Param model.CakePayloadQuery
+		Ctx     context.Context
+		ID      int
+		Version int
+		Param   model.CakePayloadQuery
 	}
 	mock.lockUpdateCake.RLock()
 	calls = mock.calls.UpdateCake
 	mock.lockUpdateCake.RUnlock()
 	return calls
 }
+
+// Ensure, that ApiKeyDBInterfaceMock does implement ApiKeyDBInterface.
+// If this is not the case, regenerate this file with moq.
+var _ ApiKeyDBInterface = &ApiKeyDBInterfaceMock{}
+
+// ApiKeyDBInterfaceMock is a mock implementation of ApiKeyDBInterface.
+//
+//	func TestSomethingThatUsesApiKeyDBInterface(t *testing.T) {
+//
+//		// make and configure a mocked ApiKeyDBInterface
+//		mockedApiKeyDBInterface := &ApiKeyDBInterfaceMock{
+//			GetApiKeyFunc: func(ctx context.Context, id int) (*model.ApiKey, error) {
+//				panic("mock out the GetApiKey method")
+//			},
+//			GetApiKeyByPrefixFunc: func(ctx context.Context, prefix string) (*model.ApiKey, error) {
+//				panic("mock out the GetApiKeyByPrefix method")
+//			},
+//			GetApiKeysFunc: func(ctx context.Context) ([]model.ApiKey, error) {
+//				panic("mock out the GetApiKeys method")
+//			},
+//			InsertApiKeyFunc: func(ctx context.Context, param model.ApiKeyPayloadQuery) (int64, error) {
+//				panic("mock out the InsertApiKey method")
+//			},
+//			RevokeApiKeyFunc: func(ctx context.Context, id int, revokedAt time.Time) (bool, error) {
+//				panic("mock out the RevokeApiKey method")
+//			},
+//			TouchApiKeyFunc: func(ctx context.Context, id int, usedAt time.Time) error {
+//				panic("mock out the TouchApiKey method")
+//			},
+//		}
+//
+//		// use mockedApiKeyDBInterface in code that requires ApiKeyDBInterface
+//		// and then make assertions.
+//
+//	}
+type ApiKeyDBInterfaceMock struct {
+	// GetApiKeyFunc mocks the GetApiKey method.
+	GetApiKeyFunc func(ctx context.Context, id int) (*model.ApiKey, error)
+
+	// GetApiKeyByPrefixFunc mocks the GetApiKeyByPrefix method.
+	GetApiKeyByPrefixFunc func(ctx context.Context, prefix string) (*model.ApiKey, error)
+
+	// GetApiKeysFunc mocks the GetApiKeys method.
+	GetApiKeysFunc func(ctx context.Context) ([]model.ApiKey, error)
+
+	// InsertApiKeyFunc mocks the InsertApiKey method.
+	InsertApiKeyFunc func(ctx context.Context, param model.ApiKeyPayloadQuery) (int64, error)
+
+	// RevokeApiKeyFunc mocks the RevokeApiKey method.
+	RevokeApiKeyFunc func(ctx context.Context, id int, revokedAt time.Time) (bool, error)
+
+	// TouchApiKeyFunc mocks the TouchApiKey method.
+	TouchApiKeyFunc func(ctx context.Context, id int, usedAt time.Time) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetApiKey holds details about calls to the GetApiKey method.
+		GetApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+		}
+		// GetApiKeyByPrefix holds details about calls to the GetApiKeyByPrefix method.
+		GetApiKeyByPrefix []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Prefix is the prefix argument value.
+			Prefix string
+		}
+		// GetApiKeys holds details about calls to the GetApiKeys method.
+		GetApiKeys []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// InsertApiKey holds details about calls to the InsertApiKey method.
+		InsertApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.ApiKeyPayloadQuery
+		}
+		// RevokeApiKey holds details about calls to the RevokeApiKey method.
+		RevokeApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+			// RevokedAt is the revokedAt argument value.
+			RevokedAt time.Time
+		}
+		// TouchApiKey holds details about calls to the TouchApiKey method.
+		TouchApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+			// UsedAt is the usedAt argument value.
+			UsedAt time.Time
+		}
+	}
+	lockGetApiKey         sync.RWMutex
+	lockGetApiKeyByPrefix sync.RWMutex
+	lockGetApiKeys        sync.RWMutex
+	lockInsertApiKey      sync.RWMutex
+	lockRevokeApiKey      sync.RWMutex
+	lockTouchApiKey       sync.RWMutex
+}
+
+// GetApiKey calls GetApiKeyFunc.
+func (mock *ApiKeyDBInterfaceMock) GetApiKey(ctx context.Context, id int) (*model.ApiKey, error) {
+	if mock.GetApiKeyFunc == nil {
+		panic("ApiKeyDBInterfaceMock.GetApiKeyFunc: method is nil but ApiKeyDBInterface.GetApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetApiKey.Lock()
+	mock.calls.GetApiKey = append(mock.calls.GetApiKey, callInfo)
+	mock.lockGetApiKey.Unlock()
+	return mock.GetApiKeyFunc(ctx, id)
+}
+
+// GetApiKeyCalls gets all the calls that were made to GetApiKey.
+// Check the length with:
+//
+//	len(mockedApiKeyDBInterface.GetApiKeyCalls())
+func (mock *ApiKeyDBInterfaceMock) GetApiKeyCalls() []struct {
+	Ctx context.Context
+	ID  int
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int
+	}
+	mock.lockGetApiKey.RLock()
+	calls = mock.calls.GetApiKey
+	mock.lockGetApiKey.RUnlock()
+	return calls
+}
+
+// GetApiKeyByPrefix calls GetApiKeyByPrefixFunc.
+func (mock *ApiKeyDBInterfaceMock) GetApiKeyByPrefix(ctx context.Context, prefix string) (*model.ApiKey, error) {
+	if mock.GetApiKeyByPrefixFunc == nil {
+		panic("ApiKeyDBInterfaceMock.GetApiKeyByPrefixFunc: method is nil but ApiKeyDBInterface.GetApiKeyByPrefix was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Prefix string
+	}{
+		Ctx:    ctx,
+		Prefix: prefix,
+	}
+	mock.lockGetApiKeyByPrefix.Lock()
+	mock.calls.GetApiKeyByPrefix = append(mock.calls.GetApiKeyByPrefix, callInfo)
+	mock.lockGetApiKeyByPrefix.Unlock()
+	return mock.GetApiKeyByPrefixFunc(ctx, prefix)
+}
+
+// GetApiKeyByPrefixCalls gets all the calls that were made to GetApiKeyByPrefix.
+// Check the length with:
+//
+//	len(mockedApiKeyDBInterface.GetApiKeyByPrefixCalls())
+func (mock *ApiKeyDBInterfaceMock) GetApiKeyByPrefixCalls() []struct {
+	Ctx    context.Context
+	Prefix string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Prefix string
+	}
+	mock.lockGetApiKeyByPrefix.RLock()
+	calls = mock.calls.GetApiKeyByPrefix
+	mock.lockGetApiKeyByPrefix.RUnlock()
+	return calls
+}
+
+// GetApiKeys calls GetApiKeysFunc.
+func (mock *ApiKeyDBInterfaceMock) GetApiKeys(ctx context.Context) ([]model.ApiKey, error) {
+	if mock.GetApiKeysFunc == nil {
+		panic("ApiKeyDBInterfaceMock.GetApiKeysFunc: method is nil but ApiKeyDBInterface.GetApiKeys was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetApiKeys.Lock()
+	mock.calls.GetApiKeys = append(mock.calls.GetApiKeys, callInfo)
+	mock.lockGetApiKeys.Unlock()
+	return mock.GetApiKeysFunc(ctx)
+}
+
+// GetApiKeysCalls gets all the calls that were made to GetApiKeys.
+// Check the length with:
+//
+//	len(mockedApiKeyDBInterface.GetApiKeysCalls())
+func (mock *ApiKeyDBInterfaceMock) GetApiKeysCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetApiKeys.RLock()
+	calls = mock.calls.GetApiKeys
+	mock.lockGetApiKeys.RUnlock()
+	return calls
+}
+
+// InsertApiKey calls InsertApiKeyFunc.
+func (mock *ApiKeyDBInterfaceMock) InsertApiKey(ctx context.Context, param model.ApiKeyPayloadQuery) (int64, error) {
+	if mock.InsertApiKeyFunc == nil {
+		panic("ApiKeyDBInterfaceMock.InsertApiKeyFunc: method is nil but ApiKeyDBInterface.InsertApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.ApiKeyPayloadQuery
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockInsertApiKey.Lock()
+	mock.calls.InsertApiKey = append(mock.calls.InsertApiKey, callInfo)
+	mock.lockInsertApiKey.Unlock()
+	return mock.InsertApiKeyFunc(ctx, param)
+}
+
+// InsertApiKeyCalls gets all the calls that were made to InsertApiKey.
+// Check the length with:
+//
+//	len(mockedApiKeyDBInterface.InsertApiKeyCalls())
+func (mock *ApiKeyDBInterfaceMock) InsertApiKeyCalls() []struct {
+	Ctx   context.Context
+	Param model.ApiKeyPayloadQuery
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.ApiKeyPayloadQuery
+	}
+	mock.lockInsertApiKey.RLock()
+	calls = mock.calls.InsertApiKey
+	mock.lockInsertApiKey.RUnlock()
+	return calls
+}
+
+// RevokeApiKey calls RevokeApiKeyFunc.
+func (mock *ApiKeyDBInterfaceMock) RevokeApiKey(ctx context.Context, id int, revokedAt time.Time) (bool, error) {
+	if mock.RevokeApiKeyFunc == nil {
+		panic("ApiKeyDBInterfaceMock.RevokeApiKeyFunc: method is nil but ApiKeyDBInterface.RevokeApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        int
+		RevokedAt time.Time
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		RevokedAt: revokedAt,
+	}
+	mock.lockRevokeApiKey.Lock()
+	mock.calls.RevokeApiKey = append(mock.calls.RevokeApiKey, callInfo)
+	mock.lockRevokeApiKey.Unlock()
+	return mock.RevokeApiKeyFunc(ctx, id, revokedAt)
+}
+
+// RevokeApiKeyCalls gets all the calls that were made to RevokeApiKey.
+// Check the length with:
+//
+//	len(mockedApiKeyDBInterface.RevokeApiKeyCalls())
+func (mock *ApiKeyDBInterfaceMock) RevokeApiKeyCalls() []struct {
+	Ctx       context.Context
+	ID        int
+	RevokedAt time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ID        int
+		RevokedAt time.Time
+	}
+	mock.lockRevokeApiKey.RLock()
+	calls = mock.calls.RevokeApiKey
+	mock.lockRevokeApiKey.RUnlock()
+	return calls
+}
+
+// TouchApiKey calls TouchApiKeyFunc.
+func (mock *ApiKeyDBInterfaceMock) TouchApiKey(ctx context.Context, id int, usedAt time.Time) error {
+	if mock.TouchApiKeyFunc == nil {
+		panic("ApiKeyDBInterfaceMock.TouchApiKeyFunc: method is nil but ApiKeyDBInterface.TouchApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     int
+		UsedAt time.Time
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		UsedAt: usedAt,
+	}
+	mock.lockTouchApiKey.Lock()
+	mock.calls.TouchApiKey = append(mock.calls.TouchApiKey, callInfo)
+	mock.lockTouchApiKey.Unlock()
+	return mock.TouchApiKeyFunc(ctx, id, usedAt)
+}
+
+// TouchApiKeyCalls gets all the calls that were made to TouchApiKey.
+// Check the length with:
+//
+//	len(mockedApiKeyDBInterface.TouchApiKeyCalls())
+func (mock *ApiKeyDBInterfaceMock) TouchApiKeyCalls() []struct {
+	Ctx    context.Context
+	ID     int
+	UsedAt time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     int
+		UsedAt time.Time
+	}
+	mock.lockTouchApiKey.RLock()
+	calls = mock.calls.TouchApiKey
+	mock.lockTouchApiKey.RUnlock()
+	return calls
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/forderation/ralali-test/internal/model"
+)
+
+// ErrImageNotFound: returned by ImageStore when no image stored under the key
+var ErrImageNotFound = errors.New("image not found")
+
+//go:generate moq -out mock_interface.go . ImageStore
+type ImageStore interface {
+	// Put: store image content under key, existing image with the same key is replaced
+	Put(ctx context.Context, key string, contentType string, content io.Reader, size int64) error
+	// Get: open stored image, caller must close model.ImageObject.Content. will return ErrImageNotFound if key not exist
+	Get(ctx context.Context, key string) (*model.ImageObject, error)
+	// Delete: remove stored image, deleting not exist key is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// LocalImageStore: ImageStore on local filesystem, key is relative path under baseDir
+type LocalImageStore struct {
+	baseDir string
+}
+
+func NewLocalImageStore(baseDir string) ImageStore {
+	if baseDir == "" {
+		logrus.Panic("baseDir param for NewLocalImageStore is empty")
+	}
+	return &LocalImageStore{
+		baseDir: baseDir,
+	}
+}
+
+// filePath: resolve key into file path, invalid key or key escaping baseDir is rejected as ErrImageNotFound
+// since no image can be stored under it
+func (s *LocalImageStore) filePath(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", fmt.Errorf("%w: invalid image key '%s'", ErrImageNotFound, key)
+	}
+	return filepath.Join(s.baseDir, filepath.FromSlash(strings.TrimPrefix(cleaned, "/"))), nil
+}
+
+func (s *LocalImageStore) Put(ctx context.Context, key string, contentType string, content io.Reader, size int64) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(filePath), 0o755)
+	if err != nil {
+		return err
+	}
+	// write into temporary file then rename so reader never see partial image
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.Copy(tmp, content)
+	if errClose := tmp.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmp.Name(), 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
+
+func (s *LocalImageStore) Get(ctx context.Context, key string) (*model.ImageObject, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		f.Close()
+		return nil, ErrImageNotFound
+	}
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &model.ImageObject{
+		Content:     f,
+		ContentType: contentType,
+		Size:        info.Size(),
+	}, nil
+}
+
+func (s *LocalImageStore) Delete(ctx context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if errors.Is(err, ErrImageNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalImageStore(t *testing.T) {
+	store := NewLocalImageStore(t.TempDir())
+	ctx := context.TODO()
+	content := []byte("\x89PNG image content")
+	key := "cakes/1/abcd.png"
+
+	err := store.Put(ctx, key, "image/png", bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+
+	object, err := store.Get(ctx, key)
+	if assert.NoError(t, err) {
+		got, _ := io.ReadAll(object.Content)
+		object.Content.Close()
+		assert.EqualValues(t, content, got)
+		assert.EqualValues(t, "image/png", object.ContentType)
+		assert.EqualValues(t, len(content), object.Size)
+	}
+
+	assert.NoError(t, store.Delete(ctx, key))
+	assert.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrImageNotFound)
+}
+
+func TestLocalImageStore_InvalidKey(t *testing.T) {
+	store := NewLocalImageStore(t.TempDir())
+	ctx := context.TODO()
+	for _, key := range []string{"../secret.png", "cakes/../../secret.png", "/cakes/1.png", "", "cakes//1.png"} {
+		err := store.Put(ctx, key, "image/png", bytes.NewReader(nil), 0)
+		assert.ErrorIs(t, err, ErrImageNotFound, key)
+		_, err = store.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrImageNotFound, key)
+		assert.NoError(t, store.Delete(ctx, key), key)
+	}
+}
@@ -0,0 +1,201 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package storage
+
+import (
+	"context"
+	"github.com/forderation/ralali-test/internal/model"
+	"io"
+	"sync"
+)
+
+// Ensure, that ImageStoreMock does implement ImageStore.
+// If this is not the case, regenerate this file with moq.
+var _ ImageStore = &ImageStoreMock{}
+
+// ImageStoreMock is a mock implementation of ImageStore.
+//
+//	func TestSomethingThatUsesImageStore(t *testing.T) {
+//
+//		// make and configure a mocked ImageStore
+//		mockedImageStore := &ImageStoreMock{
+//			DeleteFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the Delete method")
+//			},
+//			GetFunc: func(ctx context.Context, key string) (*model.ImageObject, error) {
+//				panic("mock out the Get method")
+//			},
+//			PutFunc: func(ctx context.Context, key string, contentType string, content io.Reader, size int64) error {
+//				panic("mock out the Put method")
+//			},
+//		}
+//
+//		// use mockedImageStore in code that requires ImageStore
+//		// and then make assertions.
+//
+//	}
+type ImageStoreMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, key string) error
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, key string) (*model.ImageObject, error)
+
+	// PutFunc mocks the Put method.
+	PutFunc func(ctx context.Context, key string, contentType string, content io.Reader, size int64) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Put holds details about calls to the Put method.
+		Put []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// ContentType is the contentType argument value.
+			ContentType string
+			// Content is the content argument value.
+			Content io.Reader
+			// Size is the size argument value.
+			Size int64
+		}
+	}
+	lockDelete sync.RWMutex
+	lockGet    sync.RWMutex
+	lockPut    sync.RWMutex
+}
+
+// Delete calls DeleteFunc.
+func (mock *ImageStoreMock) Delete(ctx context.Context, key string) error {
+	if mock.DeleteFunc == nil {
+		panic("ImageStoreMock.DeleteFunc: method is nil but ImageStore.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, key)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedImageStore.DeleteCalls())
+func (mock *ImageStoreMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *ImageStoreMock) Get(ctx context.Context, key string) (*model.ImageObject, error) {
+	if mock.GetFunc == nil {
+		panic("ImageStoreMock.GetFunc: method is nil but ImageStore.Get was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, key)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedImageStore.GetCalls())
+func (mock *ImageStoreMock) GetCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// Put calls PutFunc.
+func (mock *ImageStoreMock) Put(ctx context.Context, key string, contentType string, content io.Reader, size int64) error {
+	if mock.PutFunc == nil {
+		panic("ImageStoreMock.PutFunc: method is nil but ImageStore.Put was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Key         string
+		ContentType string
+		Content     io.Reader
+		Size        int64
+	}{
+		Ctx:         ctx,
+		Key:         key,
+		ContentType: contentType,
+		Content:     content,
+		Size:        size,
+	}
+	mock.lockPut.Lock()
+	mock.calls.Put = append(mock.calls.Put, callInfo)
+	mock.lockPut.Unlock()
+	return mock.PutFunc(ctx, key, contentType, content, size)
+}
+
+// PutCalls gets all the calls that were made to Put.
+// Check the length with:
+//
+//	len(mockedImageStore.PutCalls())
+func (mock *ImageStoreMock) PutCalls() []struct {
+	Ctx         context.Context
+	Key         string
+	ContentType string
+	Content     io.Reader
+	Size        int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Key         string
+		ContentType string
+		Content     io.Reader
+		Size        int64
+	}
+	mock.lockPut.RLock()
+	calls = mock.calls.Put
+	mock.lockPut.RUnlock()
+	return calls
+}
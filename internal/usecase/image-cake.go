@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/storage"
+	"github.com/forderation/ralali-test/util"
+	"github.com/sirupsen/logrus"
+	_ "golang.org/x/image/webp"
+)
+
+// maxImageVariantPixels: larger image is not decoded to bound memory used on resizing
+const maxImageVariantPixels = 50000000
+
+// imageVariantWidths: width in pixel of resized variants generated for each stored image
+var imageVariantWidths = []int{128, 512, 1024}
+
+type imageVariantFormat struct {
+	name        string
+	contentType string
+	extension   string
+	encode      func(w io.Writer, img image.Image) error
+}
+
+// imageVariantFormats: encoding of generated variants, JPEG has no alpha channel so transparent area is flattened
+var imageVariantFormats = []imageVariantFormat{
+	{
+		name:        "jpeg",
+		contentType: "image/jpeg",
+		extension:   ".jpg",
+		encode: func(w io.Writer, img image.Image) error {
+			return jpeg.Encode(w, util.FlattenImage(img), &jpeg.Options{Quality: 82})
+		},
+	},
+}
+
+// imageVariantKey: key of variant is derived from key of original image e.g. cakes/1/0f1e.png_512.jpg
+func imageVariantKey(key string, width int, format imageVariantFormat) string {
+	return fmt.Sprintf("%s_%d%s", key, width, format.extension)
+}
+
+// imageVariantSource: resolve key of original image from key of variant, false when key is not a variant
+func imageVariantSource(key string) (string, bool) {
+	for _, format := range imageVariantFormats {
+		name, ok := strings.CutSuffix(key, format.extension)
+		if !ok {
+			continue
+		}
+		index := strings.LastIndex(name, "_")
+		if index < 0 {
+			continue
+		}
+		width, err := strconv.Atoi(name[index+1:])
+		if err != nil {
+			continue
+		}
+		for _, variantWidth := range imageVariantWidths {
+			if width == variantWidth {
+				return name[:index], true
+			}
+		}
+	}
+	return "", false
+}
+
+// imageKey: key of image url on image store, false when image is hosted elsewhere
+func (uc *CakeUsecase) imageKey(imageURL string) (string, bool) {
+	if uc.imageBaseURL == "" {
+		return "", false
+	}
+	key, ok := strings.CutPrefix(imageURL, uc.imageBaseURL+"/")
+	return key, ok && key != ""
+}
+
+func (uc *CakeUsecase) mapCakeImagesResponse(imageURL string) *model.CakeImagesResponse {
+	key, stored := uc.imageKey(imageURL)
+	response := &model.CakeImagesResponse{
+		Original: imageURL,
+		Variants: make([]model.CakeImageVariantResponse, 0, len(imageVariantWidths)*len(imageVariantFormats)),
+	}
+	for _, width := range imageVariantWidths {
+		for _, format := range imageVariantFormats {
+			url := imageURL
+			if stored {
+				url = uc.imageBaseURL + "/" + imageVariantKey(key, width, format)
+			}
+			response.Variants = append(response.Variants, model.CakeImageVariantResponse{
+				Width:  width,
+				Format: format.name,
+				URL:    url,
+			})
+		}
+	}
+	return response
+}
+
+// scheduleImageVariants: queue generation of variants without blocking the request,
+// image dropped from full queue can be generated later through RegenerateImageVariants
+func (uc *CakeUsecase) scheduleImageVariants(key string) {
+	if uc.imageVariantJobs == nil {
+		return
+	}
+	select {
+	case uc.imageVariantJobs <- key:
+	default:
+		logrus.Warnf("image variant queue is full, skip generate variants of '%s'", key)
+	}
+}
+
+// GenerateImageVariants: resize stored image into every variant width and format. it is idempotent since variant key
+// is derived from original key, existing variants are skipped unless force is set. return true when variants are written
+func (uc *CakeUsecase) GenerateImageVariants(ctx context.Context, key string, force bool) (bool, *model.ErrorResponse) {
+	if !force && uc.imageVariantsExist(ctx, key) {
+		return false, nil
+	}
+	original, err := uc.imageStore.Get(ctx, key)
+	if errors.Is(err, storage.ErrImageNotFound) {
+		return false, &model.ErrorResponse{
+			Kind: model.ErrImageNotFound,
+			Err:  errors.New("image not found"),
+			Code: model.ErrCodeImageNotFound,
+		}
+	}
+	if err != nil {
+		return false, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error get image"),
+			Code: model.ErrCodeGetImageFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	content, err := io.ReadAll(original.Content)
+	original.Content.Close()
+	if err != nil {
+		return false, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error read image"),
+			Code: model.ErrCodeGetImageFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return false, &model.ErrorResponse{
+			Kind:   model.ErrUnsupported,
+			Err:    fmt.Errorf("cannot resize image '%s': %w", key, err),
+			Code:   model.ErrCodeImageDecodeFailed,
+			Params: map[string]interface{}{"key": key},
+		}
+	}
+	if config.Width*config.Height > maxImageVariantPixels {
+		return false, &model.ErrorResponse{
+			Kind:   model.ErrValidation,
+			Err:    fmt.Errorf("image '%s' is too large to resize", key),
+			Code:   model.ErrCodeImageTooLargeToResize,
+			Params: map[string]interface{}{"key": key},
+		}
+	}
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return false, &model.ErrorResponse{
+			Kind:   model.ErrUnsupported,
+			Err:    fmt.Errorf("cannot resize image '%s': %w", key, err),
+			Code:   model.ErrCodeImageDecodeFailed,
+			Params: map[string]interface{}{"key": key},
+		}
+	}
+	for _, width := range imageVariantWidths {
+		resized := util.ResizeImage(img, width)
+		for _, format := range imageVariantFormats {
+			buffer := &bytes.Buffer{}
+			err = format.encode(buffer, resized)
+			if err == nil {
+				err = uc.imageStore.Put(ctx, imageVariantKey(key, width, format), format.contentType, buffer, int64(buffer.Len()))
+			}
+			if err != nil {
+				return false, &model.ErrorResponse{
+					Kind: model.ErrInternal,
+					Err:  errors.New("error store image variant"),
+					Code: model.ErrCodeStoreImageVariantFailed,
+					ErrData: model.ErrorDetailResponse{
+						Detail: err.Error(),
+					},
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+func (uc *CakeUsecase) imageVariantsExist(ctx context.Context, key string) bool {
+	for _, width := range imageVariantWidths {
+		for _, format := range imageVariantFormats {
+			variant, err := uc.imageStore.Get(ctx, imageVariantKey(key, width, format))
+			if err != nil {
+				return false
+			}
+			variant.Content.Close()
+		}
+	}
+	return true
+}
+
+// RegenerateImageVariants: generate missing variants of every stored cake image, failed image is counted and skipped
+func (uc *CakeUsecase) RegenerateImageVariants(ctx context.Context, param model.RegenerateImageVariantsParam) (*model.RegenerateImageVariantsResponse, *model.ErrorResponse) {
+	hasImage := true
+	keys := []string{}
+	// collect keys first so the query is not kept open while resizing
+	err := uc.dbCakeRepository.StreamCakes(ctx, model.StreamCakesQuery{
+		Filter: model.CakesFilterQuery{
+			HasImage: &hasImage,
+		},
+	}, func(cake model.Cake) error {
+		if cake.Image == nil {
+			return nil
+		}
+		if key, ok := uc.imageKey(*cake.Image); ok {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error get data cakes"),
+			Code: model.ErrCodeRegenerateImagesFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	response := &model.RegenerateImageVariantsResponse{
+		Images: len(keys),
+	}
+	for _, key := range keys {
+		generated, errResponse := uc.GenerateImageVariants(ctx, key, param.Force)
+		if ctx.Err() != nil {
+			return nil, &model.ErrorResponse{
+				Kind: model.ErrInternal,
+				Err:  errors.New("image variant regeneration is cancelled"),
+				Code: model.ErrCodeRegenerateImageCancelled,
+				ErrData: model.ErrorDetailResponse{
+					Detail: ctx.Err().Error(),
+				},
+			}
+		}
+		if errResponse != nil {
+			logrus.Warnf("error generate variants of image '%s': %s %v", key, errResponse.Err, errResponse.ErrData)
+			response.Failed++
+			continue
+		}
+		if generated {
+			response.Generated++
+		} else {
+			response.Skipped++
+		}
+	}
+	return response, nil
+}
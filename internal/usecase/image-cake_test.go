@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/repository"
+	"github.com/forderation/ralali-test/internal/storage"
+	"gopkg.in/guregu/null.v4"
+)
+
+// newMemoryImageStoreMock: image store mock keeping stored content in map
+func newMemoryImageStoreMock(images map[string][]byte) *storage.ImageStoreMock {
+	var mu sync.Mutex
+	return &storage.ImageStoreMock{
+		PutFunc: func(ctx context.Context, key string, contentType string, content io.Reader, size int64) error {
+			data, err := io.ReadAll(content)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			images[key] = data
+			return nil
+		},
+		GetFunc: func(ctx context.Context, key string) (*model.ImageObject, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			data, ok := images[key]
+			if !ok {
+				return nil, storage.ErrImageNotFound
+			}
+			return &model.ImageObject{
+				Content:     io.NopCloser(bytes.NewReader(data)),
+				ContentType: http.DetectContentType(data),
+				Size:        int64(len(data)),
+			}, nil
+		},
+	}
+}
+
+func mockPNG(t *testing.T, width int, height int) []byte {
+	buffer := &bytes.Buffer{}
+	err := png.Encode(buffer, image.NewNRGBA(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected", err)
+	}
+	return buffer.Bytes()
+}
+
+// mockWebP: lossy WebP image of 150x100 pixel
+func mockWebP(t *testing.T) []byte {
+	content, err := os.ReadFile("testdata/cake.webp")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected", err)
+	}
+	return content
+}
+
+func Test_imageVariantSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "basic test",
+			key:    "cakes/1/0f1e.png_512.jpg",
+			want:   "cakes/1/0f1e.png",
+			wantOk: true,
+		},
+		{
+			name: "original image",
+			key:  "cakes/1/0f1e.jpg",
+		},
+		{
+			name: "unknown width",
+			key:  "cakes/1/0f1e.png_300.jpg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := imageVariantSource(tt.key)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("imageVariantSource() = %v, %v, want %v, %v", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_GenerateImageVariants(t *testing.T) {
+	tests := []struct {
+		name          string
+		images        map[string][]byte
+		key           string
+		force         bool
+		want          bool
+		wantErr       error
+		wantVariants  map[string]image.Point
+		wantUnchanged string
+	}{
+		{
+			name: "basic test",
+			images: map[string][]byte{
+				"cakes/1/0f1e.png": mockPNG(t, 800, 400),
+			},
+			key:  "cakes/1/0f1e.png",
+			want: true,
+			wantVariants: map[string]image.Point{
+				"cakes/1/0f1e.png_128.jpg":  {X: 128, Y: 64},
+				"cakes/1/0f1e.png_512.jpg":  {X: 512, Y: 256},
+				"cakes/1/0f1e.png_1024.jpg": {X: 800, Y: 400},
+			},
+		},
+		{
+			name: "webp original",
+			images: map[string][]byte{
+				"cakes/1/0f1e.webp": mockWebP(t),
+			},
+			key:  "cakes/1/0f1e.webp",
+			want: true,
+			wantVariants: map[string]image.Point{
+				"cakes/1/0f1e.webp_128.jpg": {X: 128, Y: 85},
+				"cakes/1/0f1e.webp_512.jpg": {X: 150, Y: 100},
+			},
+		},
+		{
+			name: "existing variants are skipped",
+			images: map[string][]byte{
+				"cakes/1/0f1e.png":          mockPNG(t, 800, 400),
+				"cakes/1/0f1e.png_128.jpg":  []byte("variant"),
+				"cakes/1/0f1e.png_512.jpg":  []byte("variant"),
+				"cakes/1/0f1e.png_1024.jpg": []byte("variant"),
+			},
+			key:           "cakes/1/0f1e.png",
+			want:          false,
+			wantUnchanged: "cakes/1/0f1e.png_512.jpg",
+		},
+		{
+			name: "force regenerate existing variants",
+			images: map[string][]byte{
+				"cakes/1/0f1e.png":          mockPNG(t, 800, 400),
+				"cakes/1/0f1e.png_128.jpg":  []byte("variant"),
+				"cakes/1/0f1e.png_512.jpg":  []byte("variant"),
+				"cakes/1/0f1e.png_1024.jpg": []byte("variant"),
+			},
+			key:   "cakes/1/0f1e.png",
+			force: true,
+			want:  true,
+			wantVariants: map[string]image.Point{
+				"cakes/1/0f1e.png_512.jpg": {X: 512, Y: 256},
+			},
+		},
+		{
+			name:    "image not found",
+			images:  map[string][]byte{},
+			key:     "cakes/1/0f1e.png",
+			wantErr: model.ErrImageNotFound,
+		},
+		{
+			name: "undecodable image",
+			images: map[string][]byte{
+				"cakes/1/0f1e.webp": []byte("RIFF\x00\x00\x00\x00WEBPVP8 "),
+			},
+			key:     "cakes/1/0f1e.webp",
+			wantErr: model.ErrUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &CakeUsecase{
+				imageStore: newMemoryImageStoreMock(tt.images),
+			}
+			got, errResponse := uc.GenerateImageVariants(context.TODO(), tt.key, tt.force)
+			if (errResponse != nil) != (tt.wantErr != nil) || (errResponse != nil && !errors.Is(errResponse, tt.wantErr)) {
+				t.Errorf("CakeUsecase.GenerateImageVariants() error = %v, want %v", errResponse, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CakeUsecase.GenerateImageVariants() = %v, want %v", got, tt.want)
+			}
+			for key, wantSize := range tt.wantVariants {
+				variant, _, err := image.Decode(bytes.NewReader(tt.images[key]))
+				if err != nil {
+					t.Errorf("CakeUsecase.GenerateImageVariants() variant '%s' is not decodable: %s", key, err)
+					continue
+				}
+				if variant.Bounds().Size() != wantSize {
+					t.Errorf("CakeUsecase.GenerateImageVariants() variant '%s' size = %v, want %v", key, variant.Bounds().Size(), wantSize)
+				}
+			}
+			if tt.wantUnchanged != "" && string(tt.images[tt.wantUnchanged]) != "variant" {
+				t.Errorf("CakeUsecase.GenerateImageVariants() variant '%s' is rewritten", tt.wantUnchanged)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_RegenerateImageVariants(t *testing.T) {
+	images := map[string][]byte{
+		"cakes/1/0f1e.png": mockPNG(t, 200, 100),
+		"cakes/2/1a2b.png": mockPNG(t, 200, 100),
+		"cakes/3/2c3d.gif": []byte("broken"),
+	}
+	uc := &CakeUsecase{
+		imageStore:   newMemoryImageStoreMock(images),
+		imageBaseURL: "http://127.0.0.1:8081/images",
+	}
+	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockCakeRepo.StreamCakesFunc = func(ctx context.Context, param model.StreamCakesQuery, fn func(cake model.Cake) error) error {
+		if param.Filter.HasImage == nil || !*param.Filter.HasImage {
+			t.Errorf("StreamCakes() filter has_image = %v, want true", param.Filter.HasImage)
+		}
+		for _, image := range []string{
+			"http://127.0.0.1:8081/images/cakes/1/0f1e.png",
+			"http://127.0.0.1:8081/images/cakes/2/1a2b.png",
+			"http://127.0.0.1:8081/images/cakes/3/2c3d.gif",
+			"https://cdn.example.com/cake.png",
+		} {
+			if err := fn(model.Cake{Image: null.StringFrom(image).Ptr()}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	uc.dbCakeRepository = mockCakeRepo
+	// variants of the second image already exist
+	_, errResponse := uc.GenerateImageVariants(context.TODO(), "cakes/2/1a2b.png", false)
+	if errResponse != nil {
+		t.Fatalf("an error '%s' was not expected", errResponse.Err)
+	}
+	got, errResponse := uc.RegenerateImageVariants(context.TODO(), model.RegenerateImageVariantsParam{})
+	if errResponse != nil {
+		t.Fatalf("CakeUsecase.RegenerateImageVariants() error = %v", errResponse.Err)
+	}
+	want := &model.RegenerateImageVariantsResponse{
+		Images:    3,
+		Generated: 1,
+		Skipped:   1,
+		Failed:    1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CakeUsecase.RegenerateImageVariants() = %v, want %v", got, want)
+	}
+
+	mockCakeRepo.StreamCakesFunc = func(ctx context.Context, param model.StreamCakesQuery, fn func(cake model.Cake) error) error {
+		return errors.New("error mock")
+	}
+	_, errResponse = uc.RegenerateImageVariants(context.TODO(), model.RegenerateImageVariantsParam{})
+	if errResponse == nil || !errors.Is(errResponse, model.ErrInternal) {
+		t.Errorf("CakeUsecase.RegenerateImageVariants() error = %v, want internal error", errResponse)
+	}
+}
+
+func TestCakeUsecase_GetImage_variantFallback(t *testing.T) {
+	images := map[string][]byte{
+		"cakes/1/0f1e.png":         mockPNG(t, 10, 10),
+		"cakes/1/0f1e.png_128.jpg": []byte("variant"),
+	}
+	uc := &CakeUsecase{
+		imageStore: newMemoryImageStoreMock(images),
+	}
+	tests := []struct {
+		name    string
+		key     string
+		wantKey string
+		wantErr error
+	}{
+		{
+			name:    "generated variant",
+			key:     "cakes/1/0f1e.png_128.jpg",
+			wantKey: "cakes/1/0f1e.png_128.jpg",
+		},
+		{
+			name:    "missing variant falls back to original",
+			key:     "cakes/1/0f1e.png_512.jpg",
+			wantKey: "cakes/1/0f1e.png",
+		},
+		{
+			name:    "missing original",
+			key:     "cakes/2/0f1e.png_512.jpg",
+			wantErr: model.ErrImageNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errResponse := uc.GetImage(context.TODO(), tt.key)
+			if (errResponse != nil) != (tt.wantErr != nil) || (errResponse != nil && !errors.Is(errResponse, tt.wantErr)) {
+				t.Errorf("CakeUsecase.GetImage() error = %v, want %v", errResponse, tt.wantErr)
+			}
+			gotKey := ""
+			if got != nil {
+				gotKey = got.Key
+			}
+			if gotKey != tt.wantKey {
+				t.Errorf("CakeUsecase.GetImage() key = %v, want %v", gotKey, tt.wantKey)
+			}
+		})
+	}
+}
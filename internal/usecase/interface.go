@@ -6,11 +6,34 @@ import (
 	"github.com/forderation/ralali-test/internal/model"
 )
 
-//go:generate moq -out mock_interface.go . CakeUsecaseInterface
+//go:generate moq -out mock_interface.go . CakeUsecaseInterface ApiKeyUsecaseInterface
 type CakeUsecaseInterface interface {
-	DeleteCake(ctx context.Context, id int) (*model.CakeDeleteResponse, *model.ErrorResponse)
-	UpdateCake(ctx context.Context, id int, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse)
-	CreateCake(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse)
+	DeleteCake(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse)
+	UpdateCake(ctx context.Context, id int, version int, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse)
+	PatchCake(ctx context.Context, id int, version int, payload model.CakePatchQuery) (*model.CakeResponse, *model.ErrorResponse)
+	UploadCakeImage(ctx context.Context, id int, version int, param model.UploadCakeImageParam) (*model.CakeResponse, *model.ErrorResponse)
+	GetImage(ctx context.Context, key string) (*model.ImageObject, *model.ErrorResponse)
+	GenerateImageVariants(ctx context.Context, key string, force bool) (bool, *model.ErrorResponse)
+	RegenerateImageVariants(ctx context.Context, param model.RegenerateImageVariantsParam) (*model.RegenerateImageVariantsResponse, *model.ErrorResponse)
+	CreateCake(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse)
 	GetDetailCake(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse)
 	GetCakes(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse)
+	// GetCakesModified: validator of cakes listing matching filter, cheaper than GetCakes so conditional request is answered without it
+	GetCakesModified(ctx context.Context, filter model.CakesFilterQuery) (*model.CakesModified, *model.ErrorResponse)
+	GetDeletedCakes(ctx context.Context, param model.GetDeletedCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse)
+	RestoreCake(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse)
+	BatchCakes(ctx context.Context, param model.BatchCakesUsecaseParam) (*model.BatchCakesResponse, *model.ErrorResponse)
+	ExportCakes(ctx context.Context, param model.ExportCakesUsecaseParam, fn func(cake model.CakeResponse) error) *model.ErrorResponse
+	ImportCakes(ctx context.Context, param model.ImportCakesUsecaseParam) (*model.ImportCakesResponse, *model.ErrorResponse)
+	PurgeDeletedCakes(ctx context.Context, param model.PurgeCakesUsecaseParam) (*model.PurgeCakesResponse, *model.ErrorResponse)
+}
+
+type ApiKeyUsecaseInterface interface {
+	// CreateApiKey: create api key with random secret, the secret is only returned here and stored as hash
+	CreateApiKey(ctx context.Context, param model.CreateApiKeyUsecaseParam) (*model.CreateApiKeyResponse, *model.ErrorResponse)
+	GetApiKeys(ctx context.Context) (*model.ApiKeysResponse, *model.ErrorResponse)
+	// RevokeApiKey: revoked key cannot authenticate anymore, revoking revoked key return it as it is
+	RevokeApiKey(ctx context.Context, id int) (*model.ApiKeyResponse, *model.ErrorResponse)
+	// AuthenticateApiKey: active key of X-API-Key header, last used time is recorded on success
+	AuthenticateApiKey(ctx context.Context, key string) (*model.AuthenticatedApiKey, *model.ErrorResponse)
 }
@@ -19,21 +19,57 @@ var _ CakeUsecaseInterface = &CakeUsecaseInterfaceMock{}
 //
 //		// make and configure a mocked CakeUsecaseInterface
 //		mockedCakeUsecaseInterface := &CakeUsecaseInterfaceMock{
-//			CreateCakeFunc: func(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse) {
+//			BatchCakesFunc: func(ctx context.Context, param model.BatchCakesUsecaseParam) (*model.BatchCakesResponse, *model.ErrorResponse) {
+//				panic("mock out the BatchCakes method")
+//			},
+//			CreateCakeFunc: func(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
 //				panic("mock out the CreateCake method")
 //			},
-//			DeleteCakeFunc: func(ctx context.Context, id int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
+//			DeleteCakeFunc: func(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
 //				panic("mock out the DeleteCake method")
 //			},
+//			ExportCakesFunc: func(ctx context.Context, param model.ExportCakesUsecaseParam, fn func(cake model.CakeResponse) error) *model.ErrorResponse {
+//				panic("mock out the ExportCakes method")
+//			},
+//			GenerateImageVariantsFunc: func(ctx context.Context, key string, force bool) (bool, *model.ErrorResponse) {
+//				panic("mock out the GenerateImageVariants method")
+//			},
 //			GetCakesFunc: func(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
 //				panic("mock out the GetCakes method")
 //			},
+//			GetCakesModifiedFunc: func(ctx context.Context, filter model.CakesFilterQuery) (*model.CakesModified, *model.ErrorResponse) {
+//				panic("mock out the GetCakesModified method")
+//			},
+//			GetDeletedCakesFunc: func(ctx context.Context, param model.GetDeletedCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+//				panic("mock out the GetDeletedCakes method")
+//			},
 //			GetDetailCakeFunc: func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
 //				panic("mock out the GetDetailCake method")
 //			},
-//			UpdateCakeFunc: func(ctx context.Context, id int, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse) {
+//			GetImageFunc: func(ctx context.Context, key string) (*model.ImageObject, *model.ErrorResponse) {
+//				panic("mock out the GetImage method")
+//			},
+//			ImportCakesFunc: func(ctx context.Context, param model.ImportCakesUsecaseParam) (*model.ImportCakesResponse, *model.ErrorResponse) {
+//				panic("mock out the ImportCakes method")
+//			},
+//			PatchCakeFunc: func(ctx context.Context, id int, version int, payload model.CakePatchQuery) (*model.CakeResponse, *model.ErrorResponse) {
+//				panic("mock out the PatchCake method")
+//			},
+//			PurgeDeletedCakesFunc: func(ctx context.Context, param model.PurgeCakesUsecaseParam) (*model.PurgeCakesResponse, *model.ErrorResponse) {
+//				panic("mock out the PurgeDeletedCakes method")
+//			},
+//			RegenerateImageVariantsFunc: func(ctx context.Context, param model.RegenerateImageVariantsParam) (*model.RegenerateImageVariantsResponse, *model.ErrorResponse) {
+//				panic("mock out the RegenerateImageVariants method")
+//			},
+//			RestoreCakeFunc: func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+//				panic("mock out the RestoreCake method")
+//			},
+//			UpdateCakeFunc: func(ctx context.Context, id int, version int, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
 //				panic("mock out the UpdateCake method")
 //			},
+//			UploadCakeImageFunc: func(ctx context.Context, id int, version int, param model.UploadCakeImageParam) (*model.CakeResponse, *model.ErrorResponse) {
+//				panic("mock out the UploadCakeImage method")
+//			},
 //		}
 //
 //		// use mockedCakeUsecaseInterface in code that requires CakeUsecaseInterface
@@ -41,23 +77,66 @@ var _ CakeUsecaseInterface = &CakeUsecaseInterfaceMock{}
 //
 //	}
 type CakeUsecaseInterfaceMock struct {
+	// BatchCakesFunc mocks the BatchCakes method.
+	BatchCakesFunc func(ctx context.Context, param model.BatchCakesUsecaseParam) (*model.BatchCakesResponse, *model.ErrorResponse)
+
 	// CreateCakeFunc mocks the CreateCake method.
-	CreateCakeFunc func(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse)
+	CreateCakeFunc func(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse)
 
 	// DeleteCakeFunc mocks the DeleteCake method.
-	DeleteCakeFunc func(ctx context.Context, id int) (*model.CakeDeleteResponse, *model.ErrorResponse)
+	DeleteCakeFunc func(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse)
+
+	// ExportCakesFunc mocks the ExportCakes method.
+	ExportCakesFunc func(ctx context.Context, param model.ExportCakesUsecaseParam, fn func(cake model.CakeResponse) error) *model.ErrorResponse
+
+	// GenerateImageVariantsFunc mocks the GenerateImageVariants method.
+	GenerateImageVariantsFunc func(ctx context.Context, key string, force bool) (bool, *model.ErrorResponse)
 
 	// GetCakesFunc mocks the GetCakes method.
 	GetCakesFunc func(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse)
 
+	// GetCakesModifiedFunc mocks the GetCakesModified method.
+	GetCakesModifiedFunc func(ctx context.Context, filter model.CakesFilterQuery) (*model.CakesModified, *model.ErrorResponse)
+
+	// GetDeletedCakesFunc mocks the GetDeletedCakes method.
+	GetDeletedCakesFunc func(ctx context.Context, param model.GetDeletedCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse)
+
 	// GetDetailCakeFunc mocks the GetDetailCake method.
 	GetDetailCakeFunc func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse)
 
+	// GetImageFunc mocks the GetImage method.
+	GetImageFunc func(ctx context.Context, key string) (*model.ImageObject, *model.ErrorResponse)
+
+	// ImportCakesFunc mocks the ImportCakes method.
+	ImportCakesFunc func(ctx context.Context, param model.ImportCakesUsecaseParam) (*model.ImportCakesResponse, *model.ErrorResponse)
+
+	// PatchCakeFunc mocks the PatchCake method.
+	PatchCakeFunc func(ctx context.Context, id int, version int, payload model.CakePatchQuery) (*model.CakeResponse, *model.ErrorResponse)
+
+	// PurgeDeletedCakesFunc mocks the PurgeDeletedCakes method.
+	PurgeDeletedCakesFunc func(ctx context.Context, param model.PurgeCakesUsecaseParam) (*model.PurgeCakesResponse, *model.ErrorResponse)
+
+	// RegenerateImageVariantsFunc mocks the RegenerateImageVariants method.
+	RegenerateImageVariantsFunc func(ctx context.Context, param model.RegenerateImageVariantsParam) (*model.RegenerateImageVariantsResponse, *model.ErrorResponse)
+
+	// RestoreCakeFunc mocks the RestoreCake method.
+	RestoreCakeFunc func(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse)
+
 	// UpdateCakeFunc mocks the UpdateCake method.
-	UpdateCakeFunc func(ctx context.Context, id int, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse)
+	UpdateCakeFunc func(ctx context.Context, id int, version int, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse)
+
+	// UploadCakeImageFunc mocks the UploadCakeImage method.
+	UploadCakeImageFunc func(ctx context.Context, id int, version int, param model.UploadCakeImageParam) (*model.CakeResponse, *model.ErrorResponse)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// BatchCakes holds details about calls to the BatchCakes method.
+		BatchCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.BatchCakesUsecaseParam
+		}
 		// CreateCake holds details about calls to the CreateCake method.
 		CreateCake []struct {
 			// Ctx is the ctx argument value.
@@ -71,6 +150,26 @@ type CakeUsecaseInterfaceMock struct {
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int
+			// Version is the version argument value.
+			Version int
+		}
+		// ExportCakes holds details about calls to the ExportCakes method.
+		ExportCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.ExportCakesUsecaseParam
+			// Fn is the fn argument value.
+			Fn func(cake model.CakeResponse) error
+		}
+		// GenerateImageVariants holds details about calls to the GenerateImageVariants method.
+		GenerateImageVariants []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Force is the force argument value.
+			Force bool
 		}
 		// GetCakes holds details about calls to the GetCakes method.
 		GetCakes []struct {
@@ -79,6 +178,20 @@ type CakeUsecaseInterfaceMock struct {
 			// Param is the param argument value.
 			Param model.GetCakesUsecaseParam
 		}
+		// GetCakesModified holds details about calls to the GetCakesModified method.
+		GetCakesModified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter model.CakesFilterQuery
+		}
+		// GetDeletedCakes holds details about calls to the GetDeletedCakes method.
+		GetDeletedCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.GetDeletedCakesUsecaseParam
+		}
 		// GetDetailCake holds details about calls to the GetDetailCake method.
 		GetDetailCake []struct {
 			// Ctx is the ctx argument value.
@@ -86,25 +199,132 @@ type CakeUsecaseInterfaceMock struct {
 			// ID is the id argument value.
 			ID int
 		}
+		// GetImage holds details about calls to the GetImage method.
+		GetImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// ImportCakes holds details about calls to the ImportCakes method.
+		ImportCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.ImportCakesUsecaseParam
+		}
+		// PatchCake holds details about calls to the PatchCake method.
+		PatchCake []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+			// Version is the version argument value.
+			Version int
+			// Payload is the payload argument value.
+			Payload model.CakePatchQuery
+		}
+		// PurgeDeletedCakes holds details about calls to the PurgeDeletedCakes method.
+		PurgeDeletedCakes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.PurgeCakesUsecaseParam
+		}
+		// RegenerateImageVariants holds details about calls to the RegenerateImageVariants method.
+		RegenerateImageVariants []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.RegenerateImageVariantsParam
+		}
+		// RestoreCake holds details about calls to the RestoreCake method.
+		RestoreCake []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+		}
 		// UpdateCake holds details about calls to the UpdateCake method.
 		UpdateCake []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int
+			// Version is the version argument value.
+			Version int
 			// Payload is the payload argument value.
 			Payload model.CakePayloadQuery
 		}
+		// UploadCakeImage holds details about calls to the UploadCakeImage method.
+		UploadCakeImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+			// Version is the version argument value.
+			Version int
+			// Param is the param argument value.
+			Param model.UploadCakeImageParam
+		}
+	}
+	lockBatchCakes              sync.RWMutex
+	lockCreateCake              sync.RWMutex
+	lockDeleteCake              sync.RWMutex
+	lockExportCakes             sync.RWMutex
+	lockGenerateImageVariants   sync.RWMutex
+	lockGetCakes                sync.RWMutex
+	lockGetCakesModified        sync.RWMutex
+	lockGetDeletedCakes         sync.RWMutex
+	lockGetDetailCake           sync.RWMutex
+	lockGetImage                sync.RWMutex
+	lockImportCakes             sync.RWMutex
+	lockPatchCake               sync.RWMutex
+	lockPurgeDeletedCakes       sync.RWMutex
+	lockRegenerateImageVariants sync.RWMutex
+	lockRestoreCake             sync.RWMutex
+	lockUpdateCake              sync.RWMutex
+	lockUploadCakeImage         sync.RWMutex
+}
+
+// BatchCakes calls BatchCakesFunc.
+func (mock *CakeUsecaseInterfaceMock) BatchCakes(ctx context.Context, param model.BatchCakesUsecaseParam) (*model.BatchCakesResponse, *model.ErrorResponse) {
+	if mock.BatchCakesFunc == nil {
+		panic("CakeUsecaseInterfaceMock.BatchCakesFunc: method is nil but CakeUsecaseInterface.BatchCakes was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.BatchCakesUsecaseParam
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockBatchCakes.Lock()
+	mock.calls.BatchCakes = append(mock.calls.BatchCakes, callInfo)
+	mock.lockBatchCakes.Unlock()
+	return mock.BatchCakesFunc(ctx, param)
+}
+
+// BatchCakesCalls gets all the calls that were made to BatchCakes.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.BatchCakesCalls())
+func (mock *CakeUsecaseInterfaceMock) BatchCakesCalls() []struct {
+	Ctx   context.Context
+	Param model.BatchCakesUsecaseParam
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.BatchCakesUsecaseParam
 	}
-	lockCreateCake    sync.RWMutex
-	lockDeleteCake    sync.RWMutex
-	lockGetCakes      sync.RWMutex
-	lockGetDetailCake sync.RWMutex
-	lockUpdateCake    sync.RWMutex
+	mock.lockBatchCakes.RLock()
+	calls = mock.calls.BatchCakes
+	mock.lockBatchCakes.RUnlock()
+	return calls
 }
 
 // CreateCake calls CreateCakeFunc.
-func (mock *CakeUsecaseInterfaceMock) CreateCake(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse) {
+func (mock *CakeUsecaseInterfaceMock) CreateCake(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
 	if mock.CreateCakeFunc == nil {
 		panic("CakeUsecaseInterfaceMock.CreateCakeFunc: method is nil but CakeUsecaseInterface.CreateCake was just called")
 	}
@@ -140,21 +360,23 @@ func (mock *CakeUsecaseInterfaceMock) CreateCakeCalls() []struct {
 }
 
 // DeleteCake calls DeleteCakeFunc.
-func (mock *CakeUsecaseInterfaceMock) DeleteCake(ctx context.Context, id int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
+func (mock *CakeUsecaseInterfaceMock) DeleteCake(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
 	if mock.DeleteCakeFunc == nil {
 		panic("CakeUsecaseInterfaceMock.DeleteCakeFunc: method is nil but CakeUsecaseInterface.DeleteCake was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  int
+		Ctx     context.Context
+		ID      int
+		Version int
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
 	}
 	mock.lockDeleteCake.Lock()
 	mock.calls.DeleteCake = append(mock.calls.DeleteCake, callInfo)
 	mock.lockDeleteCake.Unlock()
-	return mock.DeleteCakeFunc(ctx, id)
+	return mock.DeleteCakeFunc(ctx, id, version)
 }
 
 // DeleteCakeCalls gets all the calls that were made to DeleteCake.
@@ -162,12 +384,14 @@ func (mock *CakeUsecaseInterfaceMock) DeleteCake(ctx context.Context, id int) (*
 //
 //	len(mockedCakeUsecaseInterface.DeleteCakeCalls())
 func (mock *CakeUsecaseInterfaceMock) DeleteCakeCalls() []struct {
-	Ctx context.Context
-	ID  int
+	Ctx     context.Context
+	ID      int
+	Version int
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int
+		Ctx     context.Context
+		ID      int
+		Version int
 	}
 	mock.lockDeleteCake.RLock()
 	calls = mock.calls.DeleteCake
@@ -175,6 +399,86 @@ func (mock *CakeUsecaseInterfaceMock) DeleteCakeCalls() []struct {
 	return calls
 }
 
+// ExportCakes calls ExportCakesFunc.
+func (mock *CakeUsecaseInterfaceMock) ExportCakes(ctx context.Context, param model.ExportCakesUsecaseParam, fn func(cake model.CakeResponse) error) *model.ErrorResponse {
+	if mock.ExportCakesFunc == nil {
+		panic("CakeUsecaseInterfaceMock.ExportCakesFunc: method is nil but CakeUsecaseInterface.ExportCakes was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.ExportCakesUsecaseParam
+		Fn    func(cake model.CakeResponse) error
+	}{
+		Ctx:   ctx,
+		Param: param,
+		Fn:    fn,
+	}
+	mock.lockExportCakes.Lock()
+	mock.calls.ExportCakes = append(mock.calls.ExportCakes, callInfo)
+	mock.lockExportCakes.Unlock()
+	return mock.ExportCakesFunc(ctx, param, fn)
+}
+
+// ExportCakesCalls gets all the calls that were made to ExportCakes.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.ExportCakesCalls())
+func (mock *CakeUsecaseInterfaceMock) ExportCakesCalls() []struct {
+	Ctx   context.Context
+	Param model.ExportCakesUsecaseParam
+	Fn    func(cake model.CakeResponse) error
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.ExportCakesUsecaseParam
+		Fn    func(cake model.CakeResponse) error
+	}
+	mock.lockExportCakes.RLock()
+	calls = mock.calls.ExportCakes
+	mock.lockExportCakes.RUnlock()
+	return calls
+}
+
+// GenerateImageVariants calls GenerateImageVariantsFunc.
+func (mock *CakeUsecaseInterfaceMock) GenerateImageVariants(ctx context.Context, key string, force bool) (bool, *model.ErrorResponse) {
+	if mock.GenerateImageVariantsFunc == nil {
+		panic("CakeUsecaseInterfaceMock.GenerateImageVariantsFunc: method is nil but CakeUsecaseInterface.GenerateImageVariants was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Key   string
+		Force bool
+	}{
+		Ctx:   ctx,
+		Key:   key,
+		Force: force,
+	}
+	mock.lockGenerateImageVariants.Lock()
+	mock.calls.GenerateImageVariants = append(mock.calls.GenerateImageVariants, callInfo)
+	mock.lockGenerateImageVariants.Unlock()
+	return mock.GenerateImageVariantsFunc(ctx, key, force)
+}
+
+// GenerateImageVariantsCalls gets all the calls that were made to GenerateImageVariants.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.GenerateImageVariantsCalls())
+func (mock *CakeUsecaseInterfaceMock) GenerateImageVariantsCalls() []struct {
+	Ctx   context.Context
+	Key   string
+	Force bool
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Key   string
+		Force bool
+	}
+	mock.lockGenerateImageVariants.RLock()
+	calls = mock.calls.GenerateImageVariants
+	mock.lockGenerateImageVariants.RUnlock()
+	return calls
+}
+
 // GetCakes calls GetCakesFunc.
 func (mock *CakeUsecaseInterfaceMock) GetCakes(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
 	if mock.GetCakesFunc == nil {
@@ -211,6 +515,78 @@ func (mock *CakeUsecaseInterfaceMock) GetCakesCalls() []struct {
 	return calls
 }
 
+// GetCakesModified calls GetCakesModifiedFunc.
+func (mock *CakeUsecaseInterfaceMock) GetCakesModified(ctx context.Context, filter model.CakesFilterQuery) (*model.CakesModified, *model.ErrorResponse) {
+	if mock.GetCakesModifiedFunc == nil {
+		panic("CakeUsecaseInterfaceMock.GetCakesModifiedFunc: method is nil but CakeUsecaseInterface.GetCakesModified was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter model.CakesFilterQuery
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockGetCakesModified.Lock()
+	mock.calls.GetCakesModified = append(mock.calls.GetCakesModified, callInfo)
+	mock.lockGetCakesModified.Unlock()
+	return mock.GetCakesModifiedFunc(ctx, filter)
+}
+
+// GetCakesModifiedCalls gets all the calls that were made to GetCakesModified.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.GetCakesModifiedCalls())
+func (mock *CakeUsecaseInterfaceMock) GetCakesModifiedCalls() []struct {
+	Ctx    context.Context
+	Filter model.CakesFilterQuery
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter model.CakesFilterQuery
+	}
+	mock.lockGetCakesModified.RLock()
+	calls = mock.calls.GetCakesModified
+	mock.lockGetCakesModified.RUnlock()
+	return calls
+}
+
+// GetDeletedCakes calls GetDeletedCakesFunc.
+func (mock *CakeUsecaseInterfaceMock) GetDeletedCakes(ctx context.Context, param model.GetDeletedCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+	if mock.GetDeletedCakesFunc == nil {
+		panic("CakeUsecaseInterfaceMock.GetDeletedCakesFunc: method is nil but CakeUsecaseInterface.GetDeletedCakes was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.GetDeletedCakesUsecaseParam
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockGetDeletedCakes.Lock()
+	mock.calls.GetDeletedCakes = append(mock.calls.GetDeletedCakes, callInfo)
+	mock.lockGetDeletedCakes.Unlock()
+	return mock.GetDeletedCakesFunc(ctx, param)
+}
+
+// GetDeletedCakesCalls gets all the calls that were made to GetDeletedCakes.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.GetDeletedCakesCalls())
+func (mock *CakeUsecaseInterfaceMock) GetDeletedCakesCalls() []struct {
+	Ctx   context.Context
+	Param model.GetDeletedCakesUsecaseParam
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.GetDeletedCakesUsecaseParam
+	}
+	mock.lockGetDeletedCakes.RLock()
+	calls = mock.calls.GetDeletedCakes
+	mock.lockGetDeletedCakes.RUnlock()
+	return calls
+}
+
 // GetDetailCake calls GetDetailCakeFunc.
 func (mock *CakeUsecaseInterfaceMock) GetDetailCake(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
 	if mock.GetDetailCakeFunc == nil {
@@ -247,24 +623,250 @@ func (mock *CakeUsecaseInterfaceMock) GetDetailCakeCalls() []struct {
 	return calls
 }
 
+// GetImage calls GetImageFunc.
+func (mock *CakeUsecaseInterfaceMock) GetImage(ctx context.Context, key string) (*model.ImageObject, *model.ErrorResponse) {
+	if mock.GetImageFunc == nil {
+		panic("CakeUsecaseInterfaceMock.GetImageFunc: method is nil but CakeUsecaseInterface.GetImage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGetImage.Lock()
+	mock.calls.GetImage = append(mock.calls.GetImage, callInfo)
+	mock.lockGetImage.Unlock()
+	return mock.GetImageFunc(ctx, key)
+}
+
+// GetImageCalls gets all the calls that were made to GetImage.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.GetImageCalls())
+func (mock *CakeUsecaseInterfaceMock) GetImageCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGetImage.RLock()
+	calls = mock.calls.GetImage
+	mock.lockGetImage.RUnlock()
+	return calls
+}
+
+// ImportCakes calls ImportCakesFunc.
+func (mock *CakeUsecaseInterfaceMock) ImportCakes(ctx context.Context, param model.ImportCakesUsecaseParam) (*model.ImportCakesResponse, *model.ErrorResponse) {
+	if mock.ImportCakesFunc == nil {
+		panic("CakeUsecaseInterfaceMock.ImportCakesFunc: method is nil but CakeUsecaseInterface.ImportCakes was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.ImportCakesUsecaseParam
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockImportCakes.Lock()
+	mock.calls.ImportCakes = append(mock.calls.ImportCakes, callInfo)
+	mock.lockImportCakes.Unlock()
+	return mock.ImportCakesFunc(ctx, param)
+}
+
+// ImportCakesCalls gets all the calls that were made to ImportCakes.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.ImportCakesCalls())
+func (mock *CakeUsecaseInterfaceMock) ImportCakesCalls() []struct {
+	Ctx   context.Context
+	Param model.ImportCakesUsecaseParam
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.ImportCakesUsecaseParam
+	}
+	mock.lockImportCakes.RLock()
+	calls = mock.calls.ImportCakes
+	mock.lockImportCakes.RUnlock()
+	return calls
+}
+
+// PatchCake calls PatchCakeFunc.
+func (mock *CakeUsecaseInterfaceMock) PatchCake(ctx context.Context, id int, version int, payload model.CakePatchQuery) (*model.CakeResponse, *model.ErrorResponse) {
+	if mock.PatchCakeFunc == nil {
+		panic("CakeUsecaseInterfaceMock.PatchCakeFunc: method is nil but CakeUsecaseInterface.PatchCake was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int
+		Version int
+		Payload model.CakePatchQuery
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Payload: payload,
+	}
+	mock.lockPatchCake.Lock()
+	mock.calls.PatchCake = append(mock.calls.PatchCake, callInfo)
+	mock.lockPatchCake.Unlock()
+	return mock.PatchCakeFunc(ctx, id, version, payload)
+}
+
+// PatchCakeCalls gets all the calls that were made to PatchCake.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.PatchCakeCalls())
+func (mock *CakeUsecaseInterfaceMock) PatchCakeCalls() []struct {
+	Ctx     context.Context
+	ID      int
+	Version int
+	Payload model.CakePatchQuery
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int
+		Version int
+		Payload model.CakePatchQuery
+	}
+	mock.lockPatchCake.RLock()
+	calls = mock.calls.PatchCake
+	mock.lockPatchCake.RUnlock()
+	return calls
+}
+
+// PurgeDeletedCakes calls PurgeDeletedCakesFunc.
+func (mock *CakeUsecaseInterfaceMock) PurgeDeletedCakes(ctx context.Context, param model.PurgeCakesUsecaseParam) (*model.PurgeCakesResponse, *model.ErrorResponse) {
+	if mock.PurgeDeletedCakesFunc == nil {
+		panic("CakeUsecaseInterfaceMock.PurgeDeletedCakesFunc: method is nil but CakeUsecaseInterface.PurgeDeletedCakes was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.PurgeCakesUsecaseParam
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockPurgeDeletedCakes.Lock()
+	mock.calls.PurgeDeletedCakes = append(mock.calls.PurgeDeletedCakes, callInfo)
+	mock.lockPurgeDeletedCakes.Unlock()
+	return mock.PurgeDeletedCakesFunc(ctx, param)
+}
+
+// PurgeDeletedCakesCalls gets all the calls that were made to PurgeDeletedCakes.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.PurgeDeletedCakesCalls())
+func (mock *CakeUsecaseInterfaceMock) PurgeDeletedCakesCalls() []struct {
+	Ctx   context.Context
+	Param model.PurgeCakesUsecaseParam
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.PurgeCakesUsecaseParam
+	}
+	mock.lockPurgeDeletedCakes.RLock()
+	calls = mock.calls.PurgeDeletedCakes
+	mock.lockPurgeDeletedCakes.RUnlock()
+	return calls
+}
+
+// RegenerateImageVariants calls RegenerateImageVariantsFunc.
+func (mock *CakeUsecaseInterfaceMock) RegenerateImageVariants(ctx context.Context, param model.RegenerateImageVariantsParam) (*model.RegenerateImageVariantsResponse, *model.ErrorResponse) {
+	if mock.RegenerateImageVariantsFunc == nil {
+		panic("CakeUsecaseInterfaceMock.RegenerateImageVariantsFunc: method is nil but CakeUsecaseInterface.RegenerateImageVariants was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.RegenerateImageVariantsParam
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockRegenerateImageVariants.Lock()
+	mock.calls.RegenerateImageVariants = append(mock.calls.RegenerateImageVariants, callInfo)
+	mock.lockRegenerateImageVariants.Unlock()
+	return mock.RegenerateImageVariantsFunc(ctx, param)
+}
+
+// RegenerateImageVariantsCalls gets all the calls that were made to RegenerateImageVariants.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.RegenerateImageVariantsCalls())
+func (mock *CakeUsecaseInterfaceMock) RegenerateImageVariantsCalls() []struct {
+	Ctx   context.Context
+	Param model.RegenerateImageVariantsParam
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.RegenerateImageVariantsParam
+	}
+	mock.lockRegenerateImageVariants.RLock()
+	calls = mock.calls.RegenerateImageVariants
+	mock.lockRegenerateImageVariants.RUnlock()
+	return calls
+}
+
+// RestoreCake calls RestoreCakeFunc.
+func (mock *CakeUsecaseInterfaceMock) RestoreCake(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+	if mock.RestoreCakeFunc == nil {
+		panic("CakeUsecaseInterfaceMock.RestoreCakeFunc: method is nil but CakeUsecaseInterface.RestoreCake was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRestoreCake.Lock()
+	mock.calls.RestoreCake = append(mock.calls.RestoreCake, callInfo)
+	mock.lockRestoreCake.Unlock()
+	return mock.RestoreCakeFunc(ctx, id)
+}
+
+// RestoreCakeCalls gets all the calls that were made to RestoreCake.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.RestoreCakeCalls())
+func (mock *CakeUsecaseInterfaceMock) RestoreCakeCalls() []struct {
+	Ctx context.Context
+	ID  int
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int
+	}
+	mock.lockRestoreCake.RLock()
+	calls = mock.calls.RestoreCake
+	mock.lockRestoreCake.RUnlock()
+	return calls
+}
+
 // UpdateCake calls UpdateCakeFunc.
-func (mock *CakeUsecaseInterfaceMock) UpdateCake(ctx context.Context, id int, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse) {
+func (mock *CakeUsecaseInterfaceMock) UpdateCake(ctx context.Context, id int, version int, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
 	if mock.UpdateCakeFunc == nil {
 		panic("CakeUsecaseInterfaceMock.UpdateCakeFunc: method is nil but CakeUsecaseInterface.UpdateCake was just called")
 	}
 	callInfo := struct {
 		Ctx     context.Context
 		ID      int
+		Version int
 		Payload model.CakePayloadQuery
 	}{
 		Ctx:     ctx,
 		ID:      id,
+		Version: version,
 		Payload: payload,
 	}
 	mock.lockUpdateCake.Lock()
 	mock.calls.UpdateCake = append(mock.calls.UpdateCake, callInfo)
 	mock.lockUpdateCake.Unlock()
-	return mock.UpdateCakeFunc(ctx, id, payload)
+	return mock.UpdateCakeFunc(ctx, id, version, payload)
 }
 
 // UpdateCakeCalls gets all the calls that were made to UpdateCake.
@@ -274,11 +876,13 @@ func (mock *CakeUsecaseInterfaceMock) UpdateCake(ctx context.Context, id int, pa
 func (mock *CakeUsecaseInterfaceMock) UpdateCakeCalls() []struct {
 	Ctx     context.Context
 	ID      int
+	Version int
 	Payload model.CakePayloadQuery
 } {
 	var calls []struct {
 		Ctx     context.Context
 		ID      int
+		Version int
 		Payload model.CakePayloadQuery
 	}
 	mock.lockUpdateCake.RLock()
@@ -286,3 +890,263 @@ func (mock *CakeUsecaseInterfaceMock) UpdateCakeCalls() []struct {
 	mock.lockUpdateCake.RUnlock()
 	return calls
 }
+
+// UploadCakeImage calls UploadCakeImageFunc.
+func (mock *CakeUsecaseInterfaceMock) UploadCakeImage(ctx context.Context, id int, version int, param model.UploadCakeImageParam) (*model.CakeResponse, *model.ErrorResponse) {
+	if mock.UploadCakeImageFunc == nil {
+		panic("CakeUsecaseInterfaceMock.UploadCakeImageFunc: method is nil but CakeUsecaseInterface.UploadCakeImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int
+		Version int
+		Param   model.UploadCakeImageParam
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Param:   param,
+	}
+	mock.lockUploadCakeImage.Lock()
+	mock.calls.UploadCakeImage = append(mock.calls.UploadCakeImage, callInfo)
+	mock.lockUploadCakeImage.Unlock()
+	return mock.UploadCakeImageFunc(ctx, id, version, param)
+}
+
+// UploadCakeImageCalls gets all the calls that were made to UploadCakeImage.
+// Check the length with:
+//
+//	len(mockedCakeUsecaseInterface.UploadCakeImageCalls())
+func (mock *CakeUsecaseInterfaceMock) UploadCakeImageCalls() []struct {
+	Ctx     context.Context
+	ID      int
+	Version int
+	Param   model.UploadCakeImageParam
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int
+		Version int
+		Param   model.UploadCakeImageParam
+	}
+	mock.lockUploadCakeImage.RLock()
+	calls = mock.calls.UploadCakeImage
+	mock.lockUploadCakeImage.RUnlock()
+	return calls
+}
+
+// Ensure, that ApiKeyUsecaseInterfaceMock does implement ApiKeyUsecaseInterface.
+// If this is not the case, regenerate this file with moq.
+var _ ApiKeyUsecaseInterface = &ApiKeyUsecaseInterfaceMock{}
+
+// ApiKeyUsecaseInterfaceMock is a mock implementation of ApiKeyUsecaseInterface.
+//
+//	func TestSomethingThatUsesApiKeyUsecaseInterface(t *testing.T) {
+//
+//		// make and configure a mocked ApiKeyUsecaseInterface
+//		mockedApiKeyUsecaseInterface := &ApiKeyUsecaseInterfaceMock{
+//			AuthenticateApiKeyFunc: func(ctx context.Context, key string) (*model.AuthenticatedApiKey, *model.ErrorResponse) {
+//				panic("mock out the AuthenticateApiKey method")
+//			},
+//			CreateApiKeyFunc: func(ctx context.Context, param model.CreateApiKeyUsecaseParam) (*model.CreateApiKeyResponse, *model.ErrorResponse) {
+//				panic("mock out the CreateApiKey method")
+//			},
+//			GetApiKeysFunc: func(ctx context.Context) (*model.ApiKeysResponse, *model.ErrorResponse) {
+//				panic("mock out the GetApiKeys method")
+//			},
+//			RevokeApiKeyFunc: func(ctx context.Context, id int) (*model.ApiKeyResponse, *model.ErrorResponse) {
+//				panic("mock out the RevokeApiKey method")
+//			},
+//		}
+//
+//		// use mockedApiKeyUsecaseInterface in code that requires ApiKeyUsecaseInterface
+//		// and then make assertions.
+//
+//	}
+type ApiKeyUsecaseInterfaceMock struct {
+	// AuthenticateApiKeyFunc mocks the AuthenticateApiKey method.
+	AuthenticateApiKeyFunc func(ctx context.Context, key string) (*model.AuthenticatedApiKey, *model.ErrorResponse)
+
+	// CreateApiKeyFunc mocks the CreateApiKey method.
+	CreateApiKeyFunc func(ctx context.Context, param model.CreateApiKeyUsecaseParam) (*model.CreateApiKeyResponse, *model.ErrorResponse)
+
+	// GetApiKeysFunc mocks the GetApiKeys method.
+	GetApiKeysFunc func(ctx context.Context) (*model.ApiKeysResponse, *model.ErrorResponse)
+
+	// RevokeApiKeyFunc mocks the RevokeApiKey method.
+	RevokeApiKeyFunc func(ctx context.Context, id int) (*model.ApiKeyResponse, *model.ErrorResponse)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AuthenticateApiKey holds details about calls to the AuthenticateApiKey method.
+		AuthenticateApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// CreateApiKey holds details about calls to the CreateApiKey method.
+		CreateApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Param is the param argument value.
+			Param model.CreateApiKeyUsecaseParam
+		}
+		// GetApiKeys holds details about calls to the GetApiKeys method.
+		GetApiKeys []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RevokeApiKey holds details about calls to the RevokeApiKey method.
+		RevokeApiKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int
+		}
+	}
+	lockAuthenticateApiKey sync.RWMutex
+	lockCreateApiKey       sync.RWMutex
+	lockGetApiKeys         sync.RWMutex
+	lockRevokeApiKey       sync.RWMutex
+}
+
+// AuthenticateApiKey calls AuthenticateApiKeyFunc.
+func (mock *ApiKeyUsecaseInterfaceMock) AuthenticateApiKey(ctx context.Context, key string) (*model.AuthenticatedApiKey, *model.ErrorResponse) {
+	if mock.AuthenticateApiKeyFunc == nil {
+		panic("ApiKeyUsecaseInterfaceMock.AuthenticateApiKeyFunc: method is nil but ApiKeyUsecaseInterface.AuthenticateApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockAuthenticateApiKey.Lock()
+	mock.calls.AuthenticateApiKey = append(mock.calls.AuthenticateApiKey, callInfo)
+	mock.lockAuthenticateApiKey.Unlock()
+	return mock.AuthenticateApiKeyFunc(ctx, key)
+}
+
+// AuthenticateApiKeyCalls gets all the calls that were made to AuthenticateApiKey.
+// Check the length with:
+//
+//	len(mockedApiKeyUsecaseInterface.AuthenticateApiKeyCalls())
+func (mock *ApiKeyUsecaseInterfaceMock) AuthenticateApiKeyCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockAuthenticateApiKey.RLock()
+	calls = mock.calls.AuthenticateApiKey
+	mock.lockAuthenticateApiKey.RUnlock()
+	return calls
+}
+
+// CreateApiKey calls CreateApiKeyFunc.
+func (mock *ApiKeyUsecaseInterfaceMock) CreateApiKey(ctx context.Context, param model.CreateApiKeyUsecaseParam) (*model.CreateApiKeyResponse, *model.ErrorResponse) {
+	if mock.CreateApiKeyFunc == nil {
+		panic("ApiKeyUsecaseInterfaceMock.CreateApiKeyFunc: method is nil but ApiKeyUsecaseInterface.CreateApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Param model.CreateApiKeyUsecaseParam
+	}{
+		Ctx:   ctx,
+		Param: param,
+	}
+	mock.lockCreateApiKey.Lock()
+	mock.calls.CreateApiKey = append(mock.calls.CreateApiKey, callInfo)
+	mock.lockCreateApiKey.Unlock()
+	return mock.CreateApiKeyFunc(ctx, param)
+}
+
+// CreateApiKeyCalls gets all the calls that were made to CreateApiKey.
+// Check the length with:
+//
+//	len(mockedApiKeyUsecaseInterface.CreateApiKeyCalls())
+func (mock *ApiKeyUsecaseInterfaceMock) CreateApiKeyCalls() []struct {
+	Ctx   context.Context
+	Param model.CreateApiKeyUsecaseParam
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Param model.CreateApiKeyUsecaseParam
+	}
+	mock.lockCreateApiKey.RLock()
+	calls = mock.calls.CreateApiKey
+	mock.lockCreateApiKey.RUnlock()
+	return calls
+}
+
+// GetApiKeys calls GetApiKeysFunc.
+func (mock *ApiKeyUsecaseInterfaceMock) GetApiKeys(ctx context.Context) (*model.ApiKeysResponse, *model.ErrorResponse) {
+	if mock.GetApiKeysFunc == nil {
+		panic("ApiKeyUsecaseInterfaceMock.GetApiKeysFunc: method is nil but ApiKeyUsecaseInterface.GetApiKeys was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetApiKeys.Lock()
+	mock.calls.GetApiKeys = append(mock.calls.GetApiKeys, callInfo)
+	mock.lockGetApiKeys.Unlock()
+	return mock.GetApiKeysFunc(ctx)
+}
+
+// GetApiKeysCalls gets all the calls that were made to GetApiKeys.
+// Check the length with:
+//
+//	len(mockedApiKeyUsecaseInterface.GetApiKeysCalls())
+func (mock *ApiKeyUsecaseInterfaceMock) GetApiKeysCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetApiKeys.RLock()
+	calls = mock.calls.GetApiKeys
+	mock.lockGetApiKeys.RUnlock()
+	return calls
+}
+
+// RevokeApiKey calls RevokeApiKeyFunc.
+func (mock *ApiKeyUsecaseInterfaceMock) RevokeApiKey(ctx context.Context, id int) (*model.ApiKeyResponse, *model.ErrorResponse) {
+	if mock.RevokeApiKeyFunc == nil {
+		panic("ApiKeyUsecaseInterfaceMock.RevokeApiKeyFunc: method is nil but ApiKeyUsecaseInterface.RevokeApiKey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRevokeApiKey.Lock()
+	mock.calls.RevokeApiKey = append(mock.calls.RevokeApiKey, callInfo)
+	mock.lockRevokeApiKey.Unlock()
+	return mock.RevokeApiKeyFunc(ctx, id)
+}
+
+// RevokeApiKeyCalls gets all the calls that were made to RevokeApiKey.
+// Check the length with:
+//
+//	len(mockedApiKeyUsecaseInterface.RevokeApiKeyCalls())
+func (mock *ApiKeyUsecaseInterfaceMock) RevokeApiKeyCalls() []struct {
+	Ctx context.Context
+	ID  int
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int
+	}
+	mock.lockRevokeApiKey.RLock()
+	calls = mock.calls.RevokeApiKey
+	mock.lockRevokeApiKey.RUnlock()
+	return calls
+}
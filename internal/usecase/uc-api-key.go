@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// apiKeyTag: key is formatted as apiKeyTag + prefix + "_" + secret, prefix is stored as it is to look the key up
+	apiKeyTag = "rk_"
+	// apiKeyPrefixBytes, apiKeySecretBytes: random bytes of prefix (hex) and secret (base64url)
+	apiKeyPrefixBytes = 6
+	apiKeySecretBytes = 32
+	// apiKeyTouchInterval: last used time is recorded at most once per interval, so authentication does not
+	// write on every request
+	apiKeyTouchInterval = time.Minute
+)
+
+type ApiKeyUsecase struct {
+	dbApiKeyRepository repository.ApiKeyDBInterface
+}
+
+func NewApiKeyUsecase(dbApiKeyRepository repository.ApiKeyDBInterface) ApiKeyUsecaseInterface {
+	return &ApiKeyUsecase{
+		dbApiKeyRepository: dbApiKeyRepository,
+	}
+}
+
+func (uc *ApiKeyUsecase) CreateApiKey(ctx context.Context, param model.CreateApiKeyUsecaseParam) (*model.CreateApiKeyResponse, *model.ErrorResponse) {
+	prefix, secret, err := generateApiKey()
+	if err != nil {
+		return nil, createApiKeyFailed(err)
+	}
+	key := apiKeyTag + prefix + "_" + secret
+	id, err := uc.dbApiKeyRepository.InsertApiKey(ctx, model.ApiKeyPayloadQuery{
+		Name:       param.Name,
+		Prefix:     prefix,
+		SecretHash: hashApiKey(key),
+		Scopes:     strings.Join(param.Scopes, " "),
+	})
+	if err != nil {
+		return nil, createApiKeyFailed(err)
+	}
+	apiKey, errResponse := uc.getApiKey(ctx, int(id))
+	if errResponse != nil {
+		return nil, errResponse
+	}
+	return &model.CreateApiKeyResponse{ApiKeyResponse: *apiKey, Key: key}, nil
+}
+
+func createApiKeyFailed(err error) *model.ErrorResponse {
+	return &model.ErrorResponse{
+		Kind: model.ErrInternal,
+		Err:  errors.New("error create api key"),
+		Code: model.ErrCodeCreateApiKeyFailed,
+		ErrData: model.ErrorDetailResponse{
+			Detail: err.Error(),
+		},
+	}
+}
+
+func (uc *ApiKeyUsecase) GetApiKeys(ctx context.Context) (*model.ApiKeysResponse, *model.ErrorResponse) {
+	apiKeys, err := uc.dbApiKeyRepository.GetApiKeys(ctx)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error get api keys"),
+			Code: model.ErrCodeGetApiKeysFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	response := &model.ApiKeysResponse{Data: make([]model.ApiKeyResponse, 0, len(apiKeys))}
+	for _, apiKey := range apiKeys {
+		response.Data = append(response.Data, mapApiKeyResponse(apiKey))
+	}
+	return response, nil
+}
+
+func (uc *ApiKeyUsecase) RevokeApiKey(ctx context.Context, id int) (*model.ApiKeyResponse, *model.ErrorResponse) {
+	apiKey, errResponse := uc.getApiKey(ctx, id)
+	if errResponse != nil || apiKey.RevokedAt != nil {
+		return apiKey, errResponse
+	}
+	_, err := uc.dbApiKeyRepository.RevokeApiKey(ctx, id, time.Now().UTC())
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error revoke api key"),
+			Code: model.ErrCodeRevokeApiKeyFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	// key revoked concurrently is returned with revoked time of that request
+	return uc.getApiKey(ctx, id)
+}
+
+func (uc *ApiKeyUsecase) AuthenticateApiKey(ctx context.Context, key string) (*model.AuthenticatedApiKey, *model.ErrorResponse) {
+	invalidApiKey := &model.ErrorResponse{
+		Kind: model.ErrUnauthenticated,
+		Err:  errors.New("invalid api key"),
+		Code: model.ErrCodeInvalidApiKey,
+	}
+	prefix, _, found := strings.Cut(strings.TrimPrefix(key, apiKeyTag), "_")
+	if !strings.HasPrefix(key, apiKeyTag) || !found || prefix == "" {
+		return nil, invalidApiKey
+	}
+	apiKey, err := uc.dbApiKeyRepository.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error authenticate api key"),
+			Code: model.ErrCodeAuthenticateApiKeyFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	if apiKey == nil || apiKey.RevokedAt != nil || subtle.ConstantTimeCompare([]byte(hashApiKey(key)), []byte(apiKey.SecretHash)) != 1 {
+		return nil, invalidApiKey
+	}
+	usedAt := time.Now().UTC()
+	if apiKey.LastUsedAt == nil || usedAt.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		// failure of audit record does not reject the request
+		if err := uc.dbApiKeyRepository.TouchApiKey(ctx, apiKey.ID, usedAt); err != nil {
+			logrus.Errorf("error record last used time of api key %d: %s", apiKey.ID, err)
+		}
+	} else {
+		usedAt = *apiKey.LastUsedAt
+	}
+	return &model.AuthenticatedApiKey{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Scopes:     strings.Fields(apiKey.Scopes),
+		LastUsedAt: usedAt,
+	}, nil
+}
+
+func (uc *ApiKeyUsecase) getApiKey(ctx context.Context, id int) (*model.ApiKeyResponse, *model.ErrorResponse) {
+	apiKey, err := uc.dbApiKeyRepository.GetApiKey(ctx, id)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error get api keys"),
+			Code: model.ErrCodeGetApiKeysFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	if apiKey == nil {
+		return nil, &model.ErrorResponse{
+			Kind:   model.ErrApiKeyNotFound,
+			Err:    fmt.Errorf("api key with id %d not found", id),
+			Code:   model.ErrCodeApiKeyNotFound,
+			Params: map[string]interface{}{"id": id},
+		}
+	}
+	response := mapApiKeyResponse(*apiKey)
+	return &response, nil
+}
+
+func mapApiKeyResponse(apiKey model.ApiKey) model.ApiKeyResponse {
+	response := model.ApiKeyResponse{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    strings.Fields(apiKey.Scopes),
+		CreatedAt: apiKey.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if apiKey.LastUsedAt != nil {
+		lastUsedAt := apiKey.LastUsedAt.UTC().Format(time.RFC3339)
+		response.LastUsedAt = &lastUsedAt
+	}
+	if apiKey.RevokedAt != nil {
+		revokedAt := apiKey.RevokedAt.UTC().Format(time.RFC3339)
+		response.RevokedAt = &revokedAt
+	}
+	return response
+}
+
+// generateApiKey: random prefix and secret of new key
+func generateApiKey() (string, string, error) {
+	prefix := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(prefix), base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashApiKey: SHA-256 of whole key, secret has enough entropy so slow password hash is not needed
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
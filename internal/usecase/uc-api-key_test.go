@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/repository"
+)
+
+func TestApiKeyUsecase_CreateApiKey(t *testing.T) {
+	timeMock := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	var gotParam model.ApiKeyPayloadQuery
+	mockApiKeyRepo := &repository.ApiKeyDBInterfaceMock{}
+	mockApiKeyRepo.InsertApiKeyFunc = func(ctx context.Context, param model.ApiKeyPayloadQuery) (int64, error) {
+		gotParam = param
+		return 1, nil
+	}
+	mockApiKeyRepo.GetApiKeyFunc = func(ctx context.Context, id int) (*model.ApiKey, error) {
+		return &model.ApiKey{
+			ID:         id,
+			Name:       gotParam.Name,
+			Prefix:     gotParam.Prefix,
+			SecretHash: gotParam.SecretHash,
+			Scopes:     gotParam.Scopes,
+			CreatedAt:  timeMock,
+		}, nil
+	}
+	uc := NewApiKeyUsecase(mockApiKeyRepo)
+	got, errResponse := uc.CreateApiKey(context.TODO(), model.CreateApiKeyUsecaseParam{
+		Name:   "partner",
+		Scopes: []string{model.ScopeCakesRead, model.ScopeCakesWrite},
+	})
+	if errResponse != nil {
+		t.Fatalf("ApiKeyUsecase.CreateApiKey() error = %v", errResponse)
+	}
+	if !strings.HasPrefix(got.Key, apiKeyTag+gotParam.Prefix+"_") {
+		t.Errorf("ApiKeyUsecase.CreateApiKey() key = %v, want prefix %v", got.Key, apiKeyTag+gotParam.Prefix+"_")
+	}
+	if gotParam.SecretHash != hashApiKey(got.Key) || strings.Contains(gotParam.SecretHash, got.Key) {
+		t.Errorf("ApiKeyUsecase.CreateApiKey() stored hash = %v, want hash of key", gotParam.SecretHash)
+	}
+	want := model.ApiKeyResponse{
+		ID:        1,
+		Name:      "partner",
+		Prefix:    gotParam.Prefix,
+		Scopes:    []string{model.ScopeCakesRead, model.ScopeCakesWrite},
+		CreatedAt: "2006-01-02T15:04:05Z",
+	}
+	if !reflect.DeepEqual(got.ApiKeyResponse, want) {
+		t.Errorf("ApiKeyUsecase.CreateApiKey() = %v, want %v", got.ApiKeyResponse, want)
+	}
+	second, _ := uc.CreateApiKey(context.TODO(), model.CreateApiKeyUsecaseParam{Name: "partner", Scopes: []string{model.ScopeCakesRead}})
+	if second.Key == got.Key {
+		t.Errorf("ApiKeyUsecase.CreateApiKey() generate the same key twice")
+	}
+}
+
+func TestApiKeyUsecase_GetApiKeys(t *testing.T) {
+	timeMock := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	mockApiKeyRepo := &repository.ApiKeyDBInterfaceMock{}
+	mockApiKeyRepo.GetApiKeysFunc = func(ctx context.Context) ([]model.ApiKey, error) {
+		return []model.ApiKey{
+			{ID: 1, Name: "partner", Prefix: "abcd", SecretHash: "hash", Scopes: "cakes:read", CreatedAt: timeMock, LastUsedAt: &timeMock, RevokedAt: &timeMock},
+		}, nil
+	}
+	lastUsedAt := "2006-01-02T15:04:05Z"
+	want := &model.ApiKeysResponse{Data: []model.ApiKeyResponse{{
+		ID:         1,
+		Name:       "partner",
+		Prefix:     "abcd",
+		Scopes:     []string{model.ScopeCakesRead},
+		LastUsedAt: &lastUsedAt,
+		CreatedAt:  "2006-01-02T15:04:05Z",
+		RevokedAt:  &lastUsedAt,
+	}}}
+	got, errResponse := NewApiKeyUsecase(mockApiKeyRepo).GetApiKeys(context.TODO())
+	if errResponse != nil {
+		t.Fatalf("ApiKeyUsecase.GetApiKeys() error = %v", errResponse)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ApiKeyUsecase.GetApiKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestApiKeyUsecase_RevokeApiKey(t *testing.T) {
+	timeMock := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	var revoked []int
+	mockApiKeyRepo := &repository.ApiKeyDBInterfaceMock{}
+	mockApiKeyRepo.GetApiKeyFunc = func(ctx context.Context, id int) (*model.ApiKey, error) {
+		switch {
+		case id == 1 && len(revoked) > 0:
+			return &model.ApiKey{ID: 1, Scopes: "cakes:read", CreatedAt: timeMock, RevokedAt: &timeMock}, nil
+		case id == 1:
+			return &model.ApiKey{ID: 1, Scopes: "cakes:read", CreatedAt: timeMock}, nil
+		case id == 2:
+			return &model.ApiKey{ID: 2, Scopes: "cakes:read", CreatedAt: timeMock, RevokedAt: &timeMock}, nil
+		}
+		return nil, nil
+	}
+	mockApiKeyRepo.RevokeApiKeyFunc = func(ctx context.Context, id int, revokedAt time.Time) (bool, error) {
+		revoked = append(revoked, id)
+		return true, nil
+	}
+	uc := NewApiKeyUsecase(mockApiKeyRepo)
+	revokedAt := "2006-01-02T15:04:05Z"
+	tests := []struct {
+		name        string
+		id          int
+		want        *model.ApiKeyResponse
+		wantRevoked []int
+		wantErr     error
+	}{
+		{
+			name:        "basic test",
+			id:          1,
+			want:        &model.ApiKeyResponse{ID: 1, Scopes: []string{model.ScopeCakesRead}, CreatedAt: "2006-01-02T15:04:05Z", RevokedAt: &revokedAt},
+			wantRevoked: []int{1},
+		},
+		{
+			name: "already revoked",
+			id:   2,
+			want: &model.ApiKeyResponse{ID: 2, Scopes: []string{model.ScopeCakesRead}, CreatedAt: "2006-01-02T15:04:05Z", RevokedAt: &revokedAt},
+		},
+		{
+			name:    "not found",
+			id:      3,
+			wantErr: model.ErrApiKeyNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked = nil
+			got, errResponse := uc.RevokeApiKey(context.TODO(), tt.id)
+			if tt.wantErr != nil {
+				if errResponse == nil || !errors.Is(errResponse, tt.wantErr) {
+					t.Errorf("ApiKeyUsecase.RevokeApiKey() error = %v, want %v", errResponse, tt.wantErr)
+				}
+				return
+			}
+			if errResponse != nil {
+				t.Fatalf("ApiKeyUsecase.RevokeApiKey() error = %v", errResponse)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApiKeyUsecase.RevokeApiKey() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(revoked, tt.wantRevoked) {
+				t.Errorf("ApiKeyUsecase.RevokeApiKey() revoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func TestApiKeyUsecase_AuthenticateApiKey(t *testing.T) {
+	const key = "rk_abcd1234_secret_with_underscore"
+	recentlyUsed := time.Now().UTC().Add(-10 * time.Second)
+	revokedAt := time.Now().UTC()
+	var touched []int
+	mockApiKeyRepo := &repository.ApiKeyDBInterfaceMock{}
+	mockApiKeyRepo.GetApiKeyByPrefixFunc = func(ctx context.Context, prefix string) (*model.ApiKey, error) {
+		switch prefix {
+		case "abcd1234":
+			return &model.ApiKey{ID: 1, Name: "partner", Prefix: prefix, SecretHash: hashApiKey(key), Scopes: "cakes:read cakes:write"}, nil
+		case "recent":
+			return &model.ApiKey{ID: 2, Prefix: prefix, SecretHash: hashApiKey("rk_recent_secret"), Scopes: "cakes:read", LastUsedAt: &recentlyUsed}, nil
+		case "revoked":
+			return &model.ApiKey{ID: 3, Prefix: prefix, SecretHash: hashApiKey("rk_revoked_secret"), Scopes: "cakes:read", RevokedAt: &revokedAt}, nil
+		case "broken":
+			return nil, errors.New("connection refused")
+		}
+		return nil, nil
+	}
+	mockApiKeyRepo.TouchApiKeyFunc = func(ctx context.Context, id int, usedAt time.Time) error {
+		touched = append(touched, id)
+		return nil
+	}
+	uc := NewApiKeyUsecase(mockApiKeyRepo)
+	tests := []struct {
+		name        string
+		key         string
+		wantID      int
+		wantScopes  []string
+		wantTouched []int
+		wantErr     error
+	}{
+		{
+			name:        "basic test",
+			key:         key,
+			wantID:      1,
+			wantScopes:  []string{model.ScopeCakesRead, model.ScopeCakesWrite},
+			wantTouched: []int{1},
+		},
+		{
+			name:       "recently used key is not recorded again",
+			key:        "rk_recent_secret",
+			wantID:     2,
+			wantScopes: []string{model.ScopeCakesRead},
+		},
+		{
+			name:    "wrong secret",
+			key:     "rk_abcd1234_other",
+			wantErr: model.ErrUnauthenticated,
+		},
+		{
+			name:    "revoked key",
+			key:     "rk_revoked_secret",
+			wantErr: model.ErrUnauthenticated,
+		},
+		{
+			name:    "unknown prefix",
+			key:     "rk_unknown_secret",
+			wantErr: model.ErrUnauthenticated,
+		},
+		{
+			name:    "malformed key",
+			key:     "abcd1234",
+			wantErr: model.ErrUnauthenticated,
+		},
+		{
+			name:    "repository error",
+			key:     "rk_broken_secret",
+			wantErr: model.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			touched = nil
+			got, errResponse := uc.AuthenticateApiKey(context.TODO(), tt.key)
+			if tt.wantErr != nil {
+				if errResponse == nil || !errors.Is(errResponse, tt.wantErr) {
+					t.Errorf("ApiKeyUsecase.AuthenticateApiKey() error = %v, want %v", errResponse, tt.wantErr)
+				}
+				return
+			}
+			if errResponse != nil {
+				t.Fatalf("ApiKeyUsecase.AuthenticateApiKey() error = %v", errResponse)
+			}
+			if got.ID != tt.wantID || !reflect.DeepEqual(got.Scopes, tt.wantScopes) {
+				t.Errorf("ApiKeyUsecase.AuthenticateApiKey() = %v, want id %v scopes %v", got, tt.wantID, tt.wantScopes)
+			}
+			if !reflect.DeepEqual(touched, tt.wantTouched) {
+				t.Errorf("ApiKeyUsecase.AuthenticateApiKey() touched = %v, want %v", touched, tt.wantTouched)
+			}
+		})
+	}
+}
@@ -1,96 +1,243 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
+	"io"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/forderation/ralali-test/internal/model"
 	"github.com/forderation/ralali-test/internal/repository"
+	"github.com/forderation/ralali-test/internal/storage"
 	"github.com/forderation/ralali-test/util"
+	"github.com/sirupsen/logrus"
 )
 
+const (
+	// defaultPurgeBatchSize: used when purge batch size is not configured
+	defaultPurgeBatchSize = 1000
+	// importBatchSize: number of rows inserted on single statement on import
+	importBatchSize = 500
+	// maxImportErrors: maximum rejected rows listed on import report
+	maxImportErrors = 1000
+)
+
+// imageExtensions: file extension of stored image by its content type
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
 type CakeUsecase struct {
 	dbCakeRepository repository.CakeDBInterface
+	imageStore       storage.ImageStore
+	// imageBaseURL: url of stored image is imageBaseURL + "/" + key
+	imageBaseURL string
+	// imageVariantJobs: key of uploaded image is sent here to generate its variants in background, nil disable it
+	imageVariantJobs chan<- string
 }
 
-func NewCakeUsecase(dbCakeRepository repository.CakeDBInterface) CakeUsecaseInterface {
+func NewCakeUsecase(dbCakeRepository repository.CakeDBInterface, imageStore storage.ImageStore, imageBaseURL string, imageVariantJobs chan<- string) CakeUsecaseInterface {
 	return &CakeUsecase{
 		dbCakeRepository: dbCakeRepository,
+		imageStore:       imageStore,
+		imageBaseURL:     strings.TrimSuffix(imageBaseURL, "/"),
+		imageVariantJobs: imageVariantJobs,
 	}
 }
 
-func (uc *CakeUsecase) DeleteCake(ctx context.Context, id int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
-	_, errResponse := uc.GetDetailCake(ctx, id)
-	if errResponse != nil {
-		return nil, errResponse
-	}
-	err := uc.dbCakeRepository.SoftDeleteCake(ctx, id)
+func (uc *CakeUsecase) DeleteCake(ctx context.Context, id int, version int) (*model.CakeDeleteResponse, *model.ErrorResponse) {
+	deletedAt := time.Now().UTC()
+	deleted, err := uc.dbCakeRepository.SoftDeleteCake(ctx, id, version, deletedAt)
 	if err != nil {
 		return nil, &model.ErrorResponse{
-			HttpStatusCode: http.StatusInternalServerError,
-			Err:            errors.New("error delete cake data"),
+			Kind: model.ErrInternal,
+			Err:  errors.New("error delete cake data"),
+			Code: model.ErrCodeDeleteCakeFailed,
 			ErrData: model.ErrorDetailResponse{
 				Detail: err.Error(),
 			},
 		}
 	}
+	if !deleted {
+		return nil, uc.conditionalMutationError(ctx, id)
+	}
 	return &model.CakeDeleteResponse{
-		ID: id,
+		ID:        id,
+		DeletedAt: deletedAt.Format(time.RFC3339),
 	}, nil
 }
 
-func (uc *CakeUsecase) UpdateCake(ctx context.Context, id int, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse) {
-	_, errResponse := uc.GetDetailCake(ctx, id)
+func (uc *CakeUsecase) UpdateCake(ctx context.Context, id int, version int, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
+	updated, err := uc.dbCakeRepository.UpdateCake(ctx, id, version, payload)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error update cake data"),
+			Code: model.ErrCodeUpdateCakeFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	if !updated {
+		return nil, uc.conditionalMutationError(ctx, id)
+	}
+	return uc.GetDetailCake(ctx, id)
+}
+
+func (uc *CakeUsecase) PatchCake(ctx context.Context, id int, version int, payload model.CakePatchQuery) (*model.CakeResponse, *model.ErrorResponse) {
+	updated, err := uc.dbCakeRepository.PatchCake(ctx, id, version, payload)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error update cake data"),
+			Code: model.ErrCodeUpdateCakeFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	if !updated {
+		return nil, uc.conditionalMutationError(ctx, id)
+	}
+	return uc.GetDetailCake(ctx, id)
+}
+
+// UploadCakeImage: store image then set its url as cake image, image key is derived from its content
+// so stored image never change and can be cached forever
+func (uc *CakeUsecase) UploadCakeImage(ctx context.Context, id int, version int, param model.UploadCakeImageParam) (*model.CakeResponse, *model.ErrorResponse) {
+	extension, ok := imageExtensions[param.ContentType]
+	if !ok {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrUnsupported,
+			Err:  fmt.Errorf("unsupported image type '%s'", param.ContentType),
+			Code: model.ErrCodeUnsupportedImageType,
+		}
+	}
+	cake, errResponse := uc.GetDetailCake(ctx, id)
 	if errResponse != nil {
 		return nil, errResponse
 	}
-	err := uc.dbCakeRepository.UpdateCake(ctx, id, payload)
+	sum := sha256.Sum256(param.Content)
+	key := fmt.Sprintf("cakes/%d/%s%s", id, hex.EncodeToString(sum[:16]), extension)
+	err := uc.imageStore.Put(ctx, key, param.ContentType, bytes.NewReader(param.Content), int64(len(param.Content)))
 	if err != nil {
 		return nil, &model.ErrorResponse{
-			HttpStatusCode: http.StatusInternalServerError,
-			Err:            errors.New("error update cake data"),
+			Kind: model.ErrInternal,
+			Err:  errors.New("error store cake image"),
+			Code: model.ErrCodeStoreImageFailed,
 			ErrData: model.ErrorDetailResponse{
 				Detail: err.Error(),
 			},
 		}
 	}
-	return &model.CakeMutationResponse{
-		Title:       payload.Title,
-		Description: payload.Description,
-		Rating:      payload.Rating,
-		Image:       payload.Image,
-	}, nil
+	imageURL := uc.imageBaseURL + "/" + key
+	updated, err := uc.dbCakeRepository.PatchCake(ctx, id, version, model.CakePatchQuery{
+		Image: model.NullableFieldFrom(imageURL),
+	})
+	if err != nil || !updated {
+		// remove the stored image unless it is the current image of cake
+		if cake.Image == nil || *cake.Image != imageURL {
+			if errDelete := uc.imageStore.Delete(ctx, key); errDelete != nil {
+				logrus.Error("error delete unused cake image: ", errDelete)
+			}
+		}
+	}
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error update cake data"),
+			Code: model.ErrCodeUpdateCakeFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	if !updated {
+		return nil, uc.conditionalMutationError(ctx, id)
+	}
+	uc.scheduleImageVariants(key)
+	return uc.GetDetailCake(ctx, id)
 }
 
-func (uc *CakeUsecase) CreateCake(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeMutationResponse, *model.ErrorResponse) {
-	err := uc.dbCakeRepository.InsertCake(ctx, payload)
+// GetImage: open stored image, missing variant falls back to its original image
+func (uc *CakeUsecase) GetImage(ctx context.Context, key string) (*model.ImageObject, *model.ErrorResponse) {
+	image, err := uc.imageStore.Get(ctx, key)
+	if errors.Is(err, storage.ErrImageNotFound) {
+		if source, ok := imageVariantSource(key); ok {
+			key = source
+			image, err = uc.imageStore.Get(ctx, key)
+		}
+	}
+	if errors.Is(err, storage.ErrImageNotFound) {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrImageNotFound,
+			Err:  errors.New("image not found"),
+			Code: model.ErrCodeImageNotFound,
+		}
+	}
 	if err != nil {
 		return nil, &model.ErrorResponse{
-			HttpStatusCode: http.StatusInternalServerError,
-			Err:            errors.New("error add cake data"),
+			Kind: model.ErrInternal,
+			Err:  errors.New("error get image"),
+			Code: model.ErrCodeGetImageFailed,
 			ErrData: model.ErrorDetailResponse{
 				Detail: err.Error(),
 			},
 		}
 	}
-	return &model.CakeMutationResponse{
-		Title:       payload.Title,
-		Description: payload.Description,
-		Rating:      payload.Rating,
-		Image:       payload.Image,
-	}, nil
+	image.Key = key
+	return image, nil
+}
+
+// conditionalMutationError: find out why conditional mutation did not affect any record,
+// either the record is not found or its version already changed
+func (uc *CakeUsecase) conditionalMutationError(ctx context.Context, id int) *model.ErrorResponse {
+	cake, errResponse := uc.GetDetailCake(ctx, id)
+	if errResponse != nil {
+		return errResponse
+	}
+	return &model.ErrorResponse{
+		Kind:   model.ErrVersionMismatch,
+		Err:    fmt.Errorf("cake data with id %d has been modified, current version is %d", id, cake.Version),
+		Code:   model.ErrCodeCakeModified,
+		Params: map[string]interface{}{"id": id, "version": cake.Version},
+	}
+}
+
+func (uc *CakeUsecase) CreateCake(ctx context.Context, payload model.CakePayloadQuery) (*model.CakeResponse, *model.ErrorResponse) {
+	id, err := uc.dbCakeRepository.InsertCake(ctx, payload)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error add cake data"),
+			Code: model.ErrCodeCreateCakeFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	return uc.GetDetailCake(ctx, int(id))
 }
 
 func (uc *CakeUsecase) GetDetailCake(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
 	cake, err := uc.dbCakeRepository.GetCake(ctx, id)
 	if err != nil {
 		return nil, &model.ErrorResponse{
-			HttpStatusCode: http.StatusInternalServerError,
-			Err:            errors.New("error get cake data"),
+			Kind: model.ErrInternal,
+			Err:  errors.New("error get cake data"),
+			Code: model.ErrCodeGetCakeFailed,
 			ErrData: model.ErrorDetailResponse{
 				Detail: err.Error(),
 			},
@@ -98,15 +245,20 @@ func (uc *CakeUsecase) GetDetailCake(ctx context.Context, id int) (*model.CakeRe
 	}
 	if cake == nil {
 		return nil, &model.ErrorResponse{
-			HttpStatusCode: http.StatusNotFound,
-			Err:            fmt.Errorf("cake data with id %d not found", id),
+			Kind:   model.ErrCakeNotFound,
+			Err:    fmt.Errorf("cake data with id %d not found", id),
+			Code:   model.ErrCodeCakeNotFound,
+			Params: map[string]interface{}{"id": id},
 		}
 	}
-	response := mapCakeDataResponse(*cake)
+	response := uc.mapCakeDataResponse(*cake)
 	return &response, nil
 }
 
 func (uc *CakeUsecase) GetCakes(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+	if param.CursorMode {
+		return uc.getCakesByCursor(ctx, param)
+	}
 	offset := int(0)
 	if param.Page > 0 {
 		offset = (param.Page - 1) * param.PageSize
@@ -118,7 +270,7 @@ func (uc *CakeUsecase) GetCakes(ctx context.Context, param model.GetCakesUsecase
 	wg := sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
-		totalData, errTotal = uc.dbCakeRepository.CountCakes(ctx)
+		totalData, errTotal = uc.dbCakeRepository.CountCakes(ctx, param.Filter)
 		wg.Done()
 	}()
 	wg.Add(1)
@@ -126,14 +278,25 @@ func (uc *CakeUsecase) GetCakes(ctx context.Context, param model.GetCakesUsecase
 		cakes, errCakes = uc.dbCakeRepository.GetCakes(ctx, model.GetCakesQuery{
 			Limit:  limit,
 			Offset: offset,
+			Filter: param.Filter,
+			Sort:   param.Sort,
 		})
 		wg.Done()
 	}()
 	wg.Wait()
+	if errors.Is(errCakes, repository.ErrInvalidSortField) {
+		return nil, &model.ErrorResponse{
+			Err:    fmt.Errorf("invalid query parameter 'sort': %w", errCakes),
+			Code:   model.ErrCodeInvalidSort,
+			Params: map[string]interface{}{"detail": errCakes.Error()},
+			Kind:   model.ErrValidation,
+		}
+	}
 	if errCakes != nil {
 		return nil, &model.ErrorResponse{
-			Err:            errors.New("error on get data cakes"),
-			HttpStatusCode: http.StatusInternalServerError,
+			Err:  errors.New("error on get data cakes"),
+			Code: model.ErrCodeGetCakesFailed,
+			Kind: model.ErrInternal,
 			ErrData: model.ErrorDetailResponse{
 				Detail: errCakes.Error(),
 			},
@@ -141,8 +304,9 @@ func (uc *CakeUsecase) GetCakes(ctx context.Context, param model.GetCakesUsecase
 	}
 	if errTotal != nil {
 		return nil, &model.ErrorResponse{
-			Err:            errors.New("error on count total cakes"),
-			HttpStatusCode: http.StatusInternalServerError,
+			Err:  errors.New("error on count total cakes"),
+			Code: model.ErrCodeCountCakesFailed,
+			Kind: model.ErrInternal,
 			ErrData: model.ErrorDetailResponse{
 				Detail: errTotal.Error(),
 			},
@@ -156,20 +320,529 @@ func (uc *CakeUsecase) GetCakes(ctx context.Context, param model.GetCakesUsecase
 		Data: make([]model.CakeResponse, 0),
 	}
 	for _, v := range cakes {
-		data := mapCakeDataResponse(v)
+		data := uc.mapCakeDataResponse(v)
 		response.Data = append(response.Data, data)
 	}
 	return &response, nil
 }
 
-func mapCakeDataResponse(cake model.Cake) model.CakeResponse {
-	return model.CakeResponse{
+func (uc *CakeUsecase) GetCakesModified(ctx context.Context, filter model.CakesFilterQuery) (*model.CakesModified, *model.ErrorResponse) {
+	modified, err := uc.dbCakeRepository.GetCakesModified(ctx, filter)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Err:  errors.New("error on get modified time of cakes"),
+			Code: model.ErrCodeGetCakesFailed,
+			Kind: model.ErrInternal,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	return &modified, nil
+}
+
+func (uc *CakeUsecase) GetDeletedCakes(ctx context.Context, param model.GetDeletedCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+	offset := int(0)
+	if param.Page > 0 {
+		offset = (param.Page - 1) * param.PageSize
+	}
+	var totalData int64
+	var errTotal, errCakes error
+	var cakes []model.Cake
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		totalData, errTotal = uc.dbCakeRepository.CountDeletedCakes(ctx)
+		wg.Done()
+	}()
+	wg.Add(1)
+	go func() {
+		cakes, errCakes = uc.dbCakeRepository.GetDeletedCakes(ctx, model.GetDeletedCakesQuery{
+			Limit:  param.PageSize,
+			Offset: offset,
+		})
+		wg.Done()
+	}()
+	wg.Wait()
+	if errCakes != nil {
+		return nil, &model.ErrorResponse{
+			Err:  errors.New("error on get data deleted cakes"),
+			Code: model.ErrCodeGetDeletedCakesFailed,
+			Kind: model.ErrInternal,
+			ErrData: model.ErrorDetailResponse{
+				Detail: errCakes.Error(),
+			},
+		}
+	}
+	if errTotal != nil {
+		return nil, &model.ErrorResponse{
+			Err:  errors.New("error on count total deleted cakes"),
+			Code: model.ErrCodeCountDeletedCakesFailed,
+			Kind: model.ErrInternal,
+			ErrData: model.ErrorDetailResponse{
+				Detail: errTotal.Error(),
+			},
+		}
+	}
+	response := model.GetCakesResponse{
+		Meta: model.MetaPagination{
+			PageCount: util.GetPageCount(param.PageSize, int(totalData)),
+			TotalData: totalData,
+		},
+		Data: make([]model.CakeResponse, 0),
+	}
+	for _, v := range cakes {
+		data := uc.mapCakeDataResponse(v)
+		response.Data = append(response.Data, data)
+	}
+	return &response, nil
+}
+
+func (uc *CakeUsecase) RestoreCake(ctx context.Context, id int) (*model.CakeResponse, *model.ErrorResponse) {
+	restored, err := uc.dbCakeRepository.RestoreCake(ctx, id)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error restore cake data"),
+			Code: model.ErrCodeRestoreCakeFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	if restored {
+		return uc.GetDetailCake(ctx, id)
+	}
+	// find out why restore did not affect any record, either not found or not deleted
+	cake, err := uc.dbCakeRepository.GetCakeWithDeleted(ctx, id)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error get cake data"),
+			Code: model.ErrCodeGetCakeFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	if cake == nil {
+		return nil, &model.ErrorResponse{
+			Kind:   model.ErrCakeNotFound,
+			Err:    fmt.Errorf("cake data with id %d not found", id),
+			Code:   model.ErrCodeCakeNotFound,
+			Params: map[string]interface{}{"id": id},
+		}
+	}
+	return nil, &model.ErrorResponse{
+		Kind:   model.ErrConflict,
+		Err:    fmt.Errorf("cake data with id %d is not deleted", id),
+		Code:   model.ErrCodeCakeNotDeleted,
+		Params: map[string]interface{}{"id": id},
+	}
+}
+
+// ExportCakes: call fn for every cake matching filter without loading all of them into memory,
+// error returned by fn stop the export and returned as ErrData detail
+func (uc *CakeUsecase) ExportCakes(ctx context.Context, param model.ExportCakesUsecaseParam, fn func(cake model.CakeResponse) error) *model.ErrorResponse {
+	err := uc.dbCakeRepository.StreamCakes(ctx, model.StreamCakesQuery{
+		Filter: param.Filter,
+		Sort:   param.Sort,
+	}, func(cake model.Cake) error {
+		return fn(uc.mapCakeDataResponse(cake))
+	})
+	if errors.Is(err, repository.ErrInvalidSortField) {
+		return &model.ErrorResponse{
+			Err:    fmt.Errorf("invalid query parameter 'sort': %w", err),
+			Code:   model.ErrCodeInvalidSort,
+			Params: map[string]interface{}{"detail": err.Error()},
+			Kind:   model.ErrValidation,
+		}
+	}
+	if err != nil {
+		return &model.ErrorResponse{
+			Err:  errors.New("error on export data cakes"),
+			Code: model.ErrCodeExportCakesFailed,
+			Kind: model.ErrInternal,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	return nil
+}
+
+// PurgeDeletedCakes: permanently delete soft deleted cakes past retention in batches until none left.
+// retention must be positive, zero retention would purge the whole trash
+func (uc *CakeUsecase) PurgeDeletedCakes(ctx context.Context, param model.PurgeCakesUsecaseParam) (*model.PurgeCakesResponse, *model.ErrorResponse) {
+	if param.Retention <= 0 {
+		return nil, &model.ErrorResponse{
+			Kind:   model.ErrValidation,
+			Err:    fmt.Errorf("purge retention must be positive, got %s", param.Retention),
+			Code:   model.ErrCodeInvalidPurgeRetention,
+			Params: map[string]interface{}{"retention": param.Retention.String()},
+		}
+	}
+	if param.BatchSize <= 0 {
+		param.BatchSize = defaultPurgeBatchSize
+	}
+	response := model.PurgeCakesResponse{
+		DeletedBefore: time.Now().UTC().Add(-param.Retention),
+		DryRun:        param.DryRun,
+	}
+	if param.DryRun {
+		total, err := uc.dbCakeRepository.CountPurgeableCakes(ctx, response.DeletedBefore)
+		if err != nil {
+			return nil, &model.ErrorResponse{
+				Kind: model.ErrInternal,
+				Err:  errors.New("error count purgeable cakes"),
+				Code: model.ErrCodeCountPurgeableFailed,
+				ErrData: model.ErrorDetailResponse{
+					Detail: err.Error(),
+				},
+			}
+		}
+		response.Purged = total
+		return &response, nil
+	}
+	for {
+		purged, err := uc.dbCakeRepository.PurgeDeletedCakes(ctx, response.DeletedBefore, param.BatchSize)
+		response.Purged += purged
+		if err != nil {
+			return &response, &model.ErrorResponse{
+				Kind: model.ErrInternal,
+				Err:  errors.New("error purge deleted cakes"),
+				Code: model.ErrCodePurgeFailed,
+				ErrData: model.ErrorDetailResponse{
+					Detail: err.Error(),
+				},
+			}
+		}
+		if purged < int64(param.BatchSize) {
+			return &response, nil
+		}
+		if ctx.Err() != nil {
+			return &response, &model.ErrorResponse{
+				Kind: model.ErrInternal,
+				Err:  errors.New("purge deleted cakes interrupted"),
+				Code: model.ErrCodePurgeInterrupted,
+				ErrData: model.ErrorDetailResponse{
+					Detail: ctx.Err().Error(),
+				},
+			}
+		}
+	}
+}
+
+// errBatchAborted: returned inside transaction to roll back atomic batch
+var errBatchAborted = errors.New("batch aborted")
+
+// BatchCakes: run create / update / delete operations on single transaction
+func (uc *CakeUsecase) BatchCakes(ctx context.Context, param model.BatchCakesUsecaseParam) (*model.BatchCakesResponse, *model.ErrorResponse) {
+	var response model.BatchCakesResponse
+	var failed *model.BatchCakeResult
+	err := uc.dbCakeRepository.RunInTx(ctx, func(txRepo repository.CakeDBInterface) error {
+		response = model.BatchCakesResponse{
+			Results: make([]model.BatchCakeResult, 0, len(param.Operations)),
+		}
+		// copy keep image store and base url, so batch respond the same image urls as single operation
+		txUsecase := *uc
+		txUsecase.dbCakeRepository = txRepo
+		for _, operation := range param.Operations {
+			result := txUsecase.runBatchOperation(ctx, operation)
+			if result.ErrorMessage != "" {
+				if param.Atomic {
+					failed = &result
+					return errBatchAborted
+				}
+				response.Failed++
+			} else {
+				response.Succeeded++
+			}
+			response.Results = append(response.Results, result)
+		}
+		return nil
+	})
+	if failed != nil {
+		return nil, &model.ErrorResponse{
+			Kind:    failed.Err,
+			Err:     fmt.Errorf("operation at index %d failed, no operation is applied: %s", failed.Index, failed.ErrorMessage),
+			Code:    model.ErrCodeBatchFailed,
+			Params:  map[string]interface{}{"index": failed.Index, "detail": failed.ErrorMessage},
+			ErrData: failed,
+		}
+	}
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Kind: model.ErrInternal,
+			Err:  errors.New("error run batch cakes"),
+			Code: model.ErrCodeBatchRunFailed,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	return &response, nil
+}
+
+func (uc *CakeUsecase) runBatchOperation(ctx context.Context, operation model.BatchCakeOperation) model.BatchCakeResult {
+	result := model.BatchCakeResult{
+		Index: operation.Index,
+		Op:    operation.Op,
+		ID:    operation.ID,
+	}
+	var errResponse *model.ErrorResponse
+	switch operation.Op {
+	case model.BatchOpCreate:
+		result.Cake, errResponse = uc.CreateCake(ctx, operation.Payload)
+	case model.BatchOpUpdate:
+		result.Cake, errResponse = uc.UpdateCake(ctx, operation.ID, operation.Version, operation.Payload)
+	case model.BatchOpDelete:
+		_, errResponse = uc.DeleteCake(ctx, operation.ID, operation.Version)
+	default:
+		errResponse = &model.ErrorResponse{
+			Kind:   model.ErrValidation,
+			Err:    fmt.Errorf("unknown operation '%s'", operation.Op),
+			Code:   model.ErrCodeUnknownOperation,
+			Params: map[string]interface{}{"op": operation.Op},
+		}
+	}
+	if errResponse != nil {
+		result.Err = errResponse
+		result.ErrorCode = errResponse.Code
+		result.ErrorMessage = errResponse.Err.Error()
+		result.ErrorParams = errResponse.Params
+		return result
+	}
+	if result.Cake != nil {
+		result.ID = result.Cake.ID
+	}
+	return result
+}
+
+// ImportCakes: validate rows from param.Next and insert valid rows in batches,
+// rows inserted before error occurred are kept and reported on ErrData
+func (uc *CakeUsecase) ImportCakes(ctx context.Context, param model.ImportCakesUsecaseParam) (*model.ImportCakesResponse, *model.ErrorResponse) {
+	response := model.ImportCakesResponse{
+		DryRun: param.DryRun,
+		Errors: make([]model.ImportCakeError, 0),
+	}
+	batch := make([]model.CakePayloadQuery, 0, importBatchSize)
+	flush := func() error {
+		if param.DryRun || len(batch) == 0 {
+			batch = batch[:0]
+			return nil
+		}
+		inserted, err := uc.dbCakeRepository.InsertCakes(ctx, batch)
+		if err != nil {
+			return err
+		}
+		response.ImportedRows += int(inserted)
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		row, err := param.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &model.ErrorResponse{
+				Kind:    model.ErrValidation,
+				Err:     fmt.Errorf("error read import file: %w", err),
+				Code:    model.ErrCodeImportReadFailed,
+				Params:  map[string]interface{}{"detail": err.Error()},
+				ErrData: response,
+			}
+		}
+		response.TotalRows++
+		if row.Err != nil {
+			response.RejectedRows++
+			if len(response.Errors) < maxImportErrors {
+				rowError := model.ImportCakeError{
+					Line:         row.Line,
+					ErrorCode:    model.ErrCodeValidationFailed,
+					ErrorMessage: row.Err.Error(),
+					ErrorParams:  map[string]interface{}{"detail": row.Err.Error()},
+				}
+				var fieldErr *model.FieldError
+				if errors.As(row.Err, &fieldErr) {
+					rowError.Errors = model.ValidationErrors{*fieldErr}
+				} else if !errors.As(row.Err, &rowError.Errors) {
+					// row which cannot be decoded e.g. malformed json or csv
+					rowError.ErrorCode = model.ErrCodeInvalidImportRow
+				}
+				response.Errors = append(response.Errors, rowError)
+			} else {
+				response.ErrorsTruncated = true
+			}
+			continue
+		}
+		response.ValidRows++
+		batch = append(batch, row.Payload)
+		if len(batch) >= importBatchSize {
+			err := flush()
+			if err != nil {
+				return nil, importCakesError(err, response)
+			}
+		}
+	}
+	err := flush()
+	if err != nil {
+		return nil, importCakesError(err, response)
+	}
+	return &response, nil
+}
+
+// importCakesError: error of failed insert on import, number of imported rows before failure is reported
+func importCakesError(err error, response model.ImportCakesResponse) *model.ErrorResponse {
+	return &model.ErrorResponse{
+		Kind:   model.ErrInternal,
+		Err:    fmt.Errorf("error import cakes, %d rows already imported", response.ImportedRows),
+		Code:   model.ErrCodeImportFailed,
+		Params: map[string]interface{}{"imported_rows": response.ImportedRows},
+		ErrData: model.ErrorDetailResponse{
+			Detail: err.Error(),
+		},
+	}
+}
+
+// getCakesByCursor: keyset pagination of cakes, total data and page count are not calculated on this mode
+func (uc *CakeUsecase) getCakesByCursor(ctx context.Context, param model.GetCakesUsecaseParam) (*model.GetCakesResponse, *model.ErrorResponse) {
+	query := model.GetCakesSeekQuery{
+		// fetch one more record to know whether next / previous page exist
+		Limit:    param.PageSize + 1,
+		Filter:   param.Filter,
+		Backward: param.Backward,
+	}
+	if param.Cursor != "" {
+		cursor, err := decodeCakeCursor(param.Cursor)
+		if err != nil {
+			return nil, &model.ErrorResponse{
+				Err:  errors.New("invalid query parameter 'cursor'"),
+				Code: model.ErrCodeInvalidCursor,
+				Kind: model.ErrValidation,
+			}
+		}
+		query.After = &cursor.CakeSortKey
+		query.Backward = cursor.Backward || param.Backward
+	}
+	cakes, err := uc.dbCakeRepository.GetCakesSeek(ctx, query)
+	if err != nil {
+		return nil, &model.ErrorResponse{
+			Err:  errors.New("error on get data cakes"),
+			Code: model.ErrCodeGetCakesFailed,
+			Kind: model.ErrInternal,
+			ErrData: model.ErrorDetailResponse{
+				Detail: err.Error(),
+			},
+		}
+	}
+	hasMore := len(cakes) > param.PageSize
+	if hasMore {
+		if query.Backward {
+			cakes = cakes[1:]
+		} else {
+			cakes = cakes[:param.PageSize]
+		}
+	}
+	response := model.GetCakesResponse{
+		Data: make([]model.CakeResponse, 0),
+	}
+	for _, v := range cakes {
+		response.Data = append(response.Data, uc.mapCakeDataResponse(v))
+	}
+	if len(cakes) == 0 {
+		return &response, nil
+	}
+	first, last := cakes[0], cakes[len(cakes)-1]
+	if (query.Backward && hasMore) || (!query.Backward && query.After != nil) {
+		prevCursor := encodeCakeCursor(cakeCursor{CakeSortKey: cakeSortKey(first), Backward: true})
+		response.Meta.PrevCursor = &prevCursor
+	}
+	if (query.Backward && query.After != nil) || (!query.Backward && hasMore) {
+		nextCursor := encodeCakeCursor(cakeCursor{CakeSortKey: cakeSortKey(last)})
+		response.Meta.NextCursor = &nextCursor
+	}
+	return &response, nil
+}
+
+// cakeCursor: content of opaque cursor given to client on cursor pagination
+type cakeCursor struct {
+	model.CakeSortKey
+	Backward bool
+}
+
+type cakeCursorPayload struct {
+	Rating   float32 `json:"r"`
+	Title    string  `json:"t"`
+	ID       int     `json:"i"`
+	Backward bool    `json:"b,omitempty"`
+}
+
+func cakeSortKey(cake model.Cake) model.CakeSortKey {
+	return model.CakeSortKey{
+		Rating: cake.Rating,
+		Title:  cake.Title,
+		ID:     cake.ID,
+	}
+}
+
+// CakeCursor: cursor of cursor pagination pointing at the cake, seeking after it return the cakes following it
+// e.g. cursor of GraphQL edge
+func CakeCursor(cake model.CakeResponse) string {
+	return encodeCakeCursor(cakeCursor{CakeSortKey: model.CakeSortKey{Rating: cake.Rating, Title: cake.Title, ID: cake.ID}})
+}
+
+func encodeCakeCursor(cursor cakeCursor) string {
+	payload, _ := json.Marshal(cakeCursorPayload{
+		Rating:   cursor.Rating,
+		Title:    cursor.Title,
+		ID:       cursor.ID,
+		Backward: cursor.Backward,
+	})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeCakeCursor(cursor string) (*cakeCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var data cakeCursorPayload
+	err = json.Unmarshal(payload, &data)
+	if err != nil {
+		return nil, err
+	}
+	if data.ID <= 0 {
+		return nil, errors.New("cursor id must be positive")
+	}
+	return &cakeCursor{
+		CakeSortKey: model.CakeSortKey{
+			Rating: data.Rating,
+			Title:  data.Title,
+			ID:     data.ID,
+		},
+		Backward: data.Backward,
+	}, nil
+}
+
+func (uc *CakeUsecase) mapCakeDataResponse(cake model.Cake) model.CakeResponse {
+	response := model.CakeResponse{
 		ID:          cake.ID,
 		Title:       cake.Title,
 		Description: cake.Description,
 		Rating:      cake.Rating,
 		Image:       cake.Image,
-		CreatedAt:   cake.CreatedAt.Local().Format(time.DateTime),
-		UpdatedAt:   cake.UpdatedAt.Local().Format(time.DateTime),
+		CreatedAt:   cake.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   cake.UpdatedAt.UTC().Format(time.RFC3339),
+		Version:     cake.Version,
+	}
+	if cake.Image != nil {
+		response.Images = uc.mapCakeImagesResponse(*cake.Image)
+	}
+	if cake.DeletedAt != nil {
+		deletedAt := cake.DeletedAt.UTC().Format(time.RFC3339)
+		response.DeletedAt = &deletedAt
 	}
+	return response
 }
@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log"
 	"reflect"
 	"testing"
@@ -10,14 +12,20 @@ import (
 
 	"github.com/forderation/ralali-test/internal/model"
 	"github.com/forderation/ralali-test/internal/repository"
+	"github.com/forderation/ralali-test/internal/storage"
 	"gopkg.in/guregu/null.v4"
 )
 
 func TestNewCakeUsecase(t *testing.T) {
 	type args struct {
 		dbCakeRepository repository.CakeDBInterface
+		imageStore       storage.ImageStore
+		imageBaseURL     string
+		imageVariantJobs chan<- string
 	}
 	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockImageStore := &storage.ImageStoreMock{}
+	imageVariantJobs := make(chan string)
 	tests := []struct {
 		name string
 		args args
@@ -27,38 +35,59 @@ func TestNewCakeUsecase(t *testing.T) {
 			name: "basic test",
 			args: args{
 				dbCakeRepository: mockCakeRepo,
+				imageStore:       mockImageStore,
+				imageBaseURL:     "http://127.0.0.1:8081/images/",
+				imageVariantJobs: imageVariantJobs,
 			},
 			want: &CakeUsecase{
 				dbCakeRepository: mockCakeRepo,
+				imageStore:       mockImageStore,
+				imageBaseURL:     "http://127.0.0.1:8081/images",
+				imageVariantJobs: imageVariantJobs,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewCakeUsecase(tt.args.dbCakeRepository); !reflect.DeepEqual(got, tt.want) {
+			if got := NewCakeUsecase(tt.args.dbCakeRepository, tt.args.imageStore, tt.args.imageBaseURL, tt.args.imageVariantJobs); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewCakeUsecase() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+// fallbackImagesResponse: images of cake hosted outside image store, every variant falls back to original image
+func fallbackImagesResponse(image string) *model.CakeImagesResponse {
+	return &model.CakeImagesResponse{
+		Original: image,
+		Variants: []model.CakeImageVariantResponse{
+			{Width: 128, Format: "jpeg", URL: image},
+			{Width: 512, Format: "jpeg", URL: image},
+			{Width: 1024, Format: "jpeg", URL: image},
+		},
+	}
+}
+
 func TestCakeUsecase_DeleteCake(t *testing.T) {
 	type fields struct {
 		dbCakeRepository repository.CakeDBInterface
 	}
 	type args struct {
-		ctx context.Context
-		id  int
+		ctx     context.Context
+		id      int
+		version int
 	}
 	mockCakeRepo := &repository.CakeDBInterfaceMock{}
 	mockCakeRepo.GetCakeFunc = func(ctx context.Context, id int) (*model.Cake, error) {
-		return &model.Cake{}, nil
+		return &model.Cake{ID: id, Version: 2}, nil
 	}
-	mockCakeRepo.SoftDeleteCakeFunc = func(ctx context.Context, id int) error {
+	var gotDeletedAt time.Time
+	mockCakeRepo.SoftDeleteCakeFunc = func(ctx context.Context, id int, version int, deletedAt time.Time) (bool, error) {
+		gotDeletedAt = deletedAt
 		if id == 1 {
-			return nil
+			return version == 2, nil
 		}
-		return errors.New("error mock")
+		return false, errors.New("error mock")
 	}
 	tests := []struct {
 		name    string
@@ -73,8 +102,9 @@ func TestCakeUsecase_DeleteCake(t *testing.T) {
 				dbCakeRepository: mockCakeRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
-				id:  1,
+				ctx:     context.TODO(),
+				id:      1,
+				version: 2,
 			},
 			want: &model.CakeDeleteResponse{
 				ID: 1,
@@ -87,8 +117,21 @@ func TestCakeUsecase_DeleteCake(t *testing.T) {
 				dbCakeRepository: mockCakeRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
-				id:  2,
+				ctx:     context.TODO(),
+				id:      2,
+				version: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "version not match",
+			fields: fields{
+				dbCakeRepository: mockCakeRepo,
+			},
+			args: args{
+				ctx:     context.TODO(),
+				id:      1,
+				version: 1,
 			},
 			wantErr: true,
 		},
@@ -98,11 +141,15 @@ func TestCakeUsecase_DeleteCake(t *testing.T) {
 			uc := &CakeUsecase{
 				dbCakeRepository: tt.fields.dbCakeRepository,
 			}
-			got, err := uc.DeleteCake(tt.args.ctx, tt.args.id)
+			got, err := uc.DeleteCake(tt.args.ctx, tt.args.id, tt.args.version)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CakeUsecase.DeleteCake() got = %v, want %v", err, tt.wantErr)
 				return
 			}
+			if tt.want != nil {
+				// deleted time is picked by usecase and stored as it is
+				tt.want.DeletedAt = gotDeletedAt.Format(time.RFC3339)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CakeUsecase.DeleteCake() got = %v, want %v", got, tt.want)
 			}
@@ -117,23 +164,43 @@ func TestCakeUsecase_UpdateCake(t *testing.T) {
 	type args struct {
 		ctx     context.Context
 		id      int
+		version int
 		payload model.CakePayloadQuery
 	}
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	stored := model.Cake{
+		CreatedAt: timeMock,
+		UpdatedAt: timeMock,
+		Version:   1,
+	}
 	mockCakeRepo := &repository.CakeDBInterfaceMock{}
 	mockCakeRepo.GetCakeFunc = func(ctx context.Context, id int) (*model.Cake, error) {
-		return &model.Cake{}, nil
+		cake := stored
+		cake.ID = id
+		return &cake, nil
 	}
-	mockCakeRepo.UpdateCakeFunc = func(ctx context.Context, id int, param model.CakePayloadQuery) error {
+	mockCakeRepo.UpdateCakeFunc = func(ctx context.Context, id int, version int, param model.CakePayloadQuery) (bool, error) {
 		if id == 1 {
-			return nil
+			if version != model.AnyVersion && version != stored.Version {
+				return false, nil
+			}
+			stored.Title = param.Title
+			stored.Description = param.Description
+			stored.Rating = param.Rating
+			stored.Image = param.Image
+			stored.Version++
+			return true, nil
 		}
-		return errors.New("error mock")
+		return false, errors.New("error mock")
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    *model.CakeMutationResponse
+		want    *model.CakeResponse
 		wantErr bool
 	}{
 		{
@@ -142,8 +209,9 @@ func TestCakeUsecase_UpdateCake(t *testing.T) {
 				dbCakeRepository: mockCakeRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
-				id:  1,
+				ctx:     context.TODO(),
+				id:      1,
+				version: 1,
 				payload: model.CakePayloadQuery{
 					Title:       "title",
 					Description: null.StringFrom("description").Ptr(),
@@ -151,12 +219,30 @@ func TestCakeUsecase_UpdateCake(t *testing.T) {
 					Image:       null.StringFrom("image").Ptr(),
 				},
 			},
-			want: &model.CakeMutationResponse{
+			want: &model.CakeResponse{
+				ID:          1,
 				Title:       "title",
 				Description: null.StringFrom("description").Ptr(),
 				Rating:      float32(4.32),
 				Image:       null.StringFrom("image").Ptr(),
+				Images:      fallbackImagesResponse("image"),
+				CreatedAt:   timeMock.UTC().Format(time.RFC3339),
+				UpdatedAt:   timeMock.UTC().Format(time.RFC3339),
+				Version:     2,
+			},
+		},
+		{
+			name: "version not match",
+			fields: fields{
+				dbCakeRepository: mockCakeRepo,
+			},
+			args: args{
+				ctx:     context.TODO(),
+				id:      1,
+				version: 1,
+				payload: model.CakePayloadQuery{},
 			},
+			wantErr: true,
 		},
 		{
 			name: "error test",
@@ -176,7 +262,7 @@ func TestCakeUsecase_UpdateCake(t *testing.T) {
 			uc := &CakeUsecase{
 				dbCakeRepository: tt.fields.dbCakeRepository,
 			}
-			got, err := uc.UpdateCake(tt.args.ctx, tt.args.id, tt.args.payload)
+			got, err := uc.UpdateCake(tt.args.ctx, tt.args.id, tt.args.version, tt.args.payload)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CakeUsecase.UpdateCake() got = %v, want %v", err, tt.wantErr)
 				return
@@ -196,18 +282,38 @@ func TestCakeUsecase_CreateCake(t *testing.T) {
 		ctx     context.Context
 		payload model.CakePayloadQuery
 	}
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	var stored model.Cake
 	mockCakeRepo := &repository.CakeDBInterfaceMock{}
-	mockCakeRepo.InsertCakeFunc = func(ctx context.Context, param model.CakePayloadQuery) error {
+	mockCakeRepo.InsertCakeFunc = func(ctx context.Context, param model.CakePayloadQuery) (int64, error) {
 		if param.Title == "title" {
-			return nil
+			stored = model.Cake{
+				ID:          1,
+				Title:       param.Title,
+				Description: param.Description,
+				Rating:      param.Rating,
+				Image:       param.Image,
+				CreatedAt:   timeMock,
+				UpdatedAt:   timeMock,
+			}
+			return 1, nil
+		}
+		return 0, errors.New("error mock")
+	}
+	mockCakeRepo.GetCakeFunc = func(ctx context.Context, id int) (*model.Cake, error) {
+		if id == stored.ID {
+			return &stored, nil
 		}
-		return errors.New("error mock")
+		return nil, nil
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    *model.CakeMutationResponse
+		want    *model.CakeResponse
 		wantErr bool
 	}{
 		{
@@ -224,11 +330,15 @@ func TestCakeUsecase_CreateCake(t *testing.T) {
 					Image:       null.StringFrom("image").Ptr(),
 				},
 			},
-			want: &model.CakeMutationResponse{
+			want: &model.CakeResponse{
+				ID:          1,
 				Title:       "title",
 				Description: null.StringFrom("description").Ptr(),
 				Rating:      float32(4.32),
 				Image:       null.StringFrom("image").Ptr(),
+				Images:      fallbackImagesResponse("image"),
+				CreatedAt:   timeMock.UTC().Format(time.RFC3339),
+				UpdatedAt:   timeMock.UTC().Format(time.RFC3339),
 			},
 		},
 		{
@@ -310,8 +420,9 @@ func TestCakeUsecase_GetDetailCake(t *testing.T) {
 				Description: null.StringFrom("description").Ptr(),
 				Rating:      float32(4.32),
 				Image:       null.StringFrom("image").Ptr(),
-				CreatedAt:   "2006-01-02 22:04:05",
-				UpdatedAt:   "2006-01-02 22:04:05",
+				Images:      fallbackImagesResponse("image"),
+				CreatedAt:   "2006-01-02T15:04:05Z",
+				UpdatedAt:   "2006-01-02T15:04:05Z",
 			},
 		},
 		{
@@ -358,6 +469,9 @@ func TestCakeUsecase_GetCakes(t *testing.T) {
 	timeMock = timeMock.UTC()
 	mockCakeRepo := &repository.CakeDBInterfaceMock{}
 	mockCakeRepo.GetCakesFunc = func(ctx context.Context, param model.GetCakesQuery) ([]model.Cake, error) {
+		if param.Sort == "unknown" {
+			return nil, fmt.Errorf("%w '%s'", repository.ErrInvalidSortField, param.Sort)
+		}
 		if param.Limit == 1 {
 			return []model.Cake{
 				{
@@ -373,7 +487,7 @@ func TestCakeUsecase_GetCakes(t *testing.T) {
 		}
 		return nil, errors.New("error mock")
 	}
-	mockCakeRepo.CountCakesFunc = func(ctx context.Context) (int64, error) {
+	mockCakeRepo.CountCakesFunc = func(ctx context.Context, filter model.CakesFilterQuery) (int64, error) {
 		return 1, nil
 	}
 	tests := []struct {
@@ -407,8 +521,9 @@ func TestCakeUsecase_GetCakes(t *testing.T) {
 						Description: null.StringFrom("description").Ptr(),
 						Rating:      float32(4.32),
 						Image:       null.StringFrom("image").Ptr(),
-						CreatedAt:   "2006-01-02 22:04:05",
-						UpdatedAt:   "2006-01-02 22:04:05",
+						Images:      fallbackImagesResponse("image"),
+						CreatedAt:   "2006-01-02T15:04:05Z",
+						UpdatedAt:   "2006-01-02T15:04:05Z",
 					},
 				},
 			},
@@ -427,6 +542,21 @@ func TestCakeUsecase_GetCakes(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid sort field",
+			fields: fields{
+				dbCakeRepository: mockCakeRepo,
+			},
+			args: args{
+				ctx: context.TODO(),
+				param: model.GetCakesUsecaseParam{
+					Page:     1,
+					PageSize: 1,
+					Sort:     "unknown",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -445,7 +575,7 @@ func TestCakeUsecase_GetCakes(t *testing.T) {
 	}
 }
 
-func Test_mapCakeDataResponse(t *testing.T) {
+func TestCakeUsecase_mapCakeDataResponse(t *testing.T) {
 	type args struct {
 		cake model.Cake
 	}
@@ -478,15 +608,1066 @@ func Test_mapCakeDataResponse(t *testing.T) {
 				Description: null.StringFrom("description").Ptr(),
 				Rating:      float32(4.32),
 				Image:       null.StringFrom("image").Ptr(),
-				CreatedAt:   "2006-01-02 22:04:05",
-				UpdatedAt:   "2006-01-02 22:04:05",
+				Images:      fallbackImagesResponse("image"),
+				CreatedAt:   "2006-01-02T15:04:05Z",
+				UpdatedAt:   "2006-01-02T15:04:05Z",
+			},
+		},
+		{
+			name: "stored image with variants",
+			args: args{
+				cake: model.Cake{
+					ID:        1,
+					Title:     "title",
+					Rating:    float32(4.32),
+					Image:     null.StringFrom("http://127.0.0.1:8081/images/cakes/1/0f1e.png").Ptr(),
+					CreatedAt: timeMock,
+					UpdatedAt: timeMock,
+				},
+			},
+			want: model.CakeResponse{
+				ID:     1,
+				Title:  "title",
+				Rating: float32(4.32),
+				Image:  null.StringFrom("http://127.0.0.1:8081/images/cakes/1/0f1e.png").Ptr(),
+				Images: &model.CakeImagesResponse{
+					Original: "http://127.0.0.1:8081/images/cakes/1/0f1e.png",
+					Variants: []model.CakeImageVariantResponse{
+						{Width: 128, Format: "jpeg", URL: "http://127.0.0.1:8081/images/cakes/1/0f1e.png_128.jpg"},
+						{Width: 512, Format: "jpeg", URL: "http://127.0.0.1:8081/images/cakes/1/0f1e.png_512.jpg"},
+						{Width: 1024, Format: "jpeg", URL: "http://127.0.0.1:8081/images/cakes/1/0f1e.png_1024.jpg"},
+					},
+				},
+				CreatedAt: "2006-01-02T15:04:05Z",
+				UpdatedAt: "2006-01-02T15:04:05Z",
+			},
+		},
+		{
+			name: "soft deleted cake",
+			args: args{
+				cake: model.Cake{
+					ID:        1,
+					Title:     "title",
+					Rating:    float32(4.32),
+					CreatedAt: timeMock,
+					UpdatedAt: timeMock,
+					DeletedAt: &timeMock,
+				},
+			},
+			want: model.CakeResponse{
+				ID:        1,
+				Title:     "title",
+				Rating:    float32(4.32),
+				CreatedAt: "2006-01-02T15:04:05Z",
+				UpdatedAt: "2006-01-02T15:04:05Z",
+				DeletedAt: null.StringFrom("2006-01-02T15:04:05Z").Ptr(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &CakeUsecase{
+				imageBaseURL: "http://127.0.0.1:8081/images",
+			}
+			if got := uc.mapCakeDataResponse(tt.args.cake); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.mapCakeDataResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_GetCakes_CursorMode(t *testing.T) {
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	records := []model.Cake{
+		{ID: 1, Title: "a", Rating: 5, CreatedAt: timeMock, UpdatedAt: timeMock},
+		{ID: 2, Title: "b", Rating: 4, CreatedAt: timeMock, UpdatedAt: timeMock},
+		{ID: 3, Title: "c", Rating: 4, CreatedAt: timeMock, UpdatedAt: timeMock},
+	}
+	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockCakeRepo.GetCakesSeekFunc = func(ctx context.Context, param model.GetCakesSeekQuery) ([]model.Cake, error) {
+		start, end := 0, len(records)
+		if param.After != nil {
+			for i, v := range records {
+				if v.ID == param.After.ID {
+					if param.Backward {
+						end = i
+					} else {
+						start = i + 1
+					}
+				}
+			}
+		}
+		if end-start > param.Limit {
+			if param.Backward {
+				start = end - param.Limit
+			} else {
+				end = start + param.Limit
+			}
+		}
+		return records[start:end], nil
+	}
+	uc := &CakeUsecase{
+		dbCakeRepository: mockCakeRepo,
+	}
+	firstPage, errResponse := uc.GetCakes(context.TODO(), model.GetCakesUsecaseParam{PageSize: 2, CursorMode: true})
+	if errResponse != nil {
+		t.Fatalf("CakeUsecase.GetCakes() first page error = %v", errResponse.Err)
+	}
+	if len(firstPage.Data) != 2 || firstPage.Meta.NextCursor == nil || firstPage.Meta.PrevCursor != nil {
+		t.Fatalf("CakeUsecase.GetCakes() first page = %+v, want 2 data with next cursor only", firstPage)
+	}
+	secondPage, errResponse := uc.GetCakes(context.TODO(), model.GetCakesUsecaseParam{PageSize: 2, CursorMode: true, Cursor: *firstPage.Meta.NextCursor})
+	if errResponse != nil {
+		t.Fatalf("CakeUsecase.GetCakes() second page error = %v", errResponse.Err)
+	}
+	if len(secondPage.Data) != 1 || secondPage.Data[0].ID != 3 || secondPage.Meta.NextCursor != nil || secondPage.Meta.PrevCursor == nil {
+		t.Fatalf("CakeUsecase.GetCakes() second page = %+v, want cake 3 with prev cursor only", secondPage)
+	}
+	prevPage, errResponse := uc.GetCakes(context.TODO(), model.GetCakesUsecaseParam{PageSize: 2, CursorMode: true, Cursor: *secondPage.Meta.PrevCursor})
+	if errResponse != nil {
+		t.Fatalf("CakeUsecase.GetCakes() previous page error = %v", errResponse.Err)
+	}
+	if len(prevPage.Data) != 2 || prevPage.Data[0].ID != 1 || prevPage.Data[1].ID != 2 || prevPage.Meta.NextCursor == nil || prevPage.Meta.PrevCursor != nil {
+		t.Fatalf("CakeUsecase.GetCakes() previous page = %+v, want cake 1 and 2 with next cursor only", prevPage)
+	}
+	_, errResponse = uc.GetCakes(context.TODO(), model.GetCakesUsecaseParam{PageSize: 2, CursorMode: true, Cursor: "not-a-cursor"})
+	if errResponse == nil || !errors.Is(errResponse, model.ErrValidation) {
+		t.Fatalf("CakeUsecase.GetCakes() invalid cursor error = %v, want validation error", errResponse)
+	}
+	lastPage, errResponse := uc.GetCakes(context.TODO(), model.GetCakesUsecaseParam{PageSize: 2, CursorMode: true, Backward: true})
+	if errResponse != nil {
+		t.Fatalf("CakeUsecase.GetCakes() last page error = %v", errResponse.Err)
+	}
+	if len(lastPage.Data) != 2 || lastPage.Data[0].ID != 2 || lastPage.Data[1].ID != 3 || lastPage.Meta.NextCursor != nil || lastPage.Meta.PrevCursor == nil {
+		t.Fatalf("CakeUsecase.GetCakes() last page = %+v, want cake 2 and 3 with prev cursor only", lastPage)
+	}
+	// cursor of single cake seek before the cake when backward is requested
+	beforePage, errResponse := uc.GetCakes(context.TODO(), model.GetCakesUsecaseParam{PageSize: 2, CursorMode: true, Cursor: CakeCursor(lastPage.Data[0]), Backward: true})
+	if errResponse != nil {
+		t.Fatalf("CakeUsecase.GetCakes() before page error = %v", errResponse.Err)
+	}
+	if len(beforePage.Data) != 1 || beforePage.Data[0].ID != 1 || beforePage.Meta.NextCursor == nil || beforePage.Meta.PrevCursor != nil {
+		t.Fatalf("CakeUsecase.GetCakes() before page = %+v, want cake 1 with next cursor only", beforePage)
+	}
+	afterPage, errResponse := uc.GetCakes(context.TODO(), model.GetCakesUsecaseParam{PageSize: 2, CursorMode: true, Cursor: CakeCursor(firstPage.Data[0])})
+	if errResponse != nil {
+		t.Fatalf("CakeUsecase.GetCakes() after page error = %v", errResponse.Err)
+	}
+	if len(afterPage.Data) != 2 || afterPage.Data[0].ID != 2 || afterPage.Data[1].ID != 3 {
+		t.Fatalf("CakeUsecase.GetCakes() after page = %+v, want cake 2 and 3", afterPage)
+	}
+}
+
+func TestCakeUsecase_PatchCake(t *testing.T) {
+	type fields struct {
+		dbCakeRepository repository.CakeDBInterface
+	}
+	type args struct {
+		ctx     context.Context
+		id      int
+		version int
+		payload model.CakePatchQuery
+	}
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	stored := model.Cake{
+		ID:          1,
+		Title:       "title",
+		Description: null.StringFrom("description").Ptr(),
+		Rating:      float32(4.32),
+		CreatedAt:   timeMock,
+		UpdatedAt:   timeMock,
+		Version:     1,
+	}
+	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockCakeRepo.GetCakeFunc = func(ctx context.Context, id int) (*model.Cake, error) {
+		if id == 3 {
+			return nil, nil
+		}
+		cake := stored
+		cake.ID = id
+		return &cake, nil
+	}
+	mockCakeRepo.PatchCakeFunc = func(ctx context.Context, id int, version int, param model.CakePatchQuery) (bool, error) {
+		if id == 1 {
+			if version != stored.Version {
+				return false, nil
+			}
+			if param.Rating.Set {
+				stored.Rating = param.Rating.Value
+			}
+			if param.Description.Set {
+				stored.Description = param.Description.Ptr()
+			}
+			stored.Version++
+			return true, nil
+		}
+		if id == 3 {
+			return false, nil
+		}
+		return false, errors.New("error mock")
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *model.CakeResponse
+		wantErr bool
+	}{
+		{
+			name: "basic test",
+			fields: fields{
+				dbCakeRepository: mockCakeRepo,
+			},
+			args: args{
+				ctx:     context.TODO(),
+				id:      1,
+				version: 1,
+				payload: model.CakePatchQuery{
+					Rating:      model.NullableFieldFrom(float32(2)),
+					Description: model.NullableField[string]{Set: true},
+				},
+			},
+			want: &model.CakeResponse{
+				ID:          1,
+				Title:       "title",
+				Description: nil,
+				Rating:      float32(2),
+				CreatedAt:   timeMock.UTC().Format(time.RFC3339),
+				UpdatedAt:   timeMock.UTC().Format(time.RFC3339),
+				Version:     2,
+			},
+		},
+		{
+			name: "error test",
+			fields: fields{
+				dbCakeRepository: mockCakeRepo,
+			},
+			args: args{
+				ctx:     context.TODO(),
+				id:      2,
+				payload: model.CakePatchQuery{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "not found test",
+			fields: fields{
+				dbCakeRepository: mockCakeRepo,
+			},
+			args: args{
+				ctx:     context.TODO(),
+				id:      3,
+				payload: model.CakePatchQuery{},
 			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &CakeUsecase{
+				dbCakeRepository: tt.fields.dbCakeRepository,
+			}
+			got, err := uc.PatchCake(tt.args.ctx, tt.args.id, tt.args.version, tt.args.payload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeUsecase.PatchCake() got = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.PatchCake() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_GetCakesModified(t *testing.T) {
+	timeMock := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockCakeRepo.GetCakesModifiedFunc = func(ctx context.Context, filter model.CakesFilterQuery) (model.CakesModified, error) {
+		if filter.Keyword == "error" {
+			return model.CakesModified{}, errors.New("error mock")
+		}
+		return model.CakesModified{Count: 2, VersionSum: 3, ModifiedAt: timeMock}, nil
+	}
+	tests := []struct {
+		name    string
+		filter  model.CakesFilterQuery
+		want    *model.CakesModified
+		wantErr bool
+	}{
+		{
+			name: "basic test",
+			want: &model.CakesModified{Count: 2, VersionSum: 3, ModifiedAt: timeMock},
+		},
+		{
+			name:    "error test",
+			filter:  model.CakesFilterQuery{Keyword: "error"},
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := mapCakeDataResponse(tt.args.cake); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("mapCakeDataResponse() = %v, want %v", got, tt.want)
+			uc := &CakeUsecase{
+				dbCakeRepository: mockCakeRepo,
+			}
+			got, err := uc.GetCakesModified(context.TODO(), tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeUsecase.GetCakesModified() got = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.GetCakesModified() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_GetDeletedCakes(t *testing.T) {
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	timeMock = timeMock.UTC()
+	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockCakeRepo.GetDeletedCakesFunc = func(ctx context.Context, param model.GetDeletedCakesQuery) ([]model.Cake, error) {
+		if param.Limit == 1 {
+			return []model.Cake{
+				{
+					ID:        1,
+					Title:     "title",
+					Rating:    float32(4.32),
+					CreatedAt: timeMock,
+					UpdatedAt: timeMock,
+					DeletedAt: &timeMock,
+				},
+			}, nil
+		}
+		return nil, errors.New("error mock")
+	}
+	mockCakeRepo.CountDeletedCakesFunc = func(ctx context.Context) (int64, error) {
+		return 1, nil
+	}
+	tests := []struct {
+		name    string
+		param   model.GetDeletedCakesUsecaseParam
+		want    *model.GetCakesResponse
+		wantErr bool
+	}{
+		{
+			name: "basic test",
+			param: model.GetDeletedCakesUsecaseParam{
+				Page:     1,
+				PageSize: 1,
+			},
+			want: &model.GetCakesResponse{
+				Meta: model.MetaPagination{
+					PageCount: 1,
+					TotalData: 1,
+				},
+				Data: []model.CakeResponse{
+					{
+						ID:        1,
+						Title:     "title",
+						Rating:    float32(4.32),
+						CreatedAt: "2006-01-02T15:04:05Z",
+						UpdatedAt: "2006-01-02T15:04:05Z",
+						DeletedAt: null.StringFrom("2006-01-02T15:04:05Z").Ptr(),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error test",
+			param: model.GetDeletedCakesUsecaseParam{
+				Page:     1,
+				PageSize: 2,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &CakeUsecase{
+				dbCakeRepository: mockCakeRepo,
+			}
+			got, err := uc.GetDeletedCakes(context.TODO(), tt.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeUsecase.GetDeletedCakes() got = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.GetDeletedCakes() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_RestoreCake(t *testing.T) {
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	timeMock = timeMock.UTC()
+	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockCakeRepo.RestoreCakeFunc = func(ctx context.Context, id int) (bool, error) {
+		if id == 4 {
+			return false, errors.New("error mock")
+		}
+		return id == 1, nil
+	}
+	mockCakeRepo.GetCakeFunc = func(ctx context.Context, id int) (*model.Cake, error) {
+		return &model.Cake{ID: id, Title: "title", CreatedAt: timeMock, UpdatedAt: timeMock, Version: 3}, nil
+	}
+	mockCakeRepo.GetCakeWithDeletedFunc = func(ctx context.Context, id int) (*model.Cake, error) {
+		if id == 2 {
+			return &model.Cake{ID: id, Title: "title", CreatedAt: timeMock, UpdatedAt: timeMock, Version: 3}, nil
+		}
+		return nil, nil
+	}
+	tests := []struct {
+		name    string
+		id      int
+		want    *model.CakeResponse
+		wantErr error
+	}{
+		{
+			name: "basic test",
+			id:   1,
+			want: &model.CakeResponse{
+				ID:        1,
+				Title:     "title",
+				CreatedAt: "2006-01-02T15:04:05Z",
+				UpdatedAt: "2006-01-02T15:04:05Z",
+				Version:   3,
+			},
+		},
+		{
+			name:    "cake is not deleted",
+			id:      2,
+			wantErr: model.ErrConflict,
+		},
+		{
+			name:    "cake not found",
+			id:      3,
+			wantErr: model.ErrCakeNotFound,
+		},
+		{
+			name:    "error test",
+			id:      4,
+			wantErr: model.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &CakeUsecase{
+				dbCakeRepository: mockCakeRepo,
+			}
+			got, errResponse := uc.RestoreCake(context.TODO(), tt.id)
+			if (errResponse != nil) != (tt.wantErr != nil) || (errResponse != nil && !errors.Is(errResponse, tt.wantErr)) {
+				t.Errorf("CakeUsecase.RestoreCake() error = %v, want %v", errResponse, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.RestoreCake() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_PurgeDeletedCakes(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      model.PurgeCakesUsecaseParam
+		batches    []int64
+		batchErr   error
+		wantPurged int64
+		wantCalls  int
+		wantErr    bool
+	}{
+		{
+			name: "purge until last batch is not full",
+			param: model.PurgeCakesUsecaseParam{
+				Retention: 90 * 24 * time.Hour,
+				BatchSize: 2,
+			},
+			batches:    []int64{2, 2, 1},
+			wantPurged: 5,
+			wantCalls:  3,
+		},
+		{
+			name: "nothing to purge",
+			param: model.PurgeCakesUsecaseParam{
+				Retention: 90 * 24 * time.Hour,
+				BatchSize: 2,
+			},
+			batches:    []int64{0},
+			wantPurged: 0,
+			wantCalls:  1,
+		},
+		{
+			name: "dry run only count",
+			param: model.PurgeCakesUsecaseParam{
+				Retention: 90 * 24 * time.Hour,
+				BatchSize: 2,
+				DryRun:    true,
+			},
+			wantPurged: 9,
+			wantCalls:  0,
+		},
+		{
+			name: "zero retention",
+			param: model.PurgeCakesUsecaseParam{
+				BatchSize: 2,
+			},
+			wantCalls: 0,
+			wantErr:   true,
+		},
+		{
+			name: "negative retention on dry run",
+			param: model.PurgeCakesUsecaseParam{
+				Retention: -time.Hour,
+				BatchSize: 2,
+				DryRun:    true,
+			},
+			wantCalls: 0,
+			wantErr:   true,
+		},
+		{
+			name: "error test",
+			param: model.PurgeCakesUsecaseParam{
+				Retention: 90 * 24 * time.Hour,
+				BatchSize: 2,
+			},
+			batches:   []int64{2, 0},
+			batchErr:  errors.New("error mock"),
+			wantCalls: 2,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			mockCakeRepo := &repository.CakeDBInterfaceMock{}
+			mockCakeRepo.PurgeDeletedCakesFunc = func(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+				calls++
+				if calls == len(tt.batches) && tt.batchErr != nil {
+					return 0, tt.batchErr
+				}
+				return tt.batches[calls-1], nil
+			}
+			mockCakeRepo.CountPurgeableCakesFunc = func(ctx context.Context, deletedBefore time.Time) (int64, error) {
+				return 9, nil
+			}
+			uc := &CakeUsecase{
+				dbCakeRepository: mockCakeRepo,
+			}
+			got, err := uc.PurgeDeletedCakes(context.TODO(), tt.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CakeUsecase.PurgeDeletedCakes() got = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("CakeUsecase.PurgeDeletedCakes() batch calls = %v, want %v", calls, tt.wantCalls)
+			}
+			if !tt.wantErr && got.Purged != tt.wantPurged {
+				t.Errorf("CakeUsecase.PurgeDeletedCakes() purged = %v, want %v", got.Purged, tt.wantPurged)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_BatchCakes(t *testing.T) {
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	timeMock = timeMock.UTC()
+	operations := []model.BatchCakeOperation{
+		{Index: 0, Op: model.BatchOpCreate, Payload: model.CakePayloadQuery{Title: "title", Rating: 4}},
+		{Index: 1, Op: model.BatchOpUpdate, ID: 1, Version: 1, Payload: model.CakePayloadQuery{Title: "title", Rating: 4}},
+		{Index: 2, Op: model.BatchOpDelete, ID: 1, Version: 2},
+	}
+	tests := []struct {
+		name          string
+		param         model.BatchCakesUsecaseParam
+		want          *model.BatchCakesResponse
+		wantErr       error
+		wantCommitted bool
+	}{
+		{
+			name: "atomic batch succeed",
+			param: model.BatchCakesUsecaseParam{
+				Atomic:     true,
+				Operations: operations[:2],
+			},
+			want: &model.BatchCakesResponse{
+				Succeeded: 2,
+				Results: []model.BatchCakeResult{
+					{Index: 0, Op: model.BatchOpCreate, ID: 1, Cake: &model.CakeResponse{ID: 1, Title: "title", CreatedAt: "2006-01-02T15:04:05Z", UpdatedAt: "2006-01-02T15:04:05Z", Version: 2}},
+					{Index: 1, Op: model.BatchOpUpdate, ID: 1, Cake: &model.CakeResponse{ID: 1, Title: "title", CreatedAt: "2006-01-02T15:04:05Z", UpdatedAt: "2006-01-02T15:04:05Z", Version: 2}},
+				},
+			},
+			wantCommitted: true,
+		},
+		{
+			name: "atomic batch rolled back on version mismatch",
+			param: model.BatchCakesUsecaseParam{
+				Atomic:     true,
+				Operations: operations,
+			},
+			wantErr:       model.ErrVersionMismatch,
+			wantCommitted: false,
+		},
+		{
+			name: "best effort batch report failed operation",
+			param: model.BatchCakesUsecaseParam{
+				Atomic:     false,
+				Operations: operations[1:],
+			},
+			want: &model.BatchCakesResponse{
+				Succeeded: 1,
+				Failed:    1,
+				Results: []model.BatchCakeResult{
+					{Index: 1, Op: model.BatchOpUpdate, ID: 1, Cake: &model.CakeResponse{ID: 1, Title: "title", CreatedAt: "2006-01-02T15:04:05Z", UpdatedAt: "2006-01-02T15:04:05Z", Version: 2}},
+					{
+						Index:        2,
+						Op:           model.BatchOpDelete,
+						ID:           1,
+						ErrorCode:    model.ErrCodeCakeModified,
+						ErrorMessage: "cake data with id 1 has been modified, current version is 2",
+						ErrorParams:  map[string]interface{}{"id": 1, "version": 2},
+						Err:          model.ErrVersionMismatch,
+					},
+				},
+			},
+			wantCommitted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			committed := false
+			mockCakeRepo := &repository.CakeDBInterfaceMock{}
+			mockCakeRepo.RunInTxFunc = func(ctx context.Context, fn func(txRepo repository.CakeDBInterface) error) error {
+				err := fn(mockCakeRepo)
+				committed = err == nil
+				return err
+			}
+			mockCakeRepo.InsertCakeFunc = func(ctx context.Context, param model.CakePayloadQuery) (int64, error) {
+				return 1, nil
+			}
+			mockCakeRepo.UpdateCakeFunc = func(ctx context.Context, id int, version int, param model.CakePayloadQuery) (bool, error) {
+				return version == 1, nil
+			}
+			mockCakeRepo.SoftDeleteCakeFunc = func(ctx context.Context, id int, version int, deletedAt time.Time) (bool, error) {
+				return version == 3, nil
+			}
+			mockCakeRepo.GetCakeFunc = func(ctx context.Context, id int) (*model.Cake, error) {
+				return &model.Cake{ID: id, Title: "title", CreatedAt: timeMock, UpdatedAt: timeMock, Version: 2}, nil
+			}
+			uc := &CakeUsecase{
+				dbCakeRepository: mockCakeRepo,
+			}
+			got, errResponse := uc.BatchCakes(context.TODO(), tt.param)
+			if (errResponse != nil) != (tt.wantErr != nil) || (errResponse != nil && !errors.Is(errResponse, tt.wantErr)) {
+				t.Errorf("CakeUsecase.BatchCakes() error = %v, want %v", errResponse, tt.wantErr)
+			}
+			if committed != tt.wantCommitted {
+				t.Errorf("CakeUsecase.BatchCakes() committed = %v, want %v", committed, tt.wantCommitted)
+			}
+			// error of failed operation is compared by its kind
+			if got != nil && tt.want != nil && len(got.Results) == len(tt.want.Results) {
+				for i, result := range got.Results {
+					if result.Err != nil && tt.want.Results[i].Err != nil && errors.Is(result.Err, tt.want.Results[i].Err) {
+						got.Results[i].Err = tt.want.Results[i].Err
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.BatchCakes() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_BatchCakes_imageURL(t *testing.T) {
+	image := "https://cdn.example.com/images/cakes/1.png"
+	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockCakeRepo.RunInTxFunc = func(ctx context.Context, fn func(txRepo repository.CakeDBInterface) error) error {
+		return fn(mockCakeRepo)
+	}
+	mockCakeRepo.InsertCakeFunc = func(ctx context.Context, param model.CakePayloadQuery) (int64, error) {
+		return 1, nil
+	}
+	mockCakeRepo.GetCakeFunc = func(ctx context.Context, id int) (*model.Cake, error) {
+		return &model.Cake{ID: id, Title: "title", Image: &image, Version: 1}, nil
+	}
+	uc := &CakeUsecase{
+		dbCakeRepository: mockCakeRepo,
+		imageBaseURL:     "https://cdn.example.com/images",
+	}
+	got, errResponse := uc.BatchCakes(context.TODO(), model.BatchCakesUsecaseParam{
+		Atomic:     true,
+		Operations: []model.BatchCakeOperation{{Index: 0, Op: model.BatchOpCreate, Payload: model.CakePayloadQuery{Title: "title", Image: &image}}},
+	})
+	if errResponse != nil {
+		t.Fatalf("CakeUsecase.BatchCakes() error = %v", errResponse)
+	}
+	want := uc.mapCakeImagesResponse(image)
+	if !reflect.DeepEqual(got.Results[0].Cake.Images, want) {
+		t.Errorf("CakeUsecase.BatchCakes() images = %v, want %v", got.Results[0].Cake.Images, want)
+	}
+	if want.Variants[0].URL == image {
+		t.Errorf("CakeUsecase.BatchCakes() variant url = %v, want url of stored variant", want.Variants[0].URL)
+	}
+}
+
+func TestCakeUsecase_ImportCakes(t *testing.T) {
+	titleErrors := model.ValidationErrors{{Field: "title", Code: "required", Message: "field 'title' cannot be empty"}}
+	// makeNext: row iterator of validRows valid rows followed by invalidRows invalid rows
+	makeNext := func(validRows int, invalidRows int, readErr error) func() (model.ImportCakeRow, error) {
+		line := 1
+		return func() (model.ImportCakeRow, error) {
+			line++
+			switch {
+			case line-1 <= validRows:
+				return model.ImportCakeRow{Line: line, Payload: model.CakePayloadQuery{Title: "title", Rating: 4}}, nil
+			case line-1 <= validRows+invalidRows:
+				return model.ImportCakeRow{Line: line, Err: titleErrors}, nil
+			case readErr != nil:
+				return model.ImportCakeRow{}, readErr
+			}
+			return model.ImportCakeRow{}, io.EOF
+		}
+	}
+	tests := []struct {
+		name        string
+		param       model.ImportCakesUsecaseParam
+		insertErr   error
+		want        *model.ImportCakesResponse
+		wantInserts []int
+		wantErr     error
+	}{
+		{
+			name: "insert valid rows in batches",
+			param: model.ImportCakesUsecaseParam{
+				Next: makeNext(1001, 1, nil),
+			},
+			want: &model.ImportCakesResponse{
+				TotalRows:    1002,
+				ValidRows:    1001,
+				ImportedRows: 1001,
+				RejectedRows: 1,
+				Errors: []model.ImportCakeError{
+					{
+						Line:         1003,
+						ErrorCode:    model.ErrCodeValidationFailed,
+						ErrorMessage: "field 'title' cannot be empty",
+						ErrorParams:  map[string]interface{}{"detail": "field 'title' cannot be empty"},
+						Errors:       titleErrors,
+					},
+				},
+			},
+			wantInserts: []int{500, 500, 1},
+		},
+		{
+			name: "dry run only validate",
+			param: model.ImportCakesUsecaseParam{
+				Next:   makeNext(2, 1, nil),
+				DryRun: true,
+			},
+			want: &model.ImportCakesResponse{
+				DryRun:       true,
+				TotalRows:    3,
+				ValidRows:    2,
+				RejectedRows: 1,
+				Errors: []model.ImportCakeError{
+					{
+						Line:         4,
+						ErrorCode:    model.ErrCodeValidationFailed,
+						ErrorMessage: "field 'title' cannot be empty",
+						ErrorParams:  map[string]interface{}{"detail": "field 'title' cannot be empty"},
+						Errors:       titleErrors,
+					},
+				},
+			},
+			wantInserts: []int{},
+		},
+		{
+			name: "error read file",
+			param: model.ImportCakesUsecaseParam{
+				Next: makeNext(1, 0, errors.New("error mock")),
+			},
+			wantInserts: []int{},
+			wantErr:     model.ErrValidation,
+		},
+		{
+			name: "error insert rows",
+			param: model.ImportCakesUsecaseParam{
+				Next: makeNext(1, 0, nil),
+			},
+			insertErr:   errors.New("error mock"),
+			wantInserts: []int{1},
+			wantErr:     model.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inserts := []int{}
+			mockCakeRepo := &repository.CakeDBInterfaceMock{}
+			mockCakeRepo.InsertCakesFunc = func(ctx context.Context, params []model.CakePayloadQuery) (int64, error) {
+				inserts = append(inserts, len(params))
+				if tt.insertErr != nil {
+					return 0, tt.insertErr
+				}
+				return int64(len(params)), nil
+			}
+			uc := &CakeUsecase{
+				dbCakeRepository: mockCakeRepo,
+			}
+			got, errResponse := uc.ImportCakes(context.TODO(), tt.param)
+			if (errResponse != nil) != (tt.wantErr != nil) || (errResponse != nil && !errors.Is(errResponse, tt.wantErr)) {
+				t.Errorf("CakeUsecase.ImportCakes() error = %v, want %v", errResponse, tt.wantErr)
+			}
+			if !reflect.DeepEqual(inserts, tt.wantInserts) {
+				t.Errorf("CakeUsecase.ImportCakes() inserts = %v, want %v", inserts, tt.wantInserts)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.ImportCakes() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_ExportCakes(t *testing.T) {
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	timeMock = timeMock.UTC()
+	mockCakeRepo := &repository.CakeDBInterfaceMock{}
+	mockCakeRepo.StreamCakesFunc = func(ctx context.Context, param model.StreamCakesQuery, fn func(cake model.Cake) error) error {
+		switch param.Sort {
+		case "unknown":
+			return fmt.Errorf("%w '%s'", repository.ErrInvalidSortField, param.Sort)
+		case "error":
+			return errors.New("error mock")
+		}
+		return fn(model.Cake{ID: 1, Title: "title", CreatedAt: timeMock, UpdatedAt: timeMock})
+	}
+	tests := []struct {
+		name    string
+		param   model.ExportCakesUsecaseParam
+		want    []model.CakeResponse
+		wantErr error
+	}{
+		{
+			name: "basic test",
+			want: []model.CakeResponse{
+				{ID: 1, Title: "title", CreatedAt: "2006-01-02T15:04:05Z", UpdatedAt: "2006-01-02T15:04:05Z"},
+			},
+		},
+		{
+			name:    "invalid sort field",
+			param:   model.ExportCakesUsecaseParam{Sort: "unknown"},
+			want:    []model.CakeResponse{},
+			wantErr: model.ErrValidation,
+		},
+		{
+			name:    "error test",
+			param:   model.ExportCakesUsecaseParam{Sort: "error"},
+			want:    []model.CakeResponse{},
+			wantErr: model.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &CakeUsecase{
+				dbCakeRepository: mockCakeRepo,
+			}
+			got := []model.CakeResponse{}
+			errResponse := uc.ExportCakes(context.TODO(), tt.param, func(cake model.CakeResponse) error {
+				got = append(got, cake)
+				return nil
+			})
+			if (errResponse != nil) != (tt.wantErr != nil) || (errResponse != nil && !errors.Is(errResponse, tt.wantErr)) {
+				t.Errorf("CakeUsecase.ExportCakes() error = %v, want %v", errResponse, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.ExportCakes() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_UploadCakeImage(t *testing.T) {
+	timeMock, err := time.Parse(time.DateTime, "2006-01-02 15:04:05")
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected", err)
+	}
+	timeMock = timeMock.UTC()
+	content := []byte("\x89PNG image content")
+	wantURL := "http://127.0.0.1:8081/images/cakes/1/9fb93de1212d778cf6c6c4cb39aad829.png"
+	tests := []struct {
+		name        string
+		id          int
+		version     int
+		contentType string
+		want        *model.CakeResponse
+		wantErr     error
+		wantDeleted bool
+	}{
+		{
+			name:        "basic test",
+			id:          1,
+			version:     1,
+			contentType: "image/png",
+			want: &model.CakeResponse{
+				ID:    1,
+				Title: "title",
+				Image: &wantURL,
+				Images: &model.CakeImagesResponse{
+					Original: wantURL,
+					Variants: []model.CakeImageVariantResponse{
+						{Width: 128, Format: "jpeg", URL: wantURL + "_128.jpg"},
+						{Width: 512, Format: "jpeg", URL: wantURL + "_512.jpg"},
+						{Width: 1024, Format: "jpeg", URL: wantURL + "_1024.jpg"},
+					},
+				},
+				CreatedAt: "2006-01-02T15:04:05Z",
+				UpdatedAt: "2006-01-02T15:04:05Z",
+				Version:   2,
+			},
+		},
+		{
+			name:        "unsupported image type",
+			id:          1,
+			version:     1,
+			contentType: "image/svg+xml",
+			wantErr:     model.ErrUnsupported,
+		},
+		{
+			name:        "cake not found",
+			id:          2,
+			version:     1,
+			contentType: "image/png",
+			wantErr:     model.ErrCakeNotFound,
+		},
+		{
+			name:        "version not match remove stored image",
+			id:          1,
+			version:     3,
+			contentType: "image/png",
+			wantErr:     model.ErrVersionMismatch,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var image *string
+			deleted := false
+			mockImageStore := &storage.ImageStoreMock{}
+			mockImageStore.PutFunc = func(ctx context.Context, key string, contentType string, content io.Reader, size int64) error {
+				if key != "cakes/1/9fb93de1212d778cf6c6c4cb39aad829.png" || contentType != "image/png" {
+					t.Errorf("ImageStore.Put() key = %v, content type = %v", key, contentType)
+				}
+				return nil
+			}
+			mockImageStore.DeleteFunc = func(ctx context.Context, key string) error {
+				deleted = true
+				return nil
+			}
+			mockCakeRepo := &repository.CakeDBInterfaceMock{}
+			mockCakeRepo.GetCakeFunc = func(ctx context.Context, id int) (*model.Cake, error) {
+				if id != 1 {
+					return nil, nil
+				}
+				version := 1
+				if image != nil {
+					version = 2
+				}
+				return &model.Cake{ID: id, Title: "title", Image: image, CreatedAt: timeMock, UpdatedAt: timeMock, Version: version}, nil
+			}
+			mockCakeRepo.PatchCakeFunc = func(ctx context.Context, id int, version int, param model.CakePatchQuery) (bool, error) {
+				if version != 1 {
+					return false, nil
+				}
+				image = param.Image.Ptr()
+				return true, nil
+			}
+			imageVariantJobs := make(chan string, 1)
+			uc := &CakeUsecase{
+				dbCakeRepository: mockCakeRepo,
+				imageStore:       mockImageStore,
+				imageBaseURL:     "http://127.0.0.1:8081/images",
+				imageVariantJobs: imageVariantJobs,
+			}
+			got, errResponse := uc.UploadCakeImage(context.TODO(), tt.id, tt.version, model.UploadCakeImageParam{
+				Content:     content,
+				ContentType: tt.contentType,
+			})
+			if (errResponse != nil) != (tt.wantErr != nil) || (errResponse != nil && !errors.Is(errResponse, tt.wantErr)) {
+				t.Errorf("CakeUsecase.UploadCakeImage() error = %v, want %v", errResponse, tt.wantErr)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("CakeUsecase.UploadCakeImage() image deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CakeUsecase.UploadCakeImage() got = %v, want %v", got, tt.want)
+			}
+			if scheduled := len(imageVariantJobs) > 0; scheduled != (tt.want != nil) {
+				t.Errorf("CakeUsecase.UploadCakeImage() variants scheduled = %v, want %v", scheduled, tt.want != nil)
+			}
+		})
+	}
+}
+
+func TestCakeUsecase_GetImage(t *testing.T) {
+	mockImageStore := &storage.ImageStoreMock{}
+	mockImageStore.GetFunc = func(ctx context.Context, key string) (*model.ImageObject, error) {
+		switch key {
+		case "cakes/1/image.png":
+			return &model.ImageObject{ContentType: "image/png", Size: 1}, nil
+		case "cakes/1/error.png":
+			return nil, errors.New("error mock")
+		}
+		return nil, storage.ErrImageNotFound
+	}
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{
+			name: "basic test",
+			key:  "cakes/1/image.png",
+		},
+		{
+			name:    "image not found",
+			key:     "cakes/1/missing.png",
+			wantErr: model.ErrImageNotFound,
+		},
+		{
+			name:    "error test",
+			key:     "cakes/1/error.png",
+			wantErr: model.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &CakeUsecase{
+				imageStore: mockImageStore,
+			}
+			got, errResponse := uc.GetImage(context.TODO(), tt.key)
+			if (errResponse != nil) != (tt.wantErr != nil) || (errResponse != nil && !errors.Is(errResponse, tt.wantErr)) {
+				t.Errorf("CakeUsecase.GetImage() error = %v, want %v", errResponse, tt.wantErr)
+			}
+			if (got != nil) != (tt.wantErr == nil) {
+				t.Errorf("CakeUsecase.GetImage() got = %v", got)
 			}
 		})
 	}
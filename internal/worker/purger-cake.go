@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// CakePurger: background worker which periodically hard delete soft deleted cakes past retention
+type CakePurger struct {
+	cakeUsecase usecase.CakeUsecaseInterface
+	interval    time.Duration
+	param       model.PurgeCakesUsecaseParam
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+func NewCakePurger(cakeUsecase usecase.CakeUsecaseInterface, interval time.Duration, retention time.Duration, batchSize int) *CakePurger {
+	if cakeUsecase == nil {
+		logrus.Panic("cakeUsecase param for NewCakePurger is nil")
+	}
+	if interval <= 0 {
+		logrus.Panic("interval param for NewCakePurger must be positive")
+	}
+	if retention <= 0 {
+		logrus.Panic("retention param for NewCakePurger must be positive")
+	}
+	return &CakePurger{
+		cakeUsecase: cakeUsecase,
+		interval:    interval,
+		param: model.PurgeCakesUsecaseParam{
+			Retention: retention,
+			BatchSize: batchSize,
+		},
+	}
+}
+
+// Start: run purge immediately then on every interval until Stop is called
+func (p *CakePurger) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			p.purge(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop: cancel running purge and wait until worker exit
+func (p *CakePurger) Stop() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	p.wg.Wait()
+}
+
+func (p *CakePurger) purge(ctx context.Context) {
+	response, errResponse := p.cakeUsecase.PurgeDeletedCakes(ctx, p.param)
+	if errResponse != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		logrus.Errorf("error purge deleted cakes: %s %v", errResponse.Err, errResponse.ErrData)
+		return
+	}
+	if response.Purged > 0 {
+		logrus.Infof("purged %d cakes deleted before %s", response.Purged, response.DeletedBefore.Format(time.RFC3339))
+	}
+}
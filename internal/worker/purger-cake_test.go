@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+)
+
+func TestCakePurger_StartStop(t *testing.T) {
+	var calls int32
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.PurgeDeletedCakesFunc = func(ctx context.Context, param model.PurgeCakesUsecaseParam) (*model.PurgeCakesResponse, *model.ErrorResponse) {
+		atomic.AddInt32(&calls, 1)
+		if param.Retention != time.Hour || param.BatchSize != 10 || param.DryRun {
+			t.Errorf("CakePurger param = %v, want retention 1h and batch size 10", param)
+		}
+		return &model.PurgeCakesResponse{}, nil
+	}
+	purger := NewCakePurger(mockCakeUsecase, 10*time.Millisecond, time.Hour, 10)
+	purger.Start()
+	time.Sleep(35 * time.Millisecond)
+	purger.Stop()
+	got := atomic.LoadInt32(&calls)
+	if got < 2 {
+		t.Errorf("CakePurger purge calls = %v, want at least 2", got)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if atomic.LoadInt32(&calls) != got {
+		t.Errorf("CakePurger still running after Stop")
+	}
+}
+
+func TestNewCakePurger_nonPositiveRetention(t *testing.T) {
+	for _, retention := range []time.Duration{0, -time.Hour} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewCakePurger() retention %s does not panic", retention)
+				}
+			}()
+			NewCakePurger(&usecase.CakeUsecaseInterfaceMock{}, time.Hour, retention, 10)
+		}()
+	}
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// CakeThumbnailer: background worker which generate resized variants of uploaded cake image received from jobs
+type CakeThumbnailer struct {
+	cakeUsecase usecase.CakeUsecaseInterface
+	jobs        <-chan string
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+func NewCakeThumbnailer(cakeUsecase usecase.CakeUsecaseInterface, jobs <-chan string) *CakeThumbnailer {
+	if cakeUsecase == nil {
+		logrus.Panic("cakeUsecase param for NewCakeThumbnailer is nil")
+	}
+	if jobs == nil {
+		logrus.Panic("jobs param for NewCakeThumbnailer is nil")
+	}
+	return &CakeThumbnailer{
+		cakeUsecase: cakeUsecase,
+		jobs:        jobs,
+	}
+}
+
+// Start: generate variants of each received image key until Stop is called
+func (w *CakeThumbnailer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case key := <-w.jobs:
+				w.generate(ctx, key)
+			}
+		}
+	}()
+}
+
+// Stop: cancel running generation and wait until worker exit, queued images are left to regeneration command
+func (w *CakeThumbnailer) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *CakeThumbnailer) generate(ctx context.Context, key string) {
+	generated, errResponse := w.cakeUsecase.GenerateImageVariants(ctx, key, false)
+	if errResponse != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		logrus.Errorf("error generate variants of image '%s': %s %v", key, errResponse.Err, errResponse.ErrData)
+		return
+	}
+	if generated {
+		logrus.Infof("generated variants of image '%s'", key)
+	}
+}
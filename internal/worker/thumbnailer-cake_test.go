@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/forderation/ralali-test/internal/model"
+	"github.com/forderation/ralali-test/internal/usecase"
+)
+
+func TestCakeThumbnailer_StartStop(t *testing.T) {
+	var mu sync.Mutex
+	got := []string{}
+	mockCakeUsecase := &usecase.CakeUsecaseInterfaceMock{}
+	mockCakeUsecase.GenerateImageVariantsFunc = func(ctx context.Context, key string, force bool) (bool, *model.ErrorResponse) {
+		if force {
+			t.Errorf("CakeThumbnailer force = %v, want false", force)
+		}
+		mu.Lock()
+		got = append(got, key)
+		mu.Unlock()
+		if key == "cakes/2/broken.png" {
+			return false, &model.ErrorResponse{
+				Err:  errors.New("error mock"),
+				Kind: model.ErrUnsupported,
+			}
+		}
+		return true, nil
+	}
+	jobs := make(chan string, 3)
+	thumbnailer := NewCakeThumbnailer(mockCakeUsecase, jobs)
+	thumbnailer.Start()
+	jobs <- "cakes/1/image.png"
+	jobs <- "cakes/2/broken.png"
+	jobs <- "cakes/3/image.jpg"
+	time.Sleep(20 * time.Millisecond)
+	thumbnailer.Stop()
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 3 || got[0] != "cakes/1/image.png" || got[2] != "cakes/3/image.jpg" {
+		t.Errorf("CakeThumbnailer generated = %v, want all queued images in order", got)
+	}
+	select {
+	case jobs <- "cakes/4/image.png":
+		time.Sleep(10 * time.Millisecond)
+		if len(got) != 3 {
+			t.Errorf("CakeThumbnailer still running after Stop")
+		}
+	default:
+	}
+}
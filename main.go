@@ -3,18 +3,28 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// zone database of tz parameter, runtime image may not ship one
+	_ "time/tzdata"
 
 	"github.com/forderation/ralali-test/docs"
+	"github.com/forderation/ralali-test/internal/auth"
 	"github.com/forderation/ralali-test/internal/delivery"
+	"github.com/forderation/ralali-test/internal/model"
 	"github.com/forderation/ralali-test/internal/repository"
+	"github.com/forderation/ralali-test/internal/storage"
 	"github.com/forderation/ralali-test/internal/usecase"
+	"github.com/forderation/ralali-test/internal/worker"
+	cakev1 "github.com/forderation/ralali-test/proto/cake/v1"
 	"github.com/forderation/ralali-test/util"
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
@@ -23,14 +33,43 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	_ "go.uber.org/mock/mockgen/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT as "Bearer <token>", required on cake mutations
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description api key of machine client, limited by its scopes
 func main() {
 	loadConfigFile()
 	mySqlDB := initMysqlDB(viper.GetString("db_dsn"))
 	cakeDBRepository := repository.NewCakeDBRepository(mySqlDB, viper.GetString("cakes_table"))
-	cakeUsecase := usecase.NewCakeUsecase(cakeDBRepository)
-	cakeDelivery := delivery.NewCakeDelivery(cakeUsecase)
+	imageStore := initImageStore(viper.GetString("image_store"))
+	imageVariantJobs := make(chan string, viper.GetInt("image_variant_queue_size"))
+	cakeUsecase := usecase.NewCakeUsecase(cakeDBRepository, imageStore, viper.GetString("image_base_url"), imageVariantJobs)
+	if len(os.Args) > 1 && os.Args[1] == "purge" {
+		runPurgeCommand(cakeUsecase, os.Args[2:])
+		closeMySQLDB(context.Background(), mySqlDB)
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "thumbnails" {
+		runThumbnailsCommand(cakeUsecase, os.Args[2:])
+		closeMySQLDB(context.Background(), mySqlDB)
+		return
+	}
+	retention := purgeRetention()
+	imageURLValidator := delivery.NewImageURLValidator(imageURLAllowedHosts(), viper.GetBool("image_url_check_existence"), viper.GetDuration("image_url_check_timeout"), viper.GetString("image_base_url"))
+	cakeDelivery := delivery.NewCakeDelivery(cakeUsecase, viper.GetInt64("image_max_size"), imageURLValidator)
+	imageDelivery := delivery.NewImageDelivery(cakeUsecase, viper.GetString("image_cache_control"))
+	graphqlDelivery := delivery.NewGraphqlDelivery(cakeUsecase, imageURLValidator, viper.GetInt("graphql_max_complexity"))
+	verifier := initAuthVerifier(viper.GetBool("auth_enabled"))
+	apiKeyDBRepository := repository.NewApiKeyDBRepository(mySqlDB, viper.GetString("api_keys_table"))
+	apiKeyDelivery := delivery.NewApiKeyDelivery(usecase.NewApiKeyUsecase(apiKeyDBRepository))
 
 	docs.SwaggerInfo.Title = "Ralali App"
 	docs.SwaggerInfo.Description = "ralali cake demo app"
@@ -38,7 +77,7 @@ func main() {
 	docs.SwaggerInfo.Host = "127.0.0.1:8081"
 	docs.SwaggerInfo.Schemes = []string{"http"}
 
-	routes := initRoute(cakeDelivery)
+	routes := initRoute(cakeDelivery, imageDelivery, graphqlDelivery, apiKeyDelivery, verifier, viper.GetBool("dev_mode"), viper.GetTime("unversioned_deprecated_at"), viper.GetTime("unversioned_sunset_at"), viper.GetString("cache_control_cakes"), viper.GetString("cache_control_cake"), viper.GetString("default_language"), viper.GetString("error_format"), viper.GetString("problem_type_base_url"))
 	address := viper.GetString("service_addr")
 	srv := &http.Server{Addr: address, Handler: routes}
 	go func() {
@@ -46,15 +85,35 @@ func main() {
 			log.Fatalf("listen: %s\n", err)
 		}
 	}()
+	grpcServer := initGrpcServer(delivery.NewCakeGrpcServer(cakeUsecase, imageURLValidator, viper.GetString("default_language")), verifier, viper.GetString("default_language"))
+	grpcListener, err := net.Listen("tcp", viper.GetString("grpc_addr"))
+	if err != nil {
+		log.Fatalf("grpc listen: %s\n", err)
+	}
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatalf("grpc serve: %s\n", err)
+		}
+	}()
+	cakePurger := worker.NewCakePurger(cakeUsecase, viper.GetDuration("purge_interval"), retention, viper.GetInt("purge_batch_size"))
+	cakePurger.Start()
+	cakeThumbnailer := worker.NewCakeThumbnailer(cakeUsecase, imageVariantJobs)
+	cakeThumbnailer.Start()
 
 	// gracefully shutdown
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("shutdown service ...")
 	ctx := context.Background()
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	stopGrpcServer(ctx, grpcServer)
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Println("error shutdown http server: ", err)
+	}
+	cakePurger.Stop()
+	cakeThumbnailer.Stop()
 	closeMySQLDB(ctx, mySqlDB)
 	select {
 	case <-ctx.Done():
@@ -70,22 +129,186 @@ func loadConfigFile() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	// secret should not be committed on config file
+	viper.BindEnv("jwt_hs256_secret", "JWT_HS256_SECRET")
+	viper.BindEnv("auth_enabled", "AUTH_ENABLED")
 	log.Println("using config file:", viper.ConfigFileUsed())
 }
 
-func initRoute(cakeDelivery *delivery.CakeDelivery) *gin.Engine {
+// runPurgeCommand: one-shot purge of soft deleted cakes past retention, usage: ./main purge [-dry-run] [-retention 2160h]
+func runPurgeCommand(cakeUsecase usecase.CakeUsecaseInterface, args []string) {
+	flags := flag.NewFlagSet("purge", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "only count cakes that would be purged")
+	retention := flags.Duration("retention", viper.GetDuration("purge_retention"), "purge cakes soft deleted longer than retention")
+	flags.Parse(args)
+	if *retention <= 0 {
+		log.Fatalf("retention must be positive, got %s", *retention)
+	}
+	response, errResponse := cakeUsecase.PurgeDeletedCakes(context.Background(), model.PurgeCakesUsecaseParam{
+		Retention: *retention,
+		BatchSize: viper.GetInt("purge_batch_size"),
+		DryRun:    *dryRun,
+	})
+	if errResponse != nil {
+		log.Fatalf("error purge deleted cakes: %s %v", errResponse.Err, errResponse.ErrData)
+	}
+	if response.DryRun {
+		log.Printf("dry run: %d cakes deleted before %s would be purged", response.Purged, response.DeletedBefore.Format(time.RFC3339))
+		return
+	}
+	log.Printf("purged %d cakes deleted before %s", response.Purged, response.DeletedBefore.Format(time.RFC3339))
+}
+
+// purgeRetention: configured retention of soft deleted cakes, missing or non positive purge_retention would purge the whole trash
+func purgeRetention() time.Duration {
+	retention := viper.GetDuration("purge_retention")
+	if retention <= 0 {
+		log.Fatalf("purge_retention must be positive, got '%s'", viper.GetString("purge_retention"))
+	}
+	return retention
+}
+
+// runThumbnailsCommand: one-shot generation of missing cake image variants, usage: ./main thumbnails [-force]
+func runThumbnailsCommand(cakeUsecase usecase.CakeUsecaseInterface, args []string) {
+	flags := flag.NewFlagSet("thumbnails", flag.ExitOnError)
+	force := flags.Bool("force", false, "regenerate variants even when they already exist")
+	flags.Parse(args)
+	response, errResponse := cakeUsecase.RegenerateImageVariants(context.Background(), model.RegenerateImageVariantsParam{
+		Force: *force,
+	})
+	if errResponse != nil {
+		log.Fatalf("error regenerate image variants: %s %v", errResponse.Err, errResponse.ErrData)
+	}
+	log.Printf("image variants of %d images: %d generated, %d skipped, %d failed", response.Images, response.Generated, response.Skipped, response.Failed)
+}
+
+// imageURLAllowedHosts: configured allow-list of image url host, host of uploaded image is always allowed
+func imageURLAllowedHosts() []string {
+	hosts := viper.GetStringSlice("image_url_allowed_hosts")
+	if len(hosts) == 0 {
+		return hosts
+	}
+	baseURL, err := url.Parse(viper.GetString("image_base_url"))
+	if err == nil && baseURL.Hostname() != "" {
+		hosts = append(hosts, baseURL.Hostname())
+	}
+	return hosts
+}
+
+func initRoute(cakeDelivery *delivery.CakeDelivery, imageDelivery *delivery.ImageDelivery, graphqlDelivery *delivery.GraphqlDelivery, apiKeyDelivery *delivery.ApiKeyDelivery, verifier *auth.Verifier, devMode bool, deprecatedAt time.Time, sunsetAt time.Time, cacheControlCakes string, cacheControlCake string, defaultLanguage string, errorFormat string, problemTypeBaseURL string) *gin.Engine {
 	baseRoot := gin.Default()
 	baseRoot.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	baseRoot.Use(util.CORSMiddleware())
-	cakeRoutes := baseRoot.Group("/cakes")
-	cakeRoutes.GET("", cakeDelivery.GetCakes)
-	cakeRoutes.GET("/:id", cakeDelivery.GetCake)
-	cakeRoutes.POST("", cakeDelivery.CreateCake)
-	cakeRoutes.PUT("/:id", cakeDelivery.UpdateCake)
-	cakeRoutes.DELETE("/:id", cakeDelivery.DeleteCake)
+	baseRoot.Use(delivery.LanguageMiddleware(defaultLanguage))
+	baseRoot.Use(delivery.ErrorFormatMiddleware(errorFormat, problemTypeBaseURL))
+	v1Routes := baseRoot.Group("/v1/cakes", apiKeyDelivery.ApiKeyMiddleware(), delivery.AuthMiddleware(verifier), delivery.APIVersionMiddleware(delivery.APIVersion1), delivery.TimeZoneMiddleware())
+	initCakeRoutes(v1Routes, cakeDelivery, cacheControlCakes, cacheControlCake)
+	initCakeBulkRoutes(v1Routes, cakeDelivery)
+	// unversioned routes are kept for existing clients until sunset
+	deprecatedRoutes := baseRoot.Group("/cakes", util.DeprecationMiddleware(deprecatedAt, sunsetAt, "/v1"), apiKeyDelivery.ApiKeyMiddleware(), delivery.AuthMiddleware(verifier), delivery.APIVersionMiddleware(delivery.APIVersion1), delivery.TimeZoneMiddleware())
+	initCakeRoutes(deprecatedRoutes, cakeDelivery, cacheControlCakes, cacheControlCake)
+	initCakeBulkRoutes(deprecatedRoutes, cakeDelivery)
+	v2Routes := baseRoot.Group("/v2/cakes", apiKeyDelivery.ApiKeyMiddleware(), delivery.AuthMiddleware(verifier), delivery.APIVersionMiddleware(delivery.APIVersion2), delivery.TimeZoneMiddleware())
+	initCakeRoutes(v2Routes, cakeDelivery, cacheControlCakes, cacheControlCake)
+	initCakeBulkRoutes(v2Routes, cakeDelivery)
+	baseRoot.GET("/images/*key", imageDelivery.GetImage)
+	baseRoot.GET("/graphql", apiKeyDelivery.ApiKeyMiddleware(), delivery.OptionalAuthMiddleware(verifier), graphqlDelivery.Query)
+	baseRoot.POST("/graphql", apiKeyDelivery.ApiKeyMiddleware(), delivery.OptionalAuthMiddleware(verifier), graphqlDelivery.Query)
+	if verifier != nil {
+		apiKeyRoutes := baseRoot.Group("/admin/api-keys", delivery.AdminAuthMiddleware(verifier, model.ScopeApiKeysAdmin))
+		apiKeyRoutes.POST("", apiKeyDelivery.CreateApiKey)
+		apiKeyRoutes.GET("", apiKeyDelivery.GetApiKeys)
+		apiKeyRoutes.DELETE("/:id", apiKeyDelivery.RevokeApiKey)
+	} else {
+		log.Println("auth is disabled, api key admin routes are not registered")
+	}
+	if devMode {
+		baseRoot.GET("/graphiql", graphqlDelivery.Playground)
+	}
 	return baseRoot
 }
 
+// initCakeRoutes: cake routes served on every API version, response shape follow APIVersionMiddleware
+func initCakeRoutes(cakeRoutes *gin.RouterGroup, cakeDelivery *delivery.CakeDelivery, cacheControlCakes string, cacheControlCake string) {
+	cakeRoutes.GET("", delivery.RequireScope(model.ScopeCakesRead), util.CacheControlMiddleware(cacheControlCakes), cakeDelivery.GetCakes)
+	cakeRoutes.GET("/trash", delivery.RequireScope(model.ScopeCakesRead), cakeDelivery.GetDeletedCakes)
+	cakeRoutes.GET("/:id", delivery.RequireScope(model.ScopeCakesRead), util.CacheControlMiddleware(cacheControlCake), cakeDelivery.GetCake)
+	cakeRoutes.POST("", delivery.RequireScope(model.ScopeCakesWrite), cakeDelivery.CreateCake)
+	cakeRoutes.PUT("/:id", delivery.RequireScope(model.ScopeCakesWrite), cakeDelivery.UpdateCake)
+	cakeRoutes.PATCH("/:id", delivery.RequireScope(model.ScopeCakesWrite), cakeDelivery.PatchCake)
+	cakeRoutes.DELETE("/:id", delivery.RequireScope(model.ScopeCakesWrite), cakeDelivery.DeleteCake)
+	cakeRoutes.POST("/:id/restore", delivery.RequireScope(model.ScopeCakesWrite), cakeDelivery.RestoreCake)
+	cakeRoutes.POST("/:id/image", delivery.RequireScope(model.ScopeCakesWrite), cakeDelivery.UploadCakeImage)
+}
+
+// initCakeBulkRoutes: export, batch and import routes, response shape follow APIVersionMiddleware
+func initCakeBulkRoutes(cakeRoutes *gin.RouterGroup, cakeDelivery *delivery.CakeDelivery) {
+	cakeRoutes.GET("/export", delivery.RequireScope(model.ScopeCakesRead), cakeDelivery.ExportCakes)
+	cakeRoutes.POST("/batch", delivery.RequireScope(model.ScopeCakesWrite), cakeDelivery.BatchCakes)
+	cakeRoutes.POST("/import", delivery.RequireScope(model.ScopeCakesWrite), cakeDelivery.ImportCakes)
+}
+
+// initGrpcServer: gRPC server of cake service, reflection is registered for grpcurl
+func initGrpcServer(cakeServer cakev1.CakeServiceServer, verifier *auth.Verifier, defaultLanguage string) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(delivery.GrpcAuthInterceptor(verifier, defaultLanguage)))
+	cakev1.RegisterCakeServiceServer(server, cakeServer)
+	reflection.Register(server)
+	return server
+}
+
+// stopGrpcServer: wait in-flight rpc (including streams) to finish, remaining rpc is cancelled when ctx is done
+func stopGrpcServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+// initAuthVerifier: verifier of bearer token on mutation, nil when authentication is disabled.
+// service refuse to start when authentication is enabled without any key
+func initAuthVerifier(enabled bool) *auth.Verifier {
+	if !enabled {
+		log.Println("authentication is disabled, anyone can modify cakes")
+		return nil
+	}
+	verifier, err := auth.NewVerifier(auth.VerifierConfig{
+		HS256Secret:        viper.GetString("jwt_hs256_secret"),
+		RS256PublicKeyFile: viper.GetString("jwt_rs256_public_key_file"),
+		JWKSFile:           viper.GetString("jwt_jwks_file"),
+		Issuer:             viper.GetString("jwt_issuer"),
+		Audience:           viper.GetString("jwt_audience"),
+		Leeway:             viper.GetDuration("jwt_leeway"),
+	})
+	if err != nil {
+		log.Fatal("error init auth verifier: ", err)
+	}
+	return verifier
+}
+
+// initImageStore: image store by config, local (default) or s3
+func initImageStore(kind string) storage.ImageStore {
+	switch kind {
+	case "", "local":
+		return storage.NewLocalImageStore(viper.GetString("image_local_dir"))
+	case "s3":
+		return storage.NewS3ImageStore(storage.S3ImageStoreConfig{
+			Endpoint:  viper.GetString("image_s3_endpoint"),
+			Region:    viper.GetString("image_s3_region"),
+			Bucket:    viper.GetString("image_s3_bucket"),
+			AccessKey: viper.GetString("image_s3_access_key"),
+			SecretKey: viper.GetString("image_s3_secret_key"),
+		}, nil)
+	}
+	logrus.Panic("unknown image_store config: ", kind)
+	return nil
+}
+
 func initMysqlDB(dsn string) *sql.DB {
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
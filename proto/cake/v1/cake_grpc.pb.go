@@ -0,0 +1,326 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: cake/v1/cake.proto
+
+package cakev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CakeService_ListCakes_FullMethodName   = "/cake.v1.CakeService/ListCakes"
+	CakeService_StreamCakes_FullMethodName = "/cake.v1.CakeService/StreamCakes"
+	CakeService_GetCake_FullMethodName     = "/cake.v1.CakeService/GetCake"
+	CakeService_CreateCake_FullMethodName  = "/cake.v1.CakeService/CreateCake"
+	CakeService_UpdateCake_FullMethodName  = "/cake.v1.CakeService/UpdateCake"
+	CakeService_DeleteCake_FullMethodName  = "/cake.v1.CakeService/DeleteCake"
+)
+
+// CakeServiceClient is the client API for CakeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CakeServiceClient interface {
+	// ListCakes: paginated cakes, same as GET /cakes
+	ListCakes(ctx context.Context, in *ListCakesRequest, opts ...grpc.CallOption) (*ListCakesResponse, error)
+	// StreamCakes: every cake matching filter streamed from database without pagination, same as GET /cakes/export
+	StreamCakes(ctx context.Context, in *StreamCakesRequest, opts ...grpc.CallOption) (CakeService_StreamCakesClient, error)
+	GetCake(ctx context.Context, in *GetCakeRequest, opts ...grpc.CallOption) (*Cake, error)
+	CreateCake(ctx context.Context, in *CreateCakeRequest, opts ...grpc.CallOption) (*Cake, error)
+	UpdateCake(ctx context.Context, in *UpdateCakeRequest, opts ...grpc.CallOption) (*Cake, error)
+	DeleteCake(ctx context.Context, in *DeleteCakeRequest, opts ...grpc.CallOption) (*DeleteCakeResponse, error)
+}
+
+type cakeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCakeServiceClient(cc grpc.ClientConnInterface) CakeServiceClient {
+	return &cakeServiceClient{cc}
+}
+
+func (c *cakeServiceClient) ListCakes(ctx context.Context, in *ListCakesRequest, opts ...grpc.CallOption) (*ListCakesResponse, error) {
+	out := new(ListCakesResponse)
+	err := c.cc.Invoke(ctx, CakeService_ListCakes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cakeServiceClient) StreamCakes(ctx context.Context, in *StreamCakesRequest, opts ...grpc.CallOption) (CakeService_StreamCakesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CakeService_ServiceDesc.Streams[0], CakeService_StreamCakes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cakeServiceStreamCakesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CakeService_StreamCakesClient interface {
+	Recv() (*Cake, error)
+	grpc.ClientStream
+}
+
+type cakeServiceStreamCakesClient struct {
+	grpc.ClientStream
+}
+
+func (x *cakeServiceStreamCakesClient) Recv() (*Cake, error) {
+	m := new(Cake)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cakeServiceClient) GetCake(ctx context.Context, in *GetCakeRequest, opts ...grpc.CallOption) (*Cake, error) {
+	out := new(Cake)
+	err := c.cc.Invoke(ctx, CakeService_GetCake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cakeServiceClient) CreateCake(ctx context.Context, in *CreateCakeRequest, opts ...grpc.CallOption) (*Cake, error) {
+	out := new(Cake)
+	err := c.cc.Invoke(ctx, CakeService_CreateCake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cakeServiceClient) UpdateCake(ctx context.Context, in *UpdateCakeRequest, opts ...grpc.CallOption) (*Cake, error) {
+	out := new(Cake)
+	err := c.cc.Invoke(ctx, CakeService_UpdateCake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cakeServiceClient) DeleteCake(ctx context.Context, in *DeleteCakeRequest, opts ...grpc.CallOption) (*DeleteCakeResponse, error) {
+	out := new(DeleteCakeResponse)
+	err := c.cc.Invoke(ctx, CakeService_DeleteCake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CakeServiceServer is the server API for CakeService service.
+// All implementations must embed UnimplementedCakeServiceServer
+// for forward compatibility
+type CakeServiceServer interface {
+	// ListCakes: paginated cakes, same as GET /cakes
+	ListCakes(context.Context, *ListCakesRequest) (*ListCakesResponse, error)
+	// StreamCakes: every cake matching filter streamed from database without pagination, same as GET /cakes/export
+	StreamCakes(*StreamCakesRequest, CakeService_StreamCakesServer) error
+	GetCake(context.Context, *GetCakeRequest) (*Cake, error)
+	CreateCake(context.Context, *CreateCakeRequest) (*Cake, error)
+	UpdateCake(context.Context, *UpdateCakeRequest) (*Cake, error)
+	DeleteCake(context.Context, *DeleteCakeRequest) (*DeleteCakeResponse, error)
+	mustEmbedUnimplementedCakeServiceServer()
+}
+
+// UnimplementedCakeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCakeServiceServer struct {
+}
+
+func (UnimplementedCakeServiceServer) ListCakes(context.Context, *ListCakesRequest) (*ListCakesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCakes not implemented")
+}
+func (UnimplementedCakeServiceServer) StreamCakes(*StreamCakesRequest, CakeService_StreamCakesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCakes not implemented")
+}
+func (UnimplementedCakeServiceServer) GetCake(context.Context, *GetCakeRequest) (*Cake, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCake not implemented")
+}
+func (UnimplementedCakeServiceServer) CreateCake(context.Context, *CreateCakeRequest) (*Cake, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCake not implemented")
+}
+func (UnimplementedCakeServiceServer) UpdateCake(context.Context, *UpdateCakeRequest) (*Cake, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCake not implemented")
+}
+func (UnimplementedCakeServiceServer) DeleteCake(context.Context, *DeleteCakeRequest) (*DeleteCakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCake not implemented")
+}
+func (UnimplementedCakeServiceServer) mustEmbedUnimplementedCakeServiceServer() {}
+
+// UnsafeCakeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CakeServiceServer will
+// result in compilation errors.
+type UnsafeCakeServiceServer interface {
+	mustEmbedUnimplementedCakeServiceServer()
+}
+
+func RegisterCakeServiceServer(s grpc.ServiceRegistrar, srv CakeServiceServer) {
+	s.RegisterService(&CakeService_ServiceDesc, srv)
+}
+
+func _CakeService_ListCakes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCakesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CakeServiceServer).ListCakes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CakeService_ListCakes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CakeServiceServer).ListCakes(ctx, req.(*ListCakesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CakeService_StreamCakes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamCakesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CakeServiceServer).StreamCakes(m, &cakeServiceStreamCakesServer{stream})
+}
+
+type CakeService_StreamCakesServer interface {
+	Send(*Cake) error
+	grpc.ServerStream
+}
+
+type cakeServiceStreamCakesServer struct {
+	grpc.ServerStream
+}
+
+func (x *cakeServiceStreamCakesServer) Send(m *Cake) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CakeService_GetCake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CakeServiceServer).GetCake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CakeService_GetCake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CakeServiceServer).GetCake(ctx, req.(*GetCakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CakeService_CreateCake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CakeServiceServer).CreateCake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CakeService_CreateCake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CakeServiceServer).CreateCake(ctx, req.(*CreateCakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CakeService_UpdateCake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CakeServiceServer).UpdateCake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CakeService_UpdateCake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CakeServiceServer).UpdateCake(ctx, req.(*UpdateCakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CakeService_DeleteCake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CakeServiceServer).DeleteCake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CakeService_DeleteCake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CakeServiceServer).DeleteCake(ctx, req.(*DeleteCakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CakeService_ServiceDesc is the grpc.ServiceDesc for CakeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CakeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cake.v1.CakeService",
+	HandlerType: (*CakeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCakes",
+			Handler:    _CakeService_ListCakes_Handler,
+		},
+		{
+			MethodName: "GetCake",
+			Handler:    _CakeService_GetCake_Handler,
+		},
+		{
+			MethodName: "CreateCake",
+			Handler:    _CakeService_CreateCake_Handler,
+		},
+		{
+			MethodName: "UpdateCake",
+			Handler:    _CakeService_UpdateCake_Handler,
+		},
+		{
+			MethodName: "DeleteCake",
+			Handler:    _CakeService_DeleteCake_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCakes",
+			Handler:       _CakeService_StreamCakes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cake/v1/cake.proto",
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatETag: strong entity tag of versioned resource
+func FormatETag(version int) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// FormatZonedETag: strong entity tag of versioned resource whose timestamps are written on zone,
+// each zone is a different representation so it has its own tag. UTC keep the plain version tag
+func FormatZonedETag(version int, zone string) string {
+	if zone == "" || zone == "UTC" {
+		return FormatETag(version)
+	}
+	return fmt.Sprintf("\"%d;%s\"", version, zone)
+}
+
+// ParseIfMatch: parse If-Match header of versioned resource into its version, wildcard "*" will return 0.
+// only single strong entity tag is supported since the version is compared on conditional update
+func ParseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		return 0, errors.New("only single entity tag is supported")
+	}
+	if strings.HasPrefix(header, "W/") {
+		return 0, errors.New("weak entity tag cannot be used for If-Match")
+	}
+	if len(header) < 2 || !strings.HasPrefix(header, "\"") || !strings.HasSuffix(header, "\"") {
+		return 0, errors.New("entity tag must be quoted")
+	}
+	// zone of representation (see FormatZonedETag) does not change the version
+	value, _, _ := strings.Cut(header[1:len(header)-1], ";")
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		return 0, errors.New("unknown entity tag")
+	}
+	return version, nil
+}
+
+// FormatCollectionETag: weak entity tag of collection without version, built from number of item, sum of their version
+// and latest modified time so any mutation change it. zone is handled the same way as FormatZonedETag
+func FormatCollectionETag(count int64, versionSum int64, modifiedAt time.Time, zone string) string {
+	value := fmt.Sprintf("%d-%d-%d", count, versionSum, modifiedAt.Unix())
+	if zone != "" && zone != "UTC" {
+		value += ";" + zone
+	}
+	return fmt.Sprintf("W/\"%s\"", value)
+}
+
+// MatchIfNoneMatch: check If-None-Match header against current entity tag using weak comparison
+func MatchIfNoneMatch(header string, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// NotModifiedSince: check If-Modified-Since header, true if resource is not modified after the given date.
+// invalid header date is ignored as stated on RFC 7232
+func NotModifiedSince(header string, modifiedAt time.Time) bool {
+	if header == "" {
+		return false
+	}
+	since, err := http.ParseTime(header)
+	if err != nil {
+		return false
+	}
+	return !modifiedAt.Truncate(time.Second).After(since)
+}
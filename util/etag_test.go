@@ -0,0 +1,231 @@
+package util
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	type args struct {
+		header string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "basic test",
+			args: args{
+				header: FormatETag(3),
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "zoned entity tag",
+			args: args{
+				header: FormatZonedETag(3, "Asia/Jakarta"),
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "wildcard",
+			args: args{
+				header: " * ",
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "weak entity tag",
+			args: args{
+				header: `W/"3"`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple entity tag",
+			args: args{
+				header: `"3", "4"`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unquoted entity tag",
+			args: args{
+				header: "3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown entity tag",
+			args: args{
+				header: `"abc"`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIfMatch(tt.args.header)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseIfMatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseIfMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatZonedETag(t *testing.T) {
+	tests := []struct {
+		name string
+		zone string
+		want string
+	}{
+		{name: "utc", zone: "UTC", want: `"3"`},
+		{name: "empty zone", zone: "", want: `"3"`},
+		{name: "other zone", zone: "Asia/Jakarta", want: `"3;Asia/Jakarta"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatZonedETag(3, tt.zone); got != tt.want {
+				t.Errorf("FormatZonedETag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCollectionETag(t *testing.T) {
+	modifiedAt := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name       string
+		count      int64
+		versionSum int64
+		modifiedAt time.Time
+		zone       string
+		want       string
+	}{
+		{name: "utc", count: 2, versionSum: 5, modifiedAt: modifiedAt, zone: "UTC", want: `W/"2-5-1672671845"`},
+		{name: "other zone", count: 2, versionSum: 5, modifiedAt: modifiedAt, zone: "Asia/Jakarta", want: `W/"2-5-1672671845;Asia/Jakarta"`},
+		{name: "empty collection", zone: "", want: `W/"0-0--62135596800"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatCollectionETag(tt.count, tt.versionSum, tt.modifiedAt, tt.zone); got != tt.want {
+				t.Errorf("FormatCollectionETag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchIfNoneMatch(t *testing.T) {
+	type args struct {
+		header string
+		etag   string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "empty header",
+			args: args{
+				header: "",
+				etag:   `"1"`,
+			},
+			want: false,
+		},
+		{
+			name: "wildcard",
+			args: args{
+				header: "*",
+				etag:   `"1"`,
+			},
+			want: true,
+		},
+		{
+			name: "weak comparison on list",
+			args: args{
+				header: `"3", W/"1"`,
+				etag:   `"1"`,
+			},
+			want: true,
+		},
+		{
+			name: "not match",
+			args: args{
+				header: `"2"`,
+				etag:   `"1"`,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchIfNoneMatch(tt.args.header, tt.args.etag); got != tt.want {
+				t.Errorf("MatchIfNoneMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotModifiedSince(t *testing.T) {
+	modifiedAt := time.Date(2023, 1, 2, 15, 4, 5, 500, time.UTC)
+	type args struct {
+		header     string
+		modifiedAt time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "empty header",
+			args: args{
+				header:     "",
+				modifiedAt: modifiedAt,
+			},
+			want: false,
+		},
+		{
+			name: "same second",
+			args: args{
+				header:     modifiedAt.Format(http.TimeFormat),
+				modifiedAt: modifiedAt,
+			},
+			want: true,
+		},
+		{
+			name: "modified after",
+			args: args{
+				header:     modifiedAt.Add(-time.Minute).Format(http.TimeFormat),
+				modifiedAt: modifiedAt,
+			},
+			want: false,
+		},
+		{
+			name: "invalid date",
+			args: args{
+				header:     "yesterday",
+				modifiedAt: modifiedAt,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NotModifiedSince(tt.args.header, tt.args.modifiedAt); got != tt.want {
+				t.Errorf("NotModifiedSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
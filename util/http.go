@@ -1,12 +1,19 @@
 package util
 
-import "github.com/gin-gonic/gin"
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
 
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-Match, If-None-Match, If-Modified-Since, Time-Zone, X-API-Key")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, Last-Modified, Location, Deprecation, Sunset, Link")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -15,3 +22,42 @@ func CORSMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// CacheControlMiddleware: set Cache-Control header on 200 and 304 response, error response is never cached.
+// skipped when value is empty
+func CacheControlMiddleware(value string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if value != "" {
+			c.Writer.Header().Set("Cache-Control", value)
+			c.Writer = &cacheControlWriter{ResponseWriter: c.Writer}
+		}
+		c.Next()
+	}
+}
+
+// cacheControlWriter: drop Cache-Control header once status other than 200 and 304 is written,
+// body written without explicit status is 200 so the header is kept
+type cacheControlWriter struct {
+	gin.ResponseWriter
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code != http.StatusOK && code != http.StatusNotModified {
+		w.Header().Del("Cache-Control")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// DeprecationMiddleware: mark route as deprecated since deprecatedAt (RFC 9745) and removed at sunset (RFC 8594),
+// successor version is linked by prefixing request path e.g. /cakes/1 with /v1. zero sunset omit Sunset header
+func DeprecationMiddleware(deprecatedAt time.Time, sunset time.Time, successorPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+		if !sunset.IsZero() {
+			header.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+		header.Set("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successorPrefix, c.Request.URL.Path))
+		c.Next()
+	}
+}
@@ -0,0 +1,105 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDeprecationMiddleware(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 1, 7, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	tests := []struct {
+		name       string
+		sunset     time.Time
+		path       string
+		wantSunset string
+		wantLink   string
+	}{
+		{
+			name:       "basic test",
+			sunset:     sunset,
+			path:       "/cakes/1",
+			wantSunset: "Thu, 01 Apr 2027 00:00:00 GMT",
+			wantLink:   `</v1/cakes/1>; rel="successor-version"`,
+		},
+		{
+			name:     "without sunset",
+			path:     "/cakes",
+			wantLink: `</v1/cakes>; rel="successor-version"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			DeprecationMiddleware(deprecatedAt, tt.sunset, "/v1")(c)
+			if got := w.Header().Get("Deprecation"); got != "@1790812800" {
+				t.Errorf("Deprecation = %v, want %v", got, "@1790812800")
+			}
+			if got := w.Header().Get("Sunset"); got != tt.wantSunset {
+				t.Errorf("Sunset = %v, want %v", got, tt.wantSunset)
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Link = %v, want %v", got, tt.wantLink)
+			}
+		})
+	}
+}
+
+func TestCacheControlMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		handler gin.HandlerFunc
+		want    string
+	}{
+		{
+			name:    "basic test",
+			value:   "public, max-age=60",
+			handler: func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) },
+			want:    "public, max-age=60",
+		},
+		{
+			name:    "not modified",
+			value:   "public, max-age=60",
+			handler: func(c *gin.Context) { c.Status(http.StatusNotModified) },
+			want:    "public, max-age=60",
+		},
+		{
+			name:    "body without explicit status",
+			value:   "public, max-age=60",
+			handler: func(c *gin.Context) { c.Writer.WriteString("{}") },
+			want:    "public, max-age=60",
+		},
+		{
+			name:    "error response",
+			value:   "public, max-age=60",
+			handler: func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{}) },
+		},
+		{
+			name:    "aborted request",
+			value:   "public, max-age=60",
+			handler: func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) },
+		},
+		{
+			name:    "empty value",
+			handler: func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.GET("/cakes", CacheControlMiddleware(tt.value), tt.handler)
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/cakes", nil))
+			if got := w.Header().Get("Cache-Control"); got != tt.want {
+				t.Errorf("Cache-Control = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// ResizeImage: downscale image into given width keeping its aspect ratio using Catmull-Rom resampling,
+// image narrower than width is returned as it is. alpha channel is kept, see FlattenImage for format without alpha
+func ResizeImage(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	if width <= 0 || bounds.Dx() <= width {
+		return src
+	}
+	height := (bounds.Dy()*width + bounds.Dx()/2) / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, xdraw.Src, nil)
+	return dst
+}
+
+// FlattenImage: draw image over white background so it can be encoded into format without alpha channel e.g. JPEG
+func FlattenImage(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+	return flat
+}
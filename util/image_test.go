@@ -0,0 +1,97 @@
+package util
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResizeImage(t *testing.T) {
+	// left half black and right half white
+	halves := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 200; x < 400; x++ {
+			halves.Set(x, y, color.White)
+		}
+		for x := 0; x < 200; x++ {
+			halves.Set(x, y, color.Black)
+		}
+	}
+	transparent := image.NewNRGBA(image.Rect(10, 10, 30, 20))
+	transparent.SetNRGBA(10, 10, color.NRGBA{R: 255, A: 255})
+	tests := []struct {
+		name       string
+		src        image.Image
+		width      int
+		wantWidth  int
+		wantHeight int
+		wantPixels map[image.Point]color.NRGBA
+	}{
+		{
+			name:       "basic test",
+			src:        halves,
+			width:      100,
+			wantWidth:  100,
+			wantHeight: 50,
+			wantPixels: map[image.Point]color.NRGBA{
+				{X: 0, Y: 0}:   {R: 0, G: 0, B: 0, A: 255},
+				{X: 99, Y: 49}: {R: 255, G: 255, B: 255, A: 255},
+			},
+		},
+		{
+			name:       "narrower image is not upscaled",
+			src:        halves,
+			width:      1024,
+			wantWidth:  400,
+			wantHeight: 200,
+		},
+		{
+			name:       "height is at least 1 pixel",
+			src:        image.NewNRGBA(image.Rect(0, 0, 400, 1)),
+			width:      128,
+			wantWidth:  128,
+			wantHeight: 1,
+		},
+		{
+			name:       "transparent image keeps alpha",
+			src:        transparent,
+			width:      10,
+			wantWidth:  10,
+			wantHeight: 5,
+			wantPixels: map[image.Point]color.NRGBA{
+				{X: 9, Y: 4}: {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResizeImage(tt.src, tt.width)
+			if got.Bounds().Dx() != tt.wantWidth || got.Bounds().Dy() != tt.wantHeight {
+				t.Errorf("ResizeImage() size = %v, want %dx%d", got.Bounds().Size(), tt.wantWidth, tt.wantHeight)
+			}
+			for point, want := range tt.wantPixels {
+				if pixel := color.NRGBAModel.Convert(got.At(point.X, point.Y)); pixel != want {
+					t.Errorf("ResizeImage() pixel %v = %v, want %v", point, pixel, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenImage(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(10, 10, 12, 11))
+	src.SetNRGBA(11, 10, color.NRGBA{R: 255, A: 255})
+	got := FlattenImage(src)
+	if got.Bounds() != image.Rect(0, 0, 2, 1) {
+		t.Errorf("FlattenImage() bounds = %v, want %v", got.Bounds(), image.Rect(0, 0, 2, 1))
+	}
+	want := map[image.Point]color.RGBA{
+		{X: 0, Y: 0}: {R: 255, G: 255, B: 255, A: 255},
+		{X: 1, Y: 0}: {R: 255, G: 0, B: 0, A: 255},
+	}
+	for point, wantPixel := range want {
+		if pixel := got.RGBAAt(point.X, point.Y); pixel != wantPixel {
+			t.Errorf("FlattenImage() pixel %v = %v, want %v", point, pixel, wantPixel)
+		}
+	}
+}